@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// Export the access policy dependency graph of a set of Applications and Naisjobs.
+//
+// Input files contain YAML documents with Applications, Naisjobs, or lists of these,
+// such as the output of `kubectl get applications,naisjobs --all-namespaces -o yaml`.
+
+type Config struct {
+	Cluster string
+	Format  string
+	Output  string
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+func main() {
+	err := run()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg := &Config{
+		Format: "dot",
+	}
+	pflag.StringVar(&cfg.Cluster, "cluster", cfg.Cluster, "name of the cluster the resources are running in")
+	pflag.StringVar(&cfg.Format, "format", cfg.Format, "output format, either 'dot' or 'json'")
+	pflag.StringVar(&cfg.Output, "output", cfg.Output, "output file; defaults to standard output")
+	pflag.Parse()
+
+	if pflag.NArg() == 0 {
+		return fmt.Errorf("usage: %s [flags] FILE...", os.Args[0])
+	}
+
+	nodes := make([]accesspolicy.Node, 0)
+	for _, path := range pflag.Args() {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, document := range documentSeparator.Split(string(data), -1) {
+			parsed, err := parse([]byte(document), cfg.Cluster)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			nodes = append(nodes, parsed...)
+		}
+	}
+
+	graph := accesspolicy.NewGraph(nodes)
+
+	var w io.Writer = os.Stdout
+	if len(cfg.Output) > 0 {
+		file, err := os.Create(cfg.Output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	switch cfg.Format {
+	case "dot":
+		return graph.WriteDOT(w)
+	case "json":
+		return graph.WriteJSON(w)
+	default:
+		return fmt.Errorf("unsupported output format '%s'", cfg.Format)
+	}
+}
+
+func parse(document []byte, cluster string) ([]accesspolicy.Node, error) {
+	if len(bytes.TrimSpace(document)) == 0 {
+		return nil, nil
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	err := yaml.Unmarshal(document, typeMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typeMeta.Kind {
+	case "Application":
+		app := nais_io_v1alpha1.Application{}
+		err = yaml.Unmarshal(document, &app)
+		return accesspolicy.ApplicationNodes(cluster, []nais_io_v1alpha1.Application{app}), err
+	case "ApplicationList":
+		list := nais_io_v1alpha1.ApplicationList{}
+		err = yaml.Unmarshal(document, &list)
+		return accesspolicy.ApplicationNodes(cluster, list.Items), err
+	case "Naisjob":
+		job := nais_io_v1.Naisjob{}
+		err = yaml.Unmarshal(document, &job)
+		return accesspolicy.NaisjobNodes(cluster, []nais_io_v1.Naisjob{job}), err
+	case "NaisjobList":
+		list := nais_io_v1.NaisjobList{}
+		err = yaml.Unmarshal(document, &list)
+		return accesspolicy.NaisjobNodes(cluster, list.Items), err
+	case "List":
+		list := struct {
+			Items []runtime.RawExtension `json:"items"`
+		}{}
+		err = yaml.Unmarshal(document, &list)
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]accesspolicy.Node, 0, len(list.Items))
+		for _, item := range list.Items {
+			parsed, err := parse(item.Raw, cluster)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, parsed...)
+		}
+		return nodes, nil
+	default:
+		log.Warnf("skipping unsupported kind '%s'", typeMeta.Kind)
+		return nil, nil
+	}
+}
@@ -38,8 +38,12 @@ type DefaultableResource interface {
 	ApplyDefaults() error
 }
 
+type ProfileDefaultableResource interface {
+	ApplyDefaults(profiles ...*nais_io_v1.DefaultsProfile) error
+}
+
 type DocumentableResource struct {
-	Resource      DefaultableResource
+	Resource      interface{}
 	ExampleGetter func() interface{}
 }
 
@@ -65,6 +69,7 @@ type Config struct {
 	BaseClass         string
 	Group             string
 	Kind              string
+	Version           string
 	ReferenceOutput   string
 	ExampleOutput     string
 	ReferenceTemplate string
@@ -133,6 +138,7 @@ func run() error {
 	pflag.StringVar(&cfg.Directory, "dir", cfg.Directory, "directory with packages")
 	pflag.StringVar(&cfg.Group, "group", cfg.Group, "which group to generate documentation for")
 	pflag.StringVar(&cfg.Kind, "kind", cfg.Kind, "which kind to generate documentation for")
+	pflag.StringVar(&cfg.Version, "version", cfg.Version, "which version to generate documentation for, if the kind exists in multiple versions")
 	pflag.StringVar(&cfg.ReferenceOutput, "reference-output", cfg.ReferenceOutput, "reference doc markdown output file")
 	pflag.StringVar(&cfg.ExampleOutput, "example-output", cfg.ExampleOutput, "example yaml markdown output file")
 	pflag.StringVar(&cfg.ReferenceTemplate, "reference-template", cfg.ReferenceTemplate, "template file for rendering reference doc")
@@ -194,7 +200,14 @@ func run() error {
 	if !ok {
 		return fmt.Errorf("kind '%s' is not supported; needs populator config in docgen.go", cfg.Kind)
 	}
-	err = resourcer.Resource.ApplyDefaults()
+	switch resource := resourcer.Resource.(type) {
+	case DefaultableResource:
+		err = resource.ApplyDefaults()
+	case ProfileDefaultableResource:
+		err = resource.ApplyDefaults()
+	default:
+		err = fmt.Errorf("kind '%s' does not implement ApplyDefaults; the documented defaults would be missing", cfg.Kind)
+	}
 	if err != nil {
 		return err
 	}
@@ -207,12 +220,15 @@ func run() error {
 		return err
 	}
 
-	if cfg.JSONSchema != "" && len(pars.FlattenedSchemata) > 1 {
+	if cfg.JSONSchema != "" && len(cfg.Version) == 0 && len(pars.FlattenedSchemata) > 1 {
 		fmt.Fprintln(os.Stderr, "More than one schema, skipping the json schema")
 		cfg.JSONSchema = ""
 	}
 
-	for _, schemata := range pars.FlattenedSchemata {
+	for typ, schemata := range pars.FlattenedSchemata {
+		if len(cfg.Version) > 0 && pars.GroupVersions[typ.Package].Version != cfg.Version {
+			continue
+		}
 		err = Write(WriteReferenceDoc, cfg.ReferenceTemplate, cfg.ReferenceOutput, schemata.Properties["spec"])
 		if err != nil {
 			return err
@@ -0,0 +1,93 @@
+// Package accesspolicy translates NAIS access policies into the network and service mesh
+// resources enforcing them, so that all operators enforce access policies identically.
+package accesspolicy
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+const (
+	// AppLabel selects the pods of an application.
+	AppLabel = "app"
+	// NamespaceLabel selects a namespace by name.
+	NamespaceLabel = "name"
+	// AllApplications may be used as the application name in a rule to match every application in a namespace.
+	AllApplications = "*"
+)
+
+// Options contains the cluster specific settings used when translating an access policy.
+type Options struct {
+	// Name of the cluster the policy is enforced in. Rules targeting other clusters are ignored.
+	ClusterName string
+	// Additional peers all applications may reach, such as a web proxy. DNSEgressPeer is always included.
+	EgressPeers []EgressPeer
+	// Additional peers that may reach all applications, such as Prometheus.
+	IngressPeers []IngressPeer
+	// Peers that may reach applications exposed through ingresses, such as the ingress gateway.
+	IngressGatewayPeers []IngressPeer
+}
+
+// Peers allowed to reach the workload regardless of its access policy.
+func (in Options) ingressPeers(workload Workload) []IngressPeer {
+	peers := make([]IngressPeer, 0, len(in.IngressPeers)+len(in.IngressGatewayPeers))
+	peers = append(peers, in.IngressPeers...)
+	if workload.Exposed {
+		peers = append(peers, in.IngressGatewayPeers...)
+	}
+	return peers
+}
+
+// Workload identifies the application an access policy belongs to.
+type Workload struct {
+	Name      string
+	Namespace string
+	// Template for the metadata of generated resources. Name and namespace are overwritten.
+	ObjectMeta metav1.ObjectMeta
+	// Whether the workload is exposed through ingresses, and must be reachable from the ingress gateway peers.
+	Exposed bool
+}
+
+func (in Workload) objectMeta(name string) metav1.ObjectMeta {
+	meta := *in.ObjectMeta.DeepCopy()
+	meta.Name = name
+	meta.Namespace = in.Namespace
+	return meta
+}
+
+// Rules that do not match the cluster are dropped, and empty namespaces are replaced with the workload's namespace.
+func localRules(rules []nais_io_v1.AccessPolicyRule, namespace, clusterName string) []nais_io_v1.AccessPolicyRule {
+	local := make([]nais_io_v1.AccessPolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.MatchesCluster(clusterName) {
+			continue
+		}
+		if len(rule.Namespace) == 0 {
+			rule.Namespace = namespace
+		}
+		local = append(local, rule)
+	}
+	return local
+}
+
+func inboundRules(policy *nais_io_v1.AccessPolicy) []nais_io_v1.AccessPolicyRule {
+	if policy == nil || policy.Inbound == nil {
+		return nil
+	}
+	return policy.Inbound.Rules.GetRules()
+}
+
+func outboundRules(policy *nais_io_v1.AccessPolicy) []nais_io_v1.AccessPolicyRule {
+	if policy == nil || policy.Outbound == nil {
+		return nil
+	}
+	return policy.Outbound.Rules.GetRules()
+}
+
+func externalRules(policy *nais_io_v1.AccessPolicy) []nais_io_v1.AccessPolicyExternalRule {
+	if policy == nil || policy.Outbound == nil {
+		return nil
+	}
+	return policy.Outbound.External
+}
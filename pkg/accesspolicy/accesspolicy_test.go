@@ -0,0 +1,192 @@
+package accesspolicy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+var workload = accesspolicy.Workload{
+	Name:      "myapp",
+	Namespace: "myteam",
+	ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{"team": "myteam"},
+	},
+}
+
+func policy() *nais_io_v1.AccessPolicy {
+	return &nais_io_v1.AccessPolicy{
+		Inbound: &nais_io_v1.AccessPolicyInbound{
+			Rules: []nais_io_v1.AccessPolicyInboundRule{
+				{AccessPolicyRule: nais_io_v1.AccessPolicyRule{Application: "frontend"}},
+				{AccessPolicyRule: nais_io_v1.AccessPolicyRule{Application: "*", Namespace: "otherteam"}},
+				{AccessPolicyRule: nais_io_v1.AccessPolicyRule{Application: "remote", Cluster: "other-cluster"}},
+			},
+		},
+		Outbound: &nais_io_v1.AccessPolicyOutbound{
+			Rules: []nais_io_v1.AccessPolicyRule{
+				{Application: "backend", Namespace: "otherteam", Cluster: "test-cluster"},
+			},
+			External: []nais_io_v1.AccessPolicyExternalRule{
+				{Host: "www.example.com"},
+				{Host: "db.example.com", Ports: []nais_io_v1.AccessPolicyPortRule{{Name: "postgres", Port: 5432, Protocol: "TCP"}}},
+			},
+		},
+	}
+}
+
+func peer(app, namespace string) networkingv1.NetworkPolicyPeer {
+	selector := &metav1.LabelSelector{}
+	if len(app) > 0 {
+		selector.MatchLabels = map[string]string{accesspolicy.AppLabel: app}
+	}
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: selector,
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{accesspolicy.NamespaceLabel: namespace},
+		},
+	}
+}
+
+func TestNetworkPolicy(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	proxyPort := intstr.FromInt(8088)
+	proxy := accesspolicy.EgressPeer{
+		Peer:  peer("webproxy", "nais-system"),
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &proxyPort}},
+	}
+
+	np := accesspolicy.NetworkPolicy(workload, policy(), accesspolicy.Options{
+		ClusterName: "test-cluster",
+		EgressPeers: []accesspolicy.EgressPeer{proxy},
+	})
+
+	assert.Equal(t, "myapp", np.Name)
+	assert.Equal(t, "myteam", np.Namespace)
+	assert.Equal(t, "myteam", np.Labels["team"])
+	assert.Equal(t, map[string]string{accesspolicy.AppLabel: "myapp"}, np.Spec.PodSelector.MatchLabels)
+
+	require.Len(t, np.Spec.Ingress, 1)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+		peer("frontend", "myteam"),
+		peer("", "otherteam"),
+	}, np.Spec.Ingress[0].From)
+
+	require.Len(t, np.Spec.Egress, 3)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{accesspolicy.DNSEgressPeer.Peer}, np.Spec.Egress[0].To)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{peer("backend", "otherteam")}, np.Spec.Egress[1].To)
+	assert.Equal(t, proxy.Peer, np.Spec.Egress[2].To[0])
+	assert.Equal(t, proxy.Ports, np.Spec.Egress[2].Ports)
+}
+
+func TestNetworkPolicy_NilPolicy(t *testing.T) {
+	np := accesspolicy.NetworkPolicy(workload, nil, accesspolicy.Options{})
+	assert.Empty(t, np.Spec.Ingress)
+	require.Len(t, np.Spec.Egress, 1, "deny all traffic except DNS lookups")
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{accesspolicy.DNSEgressPeer.Peer}, np.Spec.Egress[0].To)
+	assert.Len(t, np.Spec.Egress[0].Ports, 2)
+	assert.Len(t, np.Spec.PolicyTypes, 2)
+}
+
+func TestNetworkPolicy_IngressPeers(t *testing.T) {
+	metricsPort := intstr.FromString("http")
+	prometheus := accesspolicy.IngressPeer{
+		Peer:  peer("prometheus", "nais-system"),
+		Ports: []networkingv1.NetworkPolicyPort{{Port: &metricsPort}},
+	}
+	gateway := accesspolicy.IngressPeer{Peer: peer("istio-ingressgateway", "istio-system")}
+	opts := accesspolicy.Options{
+		IngressPeers:        []accesspolicy.IngressPeer{prometheus},
+		IngressGatewayPeers: []accesspolicy.IngressPeer{gateway},
+	}
+
+	np := accesspolicy.NetworkPolicy(workload, nil, opts)
+	require.Len(t, np.Spec.Ingress, 1, "ingress gateway is only allowed for exposed workloads")
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{prometheus.Peer}, np.Spec.Ingress[0].From)
+	assert.Equal(t, prometheus.Ports, np.Spec.Ingress[0].Ports)
+
+	exposed := workload
+	exposed.Exposed = true
+	np = accesspolicy.NetworkPolicy(exposed, nil, opts)
+	require.Len(t, np.Spec.Ingress, 2)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{gateway.Peer}, np.Spec.Ingress[1].From)
+}
+
+func TestAuthorizationPolicy(t *testing.T) {
+	ap, err := accesspolicy.AuthorizationPolicy(workload, policy(), accesspolicy.Options{ClusterName: "test-cluster"})
+	require.NoError(t, err)
+
+	assert.Equal(t, accesspolicy.AuthorizationPolicyGVK, ap.GroupVersionKind())
+	assert.Equal(t, "myapp", ap.GetName())
+	assert.Equal(t, "myteam", ap.GetNamespace())
+
+	rules, found, err := unstructured.NestedSlice(ap.Object, "spec", "rules")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, rules, 1)
+
+	from := rules[0].(map[string]interface{})["from"].([]interface{})
+	require.Len(t, from, 2)
+	principals, _, _ := unstructured.NestedStringSlice(from[0].(map[string]interface{}), "source", "principals")
+	assert.Equal(t, []string{"cluster.local/ns/myteam/sa/frontend"}, principals)
+	namespaces, _, _ := unstructured.NestedStringSlice(from[1].(map[string]interface{}), "source", "namespaces")
+	assert.Equal(t, []string{"otherteam"}, namespaces)
+
+	// must survive a deep copy, i.e. only contain JSON compatible types
+	assert.Equal(t, ap, ap.DeepCopy())
+}
+
+func TestServiceEntries(t *testing.T) {
+	entries, err := accesspolicy.ServiceEntries(workload, policy())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		assert.Equal(t, accesspolicy.ServiceEntryGVK, entry.GroupVersionKind())
+		assert.Equal(t, "myteam", entry.GetNamespace())
+		assert.Equal(t, entry, entry.DeepCopy())
+	}
+
+	hosts, _, _ := unstructured.NestedStringSlice(entries[0].Object, "spec", "hosts")
+	assert.Equal(t, []string{"www.example.com"}, hosts)
+	ports, _, _ := unstructured.NestedSlice(entries[0].Object, "spec", "ports")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "https-443", "number": int64(443), "protocol": "HTTPS"}}, ports)
+
+	ports, _, _ = unstructured.NestedSlice(entries[1].Object, "spec", "ports")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "postgres", "number": int64(5432), "protocol": "TCP"}}, ports)
+
+	assert.NotEqual(t, entries[0].GetName(), entries[1].GetName())
+}
+
+func TestAuthorizationPolicy_IngressGateway(t *testing.T) {
+	exposed := workload
+	exposed.Exposed = true
+	gateway := accesspolicy.IngressPeer{
+		Peer:       peer("istio-ingressgateway", "istio-system"),
+		Principals: []string{"cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"},
+	}
+	opts := accesspolicy.Options{
+		IngressPeers:        []accesspolicy.IngressPeer{{Peer: peer("prometheus", "nais-system")}},
+		IngressGatewayPeers: []accesspolicy.IngressPeer{gateway},
+	}
+
+	ap, err := accesspolicy.AuthorizationPolicy(exposed, nil, opts)
+	require.NoError(t, err)
+
+	rules, _, err := unstructured.NestedSlice(ap.Object, "spec", "rules")
+	require.NoError(t, err)
+	require.Len(t, rules, 1, "peers without principals are left out")
+	from := rules[0].(map[string]interface{})["from"].([]interface{})
+	principals, _, _ := unstructured.NestedStringSlice(from[0].(map[string]interface{}), "source", "principals")
+	assert.Equal(t, gateway.Principals, principals)
+	assert.Equal(t, ap, ap.DeepCopy())
+}
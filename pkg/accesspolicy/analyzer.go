@@ -0,0 +1,188 @@
+package accesspolicy
+
+import (
+	"fmt"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// Node is an application or naisjob taking part in access policies.
+type Node struct {
+	Kind         string
+	Name         string
+	Namespace    string
+	Cluster      string
+	AccessPolicy *nais_io_v1.AccessPolicy
+}
+
+func (in Node) String() string {
+	return fmt.Sprintf("%s:%s:%s", in.Cluster, in.Namespace, in.Name)
+}
+
+func (in Node) key() nodeKey {
+	return nodeKey{cluster: in.Cluster, namespace: in.Namespace, name: in.Name}
+}
+
+type nodeKey struct {
+	cluster   string
+	namespace string
+	name      string
+}
+
+// ApplicationNodes returns a node for every application running in the given cluster.
+func ApplicationNodes(clusterName string, apps []nais_io_v1alpha1.Application) []Node {
+	nodes := make([]Node, len(apps))
+	for i, app := range apps {
+		nodes[i] = Node{
+			Kind:         "Application",
+			Name:         app.Name,
+			Namespace:    app.Namespace,
+			Cluster:      clusterName,
+			AccessPolicy: app.Spec.AccessPolicy,
+		}
+	}
+	return nodes
+}
+
+// NaisjobNodes returns a node for every naisjob running in the given cluster.
+func NaisjobNodes(clusterName string, jobs []nais_io_v1.Naisjob) []Node {
+	nodes := make([]Node, len(jobs))
+	for i, job := range jobs {
+		nodes[i] = Node{
+			Kind:         "Naisjob",
+			Name:         job.Name,
+			Namespace:    job.Namespace,
+			Cluster:      clusterName,
+			AccessPolicy: job.Spec.AccessPolicy,
+		}
+	}
+	return nodes
+}
+
+// Resolve fills in the namespace and cluster of a rule, which default to those of the node owning the rule.
+func Resolve(owner Node, rule nais_io_v1.AccessPolicyRule) nais_io_v1.AccessPolicyRule {
+	if len(rule.Namespace) == 0 {
+		rule.Namespace = owner.Namespace
+	}
+	if len(rule.Cluster) == 0 {
+		rule.Cluster = owner.Cluster
+	}
+	return rule
+}
+
+type Direction string
+
+const (
+	Inbound  Direction = "inbound"
+	Outbound Direction = "outbound"
+)
+
+type FindingType string
+
+const (
+	// An inbound rule without a matching outbound rule on the other application, or vice versa.
+	FindingOneSided FindingType = "OneSided"
+	// A rule pointing at an application that does not exist in any of the analyzed clusters.
+	FindingUnknownApplication FindingType = "UnknownApplication"
+	// A rule pointing at an application in another cluster.
+	FindingCrossCluster FindingType = "CrossCluster"
+)
+
+// Finding describes a single access policy rule that will not work as expected, or deserves attention.
+type Finding struct {
+	Type      FindingType
+	Node      Node
+	Direction Direction
+	// The offending rule, with namespace and cluster resolved.
+	Rule    nais_io_v1.AccessPolicyRule
+	Message string
+}
+
+func (in Finding) String() string {
+	return fmt.Sprintf("%s: %s rule on %s %s: %s", in.Type, in.Direction, in.Node.Kind, in.Node, in.Message)
+}
+
+// Analyze checks that the access policies of the given nodes agree with each other.
+// Traffic only flows if the inbound rules of the receiver and the outbound rules of the sender both allow it.
+//
+// Rules pointing at clusters that are not represented among the nodes can only be reported as cross-cluster rules.
+// Findings are returned in the order of the nodes and rules they concern.
+func Analyze(nodes []Node) []Finding {
+	index := make(map[nodeKey]Node, len(nodes))
+	clusters := make(map[string]bool)
+	namespaces := make(map[nodeKey]bool)
+	for _, node := range nodes {
+		index[node.key()] = node
+		clusters[node.Cluster] = true
+		namespaces[nodeKey{cluster: node.Cluster, namespace: node.Namespace}] = true
+	}
+
+	findings := make([]Finding, 0)
+	for _, node := range nodes {
+		check := func(direction Direction, rules []nais_io_v1.AccessPolicyRule, reverse func(Node) []nais_io_v1.AccessPolicyRule) {
+			for _, rule := range rules {
+				rule = Resolve(node, rule)
+				finding := Finding{Node: node, Direction: direction, Rule: rule}
+				target := nodeKey{cluster: rule.Cluster, namespace: rule.Namespace, name: rule.Application}
+
+				if !rule.MatchesCluster(node.Cluster) {
+					finding.Type = FindingCrossCluster
+					finding.Message = fmt.Sprintf("refers to %s in cluster %s", rule.Application, rule.Cluster)
+					findings = append(findings, finding)
+					if !clusters[rule.Cluster] {
+						continue
+					}
+				}
+
+				if rule.Application == AllApplications {
+					if !namespaces[nodeKey{cluster: rule.Cluster, namespace: rule.Namespace}] {
+						finding.Type = FindingUnknownApplication
+						finding.Message = fmt.Sprintf("no applications in namespace %s in cluster %s", rule.Namespace, rule.Cluster)
+						findings = append(findings, finding)
+					}
+					continue
+				}
+
+				other, found := index[target]
+				if !found {
+					finding.Type = FindingUnknownApplication
+					finding.Message = fmt.Sprintf("application %s does not exist", Node{Name: target.name, Namespace: target.namespace, Cluster: target.cluster})
+					findings = append(findings, finding)
+					continue
+				}
+
+				if !allows(other, reverse(other), node) {
+					finding.Type = FindingOneSided
+					finding.Message = fmt.Sprintf("%s %s has no matching %s rule", other.Kind, other, opposite(direction))
+					findings = append(findings, finding)
+				}
+			}
+		}
+		check(Inbound, inboundRules(node.AccessPolicy), func(n Node) []nais_io_v1.AccessPolicyRule { return outboundRules(n.AccessPolicy) })
+		check(Outbound, outboundRules(node.AccessPolicy), func(n Node) []nais_io_v1.AccessPolicyRule { return inboundRules(n.AccessPolicy) })
+	}
+
+	return findings
+}
+
+// Returns true if one of the owner's rules matches the peer.
+func allows(owner Node, rules []nais_io_v1.AccessPolicyRule, peer Node) bool {
+	for _, rule := range rules {
+		rule = Resolve(owner, rule)
+		if !rule.MatchesCluster(peer.Cluster) || rule.Namespace != peer.Namespace {
+			continue
+		}
+		if rule.Application == AllApplications || rule.Application == peer.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func opposite(direction Direction) Direction {
+	if direction == Inbound {
+		return Outbound
+	}
+	return Inbound
+}
@@ -0,0 +1,87 @@
+package accesspolicy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+func app(name, namespace string, inbound []nais_io_v1.AccessPolicyRule, outbound []nais_io_v1.AccessPolicyRule) nais_io_v1alpha1.Application {
+	inboundRules := make(nais_io_v1.AccessPolicyInboundRules, len(inbound))
+	for i := range inbound {
+		inboundRules[i].AccessPolicyRule = inbound[i]
+	}
+	return nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			AccessPolicy: &nais_io_v1.AccessPolicy{
+				Inbound:  &nais_io_v1.AccessPolicyInbound{Rules: inboundRules},
+				Outbound: &nais_io_v1.AccessPolicyOutbound{Rules: outbound},
+			},
+		},
+	}
+}
+
+type rules = []nais_io_v1.AccessPolicyRule
+
+func TestAnalyze(t *testing.T) {
+	apps := []nais_io_v1alpha1.Application{
+		// consistent pair, one using explicit namespace and cluster
+		app("frontend", "team-a", nil, rules{{Application: "backend"}}),
+		app("backend", "team-a", rules{{Application: "frontend", Namespace: "team-a", Cluster: "dev"}}, rules{{Application: "*", Namespace: "team-b"}}),
+		// accepts everything from team-a, and wildcard rules are never one-sided
+		app("api", "team-b", rules{{Application: "*", Namespace: "team-a"}}, nil),
+		// one-sided inbound rule, and a rule pointing at an unknown application
+		app("lonely", "team-b", rules{{Application: "frontend", Namespace: "team-a"}, {Application: "ghost"}}, nil),
+		// cross-cluster rules, one pointing at an analyzed cluster and one not
+		app("remote", "team-a", nil, rules{{Application: "backend", Cluster: "prod"}, {Application: "backend", Cluster: "other"}}),
+	}
+
+	jobs := []nais_io_v1.Naisjob{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "team-a"},
+			Spec: nais_io_v1.NaisjobSpec{
+				AccessPolicy: &nais_io_v1.AccessPolicy{
+					Outbound: &nais_io_v1.AccessPolicyOutbound{Rules: rules{{Application: "api", Namespace: "team-b"}}},
+				},
+			},
+		},
+	}
+
+	nodes := append(accesspolicy.ApplicationNodes("dev", apps), accesspolicy.NaisjobNodes("dev", jobs)...)
+	nodes = append(nodes, accesspolicy.ApplicationNodes("prod", []nais_io_v1alpha1.Application{
+		app("backend", "team-a", nil, nil),
+	})...)
+
+	findings := accesspolicy.Analyze(nodes)
+
+	type summary struct {
+		Type      accesspolicy.FindingType
+		Node      string
+		Direction accesspolicy.Direction
+		Target    string
+	}
+	summaries := make([]summary, len(findings))
+	for i, f := range findings {
+		summaries[i] = summary{f.Type, f.Node.String(), f.Direction, f.Rule.Application}
+	}
+
+	assert.Equal(t, []summary{
+		{accesspolicy.FindingOneSided, "dev:team-b:lonely", accesspolicy.Inbound, "frontend"},
+		{accesspolicy.FindingUnknownApplication, "dev:team-b:lonely", accesspolicy.Inbound, "ghost"},
+		{accesspolicy.FindingCrossCluster, "dev:team-a:remote", accesspolicy.Outbound, "backend"},
+		{accesspolicy.FindingOneSided, "dev:team-a:remote", accesspolicy.Outbound, "backend"},
+		{accesspolicy.FindingCrossCluster, "dev:team-a:remote", accesspolicy.Outbound, "backend"},
+	}, summaries)
+
+	assert.Equal(t, "team-b", findings[1].Rule.Namespace, "namespace defaults to the namespace of the owner")
+	assert.Equal(t, "team-a", findings[0].Rule.Namespace)
+	assert.Equal(t, "dev", findings[0].Rule.Cluster, "cluster defaults to the cluster of the owner")
+	assert.Equal(t, "prod", findings[2].Rule.Cluster)
+	assert.Equal(t, "other", findings[4].Rule.Cluster)
+}
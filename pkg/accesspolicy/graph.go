@@ -0,0 +1,262 @@
+package accesspolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// Kinds of graph nodes that do not correspond to an analyzed application or naisjob.
+const (
+	KindUnknown  = "Unknown"
+	KindExternal = "External"
+)
+
+// Graph is a directed dependency graph of applications, where edges point from the caller to the callee.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Cluster   string `json:"cluster,omitempty"`
+}
+
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// The callee's inbound rules allow the caller.
+	Inbound bool `json:"inbound"`
+	// The caller's outbound rules allow the callee.
+	Outbound bool `json:"outbound"`
+	// Custom scopes and roles granted to the caller by the callee.
+	Scopes []string `json:"scopes,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+// NewGraph builds a dependency graph from the access policies of the given nodes.
+// Inbound and outbound rules describing the same dependency are merged into a single edge.
+// Rule targets that are not among the nodes are included as nodes of kind Unknown,
+// and external hosts as nodes of kind External. Rules for all applications in a namespace
+// result in an edge to every node in that namespace, or to a single Unknown node if there are none.
+//
+// Nodes and edges are sorted by their identifiers.
+func NewGraph(nodes []Node) *Graph {
+	b := &graphBuilder{
+		nodes:      make(map[string]GraphNode),
+		edges:      make(map[[2]string]*GraphEdge),
+		namespaces: make(map[nodeKey][]string),
+	}
+
+	for _, node := range nodes {
+		b.addNode(GraphNode{ID: node.String(), Kind: node.Kind, Name: node.Name, Namespace: node.Namespace, Cluster: node.Cluster})
+		namespace := nodeKey{cluster: node.Cluster, namespace: node.Namespace}
+		b.namespaces[namespace] = append(b.namespaces[namespace], node.String())
+	}
+
+	for _, node := range nodes {
+		if node.AccessPolicy == nil {
+			continue
+		}
+		if node.AccessPolicy.Inbound != nil {
+			for _, rule := range node.AccessPolicy.Inbound.Rules {
+				for _, caller := range b.ruleNodes(node, rule.AccessPolicyRule) {
+					edge := b.edge(caller, node.String())
+					edge.Inbound = true
+					if rule.Permissions != nil {
+						edge.Scopes = appendPermissions(edge.Scopes, rule.Permissions.Scopes)
+						edge.Roles = appendPermissions(edge.Roles, rule.Permissions.Roles)
+					}
+				}
+			}
+		}
+		for _, rule := range outboundRules(node.AccessPolicy) {
+			for _, callee := range b.ruleNodes(node, rule) {
+				b.edge(node.String(), callee).Outbound = true
+			}
+		}
+		for _, external := range externalRules(node.AccessPolicy) {
+			b.addNode(GraphNode{ID: external.Host, Kind: KindExternal, Name: external.Host})
+			edge := b.edge(node.String(), external.Host)
+			// External hosts do not declare inbound rules.
+			edge.Inbound = true
+			edge.Outbound = true
+		}
+	}
+
+	return b.graph()
+}
+
+type graphBuilder struct {
+	nodes map[string]GraphNode
+	edges map[[2]string]*GraphEdge
+	// Identifiers of the analyzed nodes in every cluster and namespace.
+	namespaces map[nodeKey][]string
+}
+
+func (b *graphBuilder) addNode(node GraphNode) {
+	if _, found := b.nodes[node.ID]; !found {
+		b.nodes[node.ID] = node
+	}
+}
+
+// Returns the identifiers of the nodes a rule points to, adding an Unknown node to the graph if there are none.
+// The owner is not included in the targets of its own wildcard rules, so a wildcard rule for a namespace
+// containing only the owner points to an Unknown node as well.
+func (b *graphBuilder) ruleNodes(owner Node, rule nais_io_v1.AccessPolicyRule) []string {
+	rule = Resolve(owner, rule)
+	members, found := b.namespaces[nodeKey{cluster: rule.Cluster, namespace: rule.Namespace}]
+	if rule.Application == AllApplications && found {
+		targets := make([]string, 0, len(members))
+		for _, id := range members {
+			if id != owner.String() {
+				targets = append(targets, id)
+			}
+		}
+		if len(targets) > 0 {
+			return targets
+		}
+	}
+	target := Node{Kind: KindUnknown, Name: rule.Application, Namespace: rule.Namespace, Cluster: rule.Cluster}
+	b.addNode(GraphNode{ID: target.String(), Kind: target.Kind, Name: target.Name, Namespace: target.Namespace, Cluster: target.Cluster})
+	return []string{target.String()}
+}
+
+func (b *graphBuilder) edge(from, to string) *GraphEdge {
+	key := [2]string{from, to}
+	if b.edges[key] == nil {
+		b.edges[key] = &GraphEdge{From: from, To: to}
+	}
+	return b.edges[key]
+}
+
+func (b *graphBuilder) graph() *Graph {
+	g := &Graph{
+		Nodes: make([]GraphNode, 0, len(b.nodes)),
+		Edges: make([]GraphEdge, 0, len(b.edges)),
+	}
+	for _, node := range b.nodes {
+		g.Nodes = append(g.Nodes, node)
+	}
+	for _, edge := range b.edges {
+		g.Edges = append(g.Edges, *edge)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From == g.Edges[j].From {
+			return g.Edges[i].To < g.Edges[j].To
+		}
+		return g.Edges[i].From < g.Edges[j].From
+	})
+	return g
+}
+
+func appendPermissions(dst []string, permissions []nais_io_v1.AccessPolicyPermission) []string {
+	for _, permission := range permissions {
+		dst = append(dst, string(permission))
+	}
+	return dst
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (in *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(in)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language.
+// Nodes are grouped by cluster and namespace. One-sided edges are drawn dashed, and granted scopes and roles become edge labels.
+func (in *Graph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("digraph accesspolicy {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	groups := make(map[string][]GraphNode)
+	groupNames := make([]string, 0)
+	for _, node := range in.Nodes {
+		group := ""
+		if node.Kind != KindExternal {
+			group = fmt.Sprintf("%s:%s", node.Cluster, node.Namespace)
+		}
+		if _, found := groups[group]; !found {
+			groupNames = append(groupNames, group)
+		}
+		groups[group] = append(groups[group], node)
+	}
+	sort.Strings(groupNames)
+
+	for i, group := range groupNames {
+		indent := "  "
+		if len(group) > 0 {
+			fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", i)
+			fmt.Fprintf(&sb, "    label=%s;\n", quote(group))
+			indent = "    "
+		}
+		for _, node := range groups[group] {
+			fmt.Fprintf(&sb, "%s%s [%s];\n", indent, quote(node.ID), nodeAttributes(node))
+		}
+		if len(group) > 0 {
+			sb.WriteString("  }\n")
+		}
+	}
+
+	for _, edge := range in.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s [%s];\n", quote(edge.From), quote(edge.To), edgeAttributes(edge))
+	}
+
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func nodeAttributes(node GraphNode) string {
+	attrs := []string{"label=" + quote(node.Name)}
+	switch node.Kind {
+	case "Naisjob":
+		attrs = append(attrs, "shape=component")
+	case KindExternal:
+		attrs = append(attrs, "shape=ellipse")
+	case KindUnknown:
+		attrs = append(attrs, "style=dashed")
+	}
+	return strings.Join(attrs, ", ")
+}
+
+func edgeAttributes(edge GraphEdge) string {
+	attrs := make([]string, 0)
+	labels := make([]string, 0)
+	for _, scope := range edge.Scopes {
+		labels = append(labels, "scope:"+scope)
+	}
+	for _, role := range edge.Roles {
+		labels = append(labels, "role:"+role)
+	}
+	if len(labels) > 0 {
+		attrs = append(attrs, "label="+quote(strings.Join(labels, "\n")))
+	}
+	if !edge.Inbound || !edge.Outbound {
+		attrs = append(attrs, "style=dashed")
+	}
+	return strings.Join(attrs, ", ")
+}
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,109 @@
+package accesspolicy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+func graphNodes() []accesspolicy.Node {
+	frontend := app("frontend", "team-a", nil, rules{{Application: "backend"}})
+	frontend.Spec.AccessPolicy.Outbound.External = []nais_io_v1.AccessPolicyExternalRule{{Host: "www.example.com"}}
+
+	backend := app("backend", "team-a", nil, rules{{Application: "db", Namespace: "team-b"}})
+	backend.Spec.AccessPolicy.Inbound.Rules = nais_io_v1.AccessPolicyInboundRules{
+		{
+			AccessPolicyRule: nais_io_v1.AccessPolicyRule{Application: "frontend"},
+			Permissions: &nais_io_v1.AccessPolicyPermissions{
+				Scopes: []nais_io_v1.AccessPolicyPermission{"read"},
+				Roles:  []nais_io_v1.AccessPolicyPermission{"admin"},
+			},
+		},
+	}
+
+	return accesspolicy.ApplicationNodes("dev", []nais_io_v1alpha1.Application{frontend, backend})
+}
+
+func TestNewGraph(t *testing.T) {
+	graph := accesspolicy.NewGraph(graphNodes())
+
+	assert.Equal(t, []accesspolicy.GraphNode{
+		{ID: "dev:team-a:backend", Kind: "Application", Name: "backend", Namespace: "team-a", Cluster: "dev"},
+		{ID: "dev:team-a:frontend", Kind: "Application", Name: "frontend", Namespace: "team-a", Cluster: "dev"},
+		{ID: "dev:team-b:db", Kind: accesspolicy.KindUnknown, Name: "db", Namespace: "team-b", Cluster: "dev"},
+		{ID: "www.example.com", Kind: accesspolicy.KindExternal, Name: "www.example.com"},
+	}, graph.Nodes)
+
+	assert.Equal(t, []accesspolicy.GraphEdge{
+		{From: "dev:team-a:backend", To: "dev:team-b:db", Outbound: true},
+		{From: "dev:team-a:frontend", To: "dev:team-a:backend", Inbound: true, Outbound: true, Scopes: []string{"read"}, Roles: []string{"admin"}},
+		{From: "dev:team-a:frontend", To: "www.example.com", Inbound: true, Outbound: true},
+	}, graph.Edges)
+}
+
+func TestNewGraph_Wildcards(t *testing.T) {
+	nodes := accesspolicy.ApplicationNodes("dev", []nais_io_v1alpha1.Application{
+		app("frontend", "team-a", nil, rules{{Application: "*", Namespace: "team-b"}, {Application: "*", Namespace: "team-c"}}),
+		app("api", "team-b", rules{{Application: "*", Namespace: "team-a"}}, nil),
+		app("worker", "team-b", nil, nil),
+	})
+	graph := accesspolicy.NewGraph(nodes)
+
+	assert.Equal(t, []accesspolicy.GraphNode{
+		{ID: "dev:team-a:frontend", Kind: "Application", Name: "frontend", Namespace: "team-a", Cluster: "dev"},
+		{ID: "dev:team-b:api", Kind: "Application", Name: "api", Namespace: "team-b", Cluster: "dev"},
+		{ID: "dev:team-b:worker", Kind: "Application", Name: "worker", Namespace: "team-b", Cluster: "dev"},
+		{ID: "dev:team-c:*", Kind: accesspolicy.KindUnknown, Name: "*", Namespace: "team-c", Cluster: "dev"},
+	}, graph.Nodes, "wildcards only become unknown nodes in namespaces without other nodes")
+
+	assert.Equal(t, []accesspolicy.GraphEdge{
+		{From: "dev:team-a:frontend", To: "dev:team-b:api", Inbound: true, Outbound: true},
+		{From: "dev:team-a:frontend", To: "dev:team-b:worker", Outbound: true},
+		{From: "dev:team-a:frontend", To: "dev:team-c:*", Outbound: true},
+	}, graph.Edges)
+
+	t.Run("wildcard rules do not point at their owner", func(t *testing.T) {
+		nodes := accesspolicy.ApplicationNodes("dev", []nais_io_v1alpha1.Application{
+			app("api", "team-b", rules{{Application: "*"}}, nil),
+		})
+		graph := accesspolicy.NewGraph(nodes)
+		assert.Equal(t, []accesspolicy.GraphNode{
+			{ID: "dev:team-b:*", Kind: accesspolicy.KindUnknown, Name: "*", Namespace: "team-b", Cluster: "dev"},
+			{ID: "dev:team-b:api", Kind: "Application", Name: "api", Namespace: "team-b", Cluster: "dev"},
+		}, graph.Nodes, "namespaces containing only the owner are unknown")
+		assert.Equal(t, []accesspolicy.GraphEdge{
+			{From: "dev:team-b:*", To: "dev:team-b:api", Inbound: true},
+		}, graph.Edges)
+	})
+}
+
+func TestGraph_WriteJSON(t *testing.T) {
+	graph := accesspolicy.NewGraph(graphNodes())
+	buf := &bytes.Buffer{}
+	require.NoError(t, graph.WriteJSON(buf))
+
+	decoded := &accesspolicy.Graph{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, graph, decoded)
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	graph := accesspolicy.NewGraph(graphNodes())
+	buf := &bytes.Buffer{}
+	require.NoError(t, graph.WriteDOT(buf))
+
+	dot := buf.String()
+	assert.Contains(t, dot, `subgraph cluster_1 {`)
+	assert.Contains(t, dot, `label="dev:team-a";`)
+	assert.Contains(t, dot, `"dev:team-b:db" [label="db", style=dashed];`)
+	assert.Contains(t, dot, `"www.example.com" [label="www.example.com", shape=ellipse];`)
+	assert.Contains(t, dot, `"dev:team-a:frontend" -> "dev:team-a:backend" [label="scope:read\nrole:admin"];`)
+	assert.Contains(t, dot, `"dev:team-a:backend" -> "dev:team-b:db" [style=dashed];`)
+}
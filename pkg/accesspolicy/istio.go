@@ -0,0 +1,158 @@
+package accesspolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/nais/liberator/pkg/namegen"
+)
+
+var (
+	AuthorizationPolicyGVK = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1beta1", Kind: "AuthorizationPolicy"}
+	ServiceEntryGVK        = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1alpha3", Kind: "ServiceEntry"}
+)
+
+const (
+	DefaultExternalPort     = 443
+	DefaultExternalProtocol = "HTTPS"
+)
+
+// AuthorizationPolicy returns an Istio AuthorizationPolicy allowing requests from the
+// service accounts of the applications in the access policy's inbound rules,
+// and from the principals of the configured ingress peers.
+//
+// Applications are expected to run with a service account named after themselves.
+func AuthorizationPolicy(workload Workload, policy *nais_io_v1.AccessPolicy, opts Options) (*unstructured.Unstructured, error) {
+	rules := localRules(inboundRules(policy), workload.Namespace, opts.ClusterName)
+
+	principals := make([]interface{}, 0, len(rules))
+	namespaces := make([]interface{}, 0)
+	for _, rule := range rules {
+		if rule.Application == AllApplications {
+			namespaces = append(namespaces, rule.Namespace)
+			continue
+		}
+		principals = append(principals, fmt.Sprintf("cluster.local/ns/%s/sa/%s", rule.Namespace, rule.Application))
+	}
+
+	sources := make([]interface{}, 0, 2)
+	if len(principals) > 0 {
+		sources = append(sources, map[string]interface{}{
+			"source": map[string]interface{}{"principals": principals},
+		})
+	}
+	if len(namespaces) > 0 {
+		sources = append(sources, map[string]interface{}{
+			"source": map[string]interface{}{"namespaces": namespaces},
+		})
+	}
+
+	// A policy without rules denies all requests.
+	authzRules := make([]interface{}, 0, 1)
+	if len(sources) > 0 {
+		authzRules = append(authzRules, map[string]interface{}{"from": sources})
+	}
+	for _, peer := range opts.ingressPeers(workload) {
+		if len(peer.Principals) == 0 {
+			continue
+		}
+		peerPrincipals := make([]interface{}, 0, len(peer.Principals))
+		for _, principal := range peer.Principals {
+			peerPrincipals = append(peerPrincipals, principal)
+		}
+		authzRules = append(authzRules, map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{"principals": peerPrincipals},
+				},
+			},
+		})
+	}
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{AppLabel: workload.Name},
+		},
+		"action": "ALLOW",
+		"rules":  authzRules,
+	}
+
+	return newUnstructured(AuthorizationPolicyGVK, workload, workload.Name, spec)
+}
+
+// ServiceEntries returns one Istio ServiceEntry per external host in the access policy's outbound rules,
+// making the host reachable from the workload's namespace. Hosts without port rules default to HTTPS on port 443.
+func ServiceEntries(workload Workload, policy *nais_io_v1.AccessPolicy) ([]*unstructured.Unstructured, error) {
+	externals := externalRules(policy)
+	entries := make([]*unstructured.Unstructured, 0, len(externals))
+
+	for _, external := range externals {
+		if len(external.Host) == 0 {
+			return nil, fmt.Errorf("external rule has no host")
+		}
+
+		ports := make([]interface{}, 0, len(external.Ports))
+		for _, port := range external.Ports {
+			ports = append(ports, servicePort(port.Name, int64(port.Port), port.Protocol))
+		}
+		if len(ports) == 0 {
+			ports = append(ports, servicePort("", DefaultExternalPort, DefaultExternalProtocol))
+		}
+
+		spec := map[string]interface{}{
+			"hosts":      []interface{}{external.Host},
+			"location":   "MESH_EXTERNAL",
+			"resolution": "DNS",
+			"exportTo":   []interface{}{"."},
+			"ports":      ports,
+		}
+
+		name, err := namegen.ShortName(fmt.Sprintf("%s-%s", workload.Name, external.Host), validation.DNS1123SubdomainMaxLength)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := newUnstructured(ServiceEntryGVK, workload, name, spec)
+		if err != nil {
+			return nil, fmt.Errorf("service entry for %s: %w", external.Host, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func servicePort(name string, number int64, protocol string) map[string]interface{} {
+	if len(name) == 0 {
+		name = fmt.Sprintf("%s-%d", strings.ToLower(protocol), number)
+	}
+	return map[string]interface{}{
+		"name":     name,
+		"number":   number,
+		"protocol": protocol,
+	}
+}
+
+func newUnstructured(gvk schema.GroupVersionKind, workload Workload, name string, spec map[string]interface{}) (*unstructured.Unstructured, error) {
+	meta := workload.objectMeta(name)
+	metadata, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&meta)
+	if err != nil {
+		return nil, fmt.Errorf("convert object metadata: %w", err)
+	}
+	// Avoid emitting a null creation timestamp.
+	delete(metadata, "creationTimestamp")
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": metadata,
+			"spec":     spec,
+		},
+	}
+	obj.SetGroupVersionKind(gvk)
+	return obj, nil
+}
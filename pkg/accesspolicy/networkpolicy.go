@@ -0,0 +1,118 @@
+package accesspolicy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// EgressPeer is a destination that is always reachable, regardless of access policy.
+type EgressPeer struct {
+	Peer  networkingv1.NetworkPolicyPeer
+	Ports []networkingv1.NetworkPolicyPort
+}
+
+// DNSEgressPeer is the cluster DNS service, which every workload may reach.
+// It is always included in rendered network policies, as a policy without it breaks all name resolution.
+var DNSEgressPeer = EgressPeer{
+	Peer: networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"k8s-app": "kube-dns"},
+		},
+		NamespaceSelector: &metav1.LabelSelector{},
+	},
+	Ports: []networkingv1.NetworkPolicyPort{
+		dnsPort(corev1.ProtocolUDP),
+		dnsPort(corev1.ProtocolTCP),
+	},
+}
+
+func dnsPort(protocol corev1.Protocol) networkingv1.NetworkPolicyPort {
+	port := intstr.FromInt(53)
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &port,
+	}
+}
+
+// IngressPeer is a source that may always reach a workload, regardless of access policy.
+type IngressPeer struct {
+	Peer  networkingv1.NetworkPolicyPeer
+	Ports []networkingv1.NetworkPolicyPort
+	// Istio principals of the peer, such as `cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account`.
+	// Peers without principals are only allowed by the NetworkPolicy, not by the AuthorizationPolicy.
+	Principals []string
+}
+
+// NetworkPolicy returns a NetworkPolicy restricting traffic to and from the workload's pods
+// to the applications listed in the access policy. A nil policy denies all traffic,
+// except from the configured ingress peers, and to DNSEgressPeer and the configured egress peers.
+//
+// External hosts cannot be expressed as network policy peers, and must be enforced elsewhere, e.g. through ServiceEntries.
+func NetworkPolicy(workload Workload, policy *nais_io_v1.AccessPolicy, opts Options) *networkingv1.NetworkPolicy {
+	ingress := make([]networkingv1.NetworkPolicyIngressRule, 0)
+	if peers := networkPolicyPeers(localRules(inboundRules(policy), workload.Namespace, opts.ClusterName)); len(peers) > 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{From: peers})
+	}
+	for _, peer := range opts.ingressPeers(workload) {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  []networkingv1.NetworkPolicyPeer{*peer.Peer.DeepCopy()},
+			Ports: peer.Ports,
+		})
+	}
+
+	egress := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To:    []networkingv1.NetworkPolicyPeer{*DNSEgressPeer.Peer.DeepCopy()},
+			Ports: DNSEgressPeer.Ports,
+		},
+	}
+	if peers := networkPolicyPeers(localRules(outboundRules(policy), workload.Namespace, opts.ClusterName)); len(peers) > 0 {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{To: peers})
+	}
+	for _, peer := range opts.EgressPeers {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			To:    []networkingv1.NetworkPolicyPeer{*peer.Peer.DeepCopy()},
+			Ports: peer.Ports,
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: workload.objectMeta(workload.Name),
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{AppLabel: workload.Name},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Ingress: ingress,
+			Egress:  egress,
+		},
+	}
+}
+
+func networkPolicyPeers(rules []nais_io_v1.AccessPolicyRule) []networkingv1.NetworkPolicyPeer {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(rules))
+	for _, rule := range rules {
+		podSelector := &metav1.LabelSelector{}
+		if rule.Application != AllApplications {
+			podSelector.MatchLabels = map[string]string{AppLabel: rule.Application}
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: podSelector,
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{NamespaceLabel: rule.Namespace},
+			},
+		})
+	}
+	return peers
+}
@@ -0,0 +1,265 @@
+// Package alerttest evaluates the rules of an Alert against synthetic time series, in the manner of `promtool test rules`.
+//
+// Rules are rendered with render.AlertRules, so the labels and annotations of firing alerts are the ones
+// Prometheus would produce for the same Alert. Evaluation starts at the Unix epoch.
+package alerttest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/nais/liberator/pkg/promql"
+	"github.com/nais/liberator/pkg/render"
+)
+
+// Interval between input points and rule evaluations, unless specified by the test.
+const DefaultInterval = time.Minute
+
+// AlertnameLabel holds the name of the rule an alert originates from.
+const AlertnameLabel = "alertname"
+
+var epoch = time.Unix(0, 0).UTC()
+
+// Series is an input time series.
+type Series struct {
+	// Metric name and labels of the series, such as `up{app="myapp"}`.
+	Series string
+	// Values at every interval, starting at the epoch, in the expanding notation of promtool, such as `0+10x5`.
+	Values string
+}
+
+// Test evaluates the rules of an Alert against input series, and compares the alerts firing at given times with the expected ones.
+type Test struct {
+	// Interval between input points and rule evaluations. Defaults to DefaultInterval.
+	Interval    time.Duration
+	InputSeries []Series
+	Cases       []Case
+}
+
+// Case describes the alerts expected to fire for a single rule at a given time.
+type Case struct {
+	// Time since the epoch.
+	EvalTime time.Duration
+	// Name of the rule, as given in Rule.Alert.
+	Alertname      string
+	ExpectedAlerts []ExpectedAlert
+}
+
+type ExpectedAlert struct {
+	// Labels of the alert. The alertname label and the labels added when rendering the rule are implied, and may be omitted.
+	Labels map[string]string
+	// Expanded annotations of the alert. Annotations that are not listed are not compared.
+	Annotations map[string]string
+}
+
+// FiringAlert is an alert whose rule has been active for at least the duration in Rule.For.
+type FiringAlert struct {
+	Labels      map[string]string
+	Annotations map[string]string
+	// Time at which the expression of the rule started returning the alert.
+	ActiveAt time.Time
+	// Value of the expression at the time of evaluation.
+	Value float64
+}
+
+// Storage returns an in-memory store of the input series of a test.
+func (in Test) Storage() (*promql.Storage, error) {
+	storage := promql.NewStorage()
+	for i, series := range in.InputSeries {
+		labels, err := parseSeriesLabels(series.Series)
+		if err != nil {
+			return nil, fmt.Errorf("input series %d: %w", i, err)
+		}
+		values, err := parseValues(series.Values)
+		if err != nil {
+			return nil, fmt.Errorf("input series %d: %w", i, err)
+		}
+		for j, v := range values {
+			if v != nil {
+				storage.Append(labels, epoch.Add(time.Duration(j)*in.interval()), *v)
+			}
+		}
+	}
+	return storage, nil
+}
+
+// Evaluate returns the alerts firing at evalTime for the rule with the given name.
+// The rule is evaluated at every interval up to and including evalTime, so that the For duration is respected.
+func (in Test) Evaluate(alert *nais_io_v1.Alert, alertname string, evalTime time.Duration) ([]FiringAlert, error) {
+	var rule *render.AlertingRule
+	for _, group := range render.AlertRules(alert).Spec.Groups {
+		for i := range group.Rules {
+			if group.Rules[i].Alert == alertname {
+				rule = &group.Rules[i]
+			}
+		}
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("no rule named %q", alertname)
+	}
+
+	expr, err := promql.ParseExpr(rule.Expr)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", alertname, err)
+	}
+	holdDuration := time.Duration(0)
+	if len(rule.For) > 0 {
+		holdDuration, err = promql.ParseDuration(rule.For)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", alertname, err)
+		}
+	}
+
+	storage, err := in.Storage()
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]*FiringAlert)
+	for t := time.Duration(0); t <= evalTime; t += in.interval() {
+		ts := epoch.Add(t)
+		v, err := promql.Eval(storage, expr, ts)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q at %s: %w", alertname, t, err)
+		}
+
+		current := make(map[string]*FiringAlert, len(v))
+		for _, sample := range v {
+			firing, err := alertFromSample(rule, sample, ts)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q at %s: %w", alertname, t, err)
+			}
+			key := promql.Labels(firing.Labels).Key()
+			if previous, ok := active[key]; ok {
+				firing.ActiveAt = previous.ActiveAt
+			}
+			current[key] = firing
+		}
+		active = current
+	}
+
+	end := epoch.Add(evalTime)
+	result := make([]FiringAlert, 0, len(active))
+	for _, firing := range active {
+		if end.Sub(firing.ActiveAt) >= holdDuration {
+			result = append(result, *firing)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return promql.Labels(result[i].Labels).Key() < promql.Labels(result[j].Labels).Key()
+	})
+	return result, nil
+}
+
+// Run executes every case of the test as a subtest, and fails it if the firing alerts differ from the expected ones.
+func Run(t *testing.T, alert *nais_io_v1.Alert, test Test) {
+	for _, c := range test.Cases {
+		c := c
+		t.Run(fmt.Sprintf("%s at %s", c.Alertname, c.EvalTime), func(t *testing.T) {
+			firing, err := test.Evaluate(alert, c.Alertname, c.EvalTime)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			implied := impliedLabels(alert, c.Alertname)
+			expected := make([]map[string]string, 0, len(c.ExpectedAlerts))
+			for _, exp := range c.ExpectedAlerts {
+				labels := make(map[string]string)
+				for k, v := range implied {
+					labels[k] = v
+				}
+				for k, v := range exp.Labels {
+					labels[k] = v
+				}
+				expected = append(expected, labels)
+			}
+			actual := make([]map[string]string, 0, len(firing))
+			for _, f := range firing {
+				actual = append(actual, f.Labels)
+			}
+			if !assert.ElementsMatch(t, expected, actual, "firing alerts") {
+				return
+			}
+
+			for i, exp := range c.ExpectedAlerts {
+				for _, f := range firing {
+					if promql.Labels(f.Labels).Key() != promql.Labels(expected[i]).Key() {
+						continue
+					}
+					for name, annotation := range exp.Annotations {
+						assert.Equal(t, annotation, f.Annotations[name], "annotation %s of alert %v", name, f.Labels)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Labels added to every alert of a rule, regardless of the series it originates from.
+func impliedLabels(alert *nais_io_v1.Alert, alertname string) map[string]string {
+	labels := map[string]string{AlertnameLabel: alertname}
+	for _, group := range render.AlertRules(alert).Spec.Groups {
+		for _, rule := range group.Rules {
+			if rule.Alert == alertname {
+				for k, v := range rule.Labels {
+					labels[k] = v
+				}
+			}
+		}
+	}
+	return labels
+}
+
+func alertFromSample(rule *render.AlertingRule, sample promql.Sample, ts time.Time) (*FiringAlert, error) {
+	sampleLabels := sample.Labels.WithoutName()
+
+	labels := sampleLabels.Copy()
+	for k, v := range rule.Labels {
+		labels[k] = v
+	}
+	labels[AlertnameLabel] = rule.Alert
+
+	annotations := make(map[string]string, len(rule.Annotations))
+	for name, text := range rule.Annotations {
+		expanded, err := expand(text, sampleLabels, sample.V)
+		if err != nil {
+			return nil, fmt.Errorf("expand annotation %s: %w", name, err)
+		}
+		annotations[name] = expanded
+	}
+
+	return &FiringAlert{
+		Labels:      labels,
+		Annotations: annotations,
+		ActiveAt:    ts,
+		Value:       sample.V,
+	}, nil
+}
+
+func expand(text string, labels promql.Labels, value float64) (string, error) {
+	tmpl, err := promql.ParseTemplate("annotation", text)
+	if err != nil {
+		return "", err
+	}
+	data := promql.TemplateData{
+		Labels:         labels,
+		ExternalLabels: map[string]string{},
+		Value:          value,
+	}
+	var b strings.Builder
+	err = tmpl.Execute(&b, data)
+	return b.String(), err
+}
+
+func (in Test) interval() time.Duration {
+	if in.Interval == 0 {
+		return DefaultInterval
+	}
+	return in.Interval
+}
@@ -0,0 +1,144 @@
+package alerttest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nais/liberator/pkg/alerttest"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+func alert() *nais_io_v1.Alert {
+	return &nais_io_v1.Alert{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myalert",
+			Namespace: "myteam",
+		},
+		Spec: nais_io_v1.AlertSpec{
+			Alerts: []nais_io_v1.Rule{
+				{
+					Alert:       "app down",
+					Expr:        `up{app="myapp"} == 0`,
+					For:         "2m",
+					Description: `{{ $labels.app }} is down in {{ $labels.namespace }}`,
+					Action:      "kubectl describe pod -l app={{ $labels.app }}",
+				},
+				{
+					Alert:       "high error rate",
+					Expr:        `sum by (app) (rate(http_requests_total{code="500"}[5m])) / sum by (app) (rate(http_requests_total[5m])) > 0.1`,
+					For:         "0s",
+					Description: `error rate is {{ $value | humanizePercentage }}`,
+					Severity:    "warning",
+				},
+			},
+		},
+	}
+}
+
+var test = alerttest.Test{
+	InputSeries: []alerttest.Series{
+		{Series: `up{app="myapp", namespace="myteam"}`, Values: "1 1 0 0 0 _ 0 stale"},
+		{Series: `http_requests_total{app="myapp", code="200"}`, Values: "0+100x10"},
+		{Series: `http_requests_total{app="myapp", code="500"}`, Values: "0x5 10+20x5"},
+	},
+}
+
+func TestRun(t *testing.T) {
+	test := test
+	test.Cases = []alerttest.Case{
+		{
+			EvalTime:  3 * time.Minute,
+			Alertname: "app down",
+		},
+		{
+			EvalTime:  4 * time.Minute,
+			Alertname: "app down",
+			ExpectedAlerts: []alerttest.ExpectedAlert{
+				{
+					Labels: map[string]string{"app": "myapp", "namespace": "myteam"},
+					Annotations: map[string]string{
+						"description": "myapp is down in myteam",
+						"action":      "kubectl describe pod -l app=myapp",
+					},
+				},
+			},
+		},
+		{
+			EvalTime:  6 * time.Minute,
+			Alertname: "app down",
+			ExpectedAlerts: []alerttest.ExpectedAlert{
+				{Labels: map[string]string{"app": "myapp", "namespace": "myteam"}},
+			},
+		},
+		{
+			EvalTime:  7 * time.Minute,
+			Alertname: "app down",
+		},
+		{
+			EvalTime:  5 * time.Minute,
+			Alertname: "high error rate",
+		},
+		{
+			EvalTime:  10 * time.Minute,
+			Alertname: "high error rate",
+			ExpectedAlerts: []alerttest.ExpectedAlert{
+				{
+					Labels:      map[string]string{"app": "myapp", "severity": "warning"},
+					Annotations: map[string]string{"description": "error rate is 15.25%"},
+				},
+			},
+		},
+	}
+	alerttest.Run(t, alert(), test)
+}
+
+func TestTest_Evaluate(t *testing.T) {
+	firing, err := test.Evaluate(alert(), "app down", 4*time.Minute)
+	require.NoError(t, err)
+	require.Len(t, firing, 1)
+	assert.Equal(t, map[string]string{
+		"alertname": "app down",
+		"alert":     "myalert",
+		"team":      "myteam",
+		"severity":  "danger",
+		"app":       "myapp",
+		"namespace": "myteam",
+	}, firing[0].Labels)
+	assert.Equal(t, time.Unix(120, 0).UTC(), firing[0].ActiveAt)
+	assert.Equal(t, 0.0, firing[0].Value)
+
+	_, err = test.Evaluate(alert(), "no such rule", 0)
+	assert.EqualError(t, err, `no rule named "no such rule"`)
+
+	broken := test
+	broken.InputSeries = []alerttest.Series{{Series: `up{app=~"x"}`, Values: "1"}}
+	_, err = broken.Evaluate(alert(), "app down", 0)
+	assert.Error(t, err)
+
+	broken.InputSeries = []alerttest.Series{{Series: `up`, Values: "1 two"}}
+	_, err = broken.Evaluate(alert(), "app down", 0)
+	assert.Error(t, err)
+}
+
+func TestTest_Storage(t *testing.T) {
+	storage, err := alerttest.Test{
+		Interval: 30 * time.Second,
+		InputSeries: []alerttest.Series{
+			{Series: `x`, Values: "1 _x2 5-1x2 1x1"},
+		},
+	}.Storage()
+	require.NoError(t, err)
+
+	series := storage.Select(nil, time.Unix(-1, 0), time.Unix(3600, 0))
+	require.Len(t, series, 1)
+
+	values := make(map[int64]float64)
+	for _, point := range series[0].Points {
+		values[point.T.Unix()] = point.V
+	}
+	assert.Equal(t, map[int64]float64{0: 1, 90: 5, 120: 4, 150: 3, 180: 1, 210: 1}, values)
+}
@@ -0,0 +1,99 @@
+package alerttest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nais/liberator/pkg/promql"
+)
+
+const number = `[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?`
+
+var (
+	expandingRegex = regexp.MustCompile(`^(` + number + `)(?:([+-])(` + number + `))?x(\d+)$`)
+	missingRegex   = regexp.MustCompile(`^_x(\d+)$`)
+)
+
+// Parses the expanding notation used by `promtool test rules`. Missing points are returned as nil.
+//
+//	1 2 3      three points
+//	0+10x3     0 10 20 30
+//	5-1x2      5 4 3
+//	1x2        1 1 1
+//	_          one missing point
+//	_x3        three missing points
+//	stale      a staleness marker
+func parseValues(input string) ([]*float64, error) {
+	values := make([]*float64, 0)
+	for _, token := range strings.Fields(input) {
+		switch {
+		case token == "_":
+			values = append(values, nil)
+
+		case token == "stale":
+			values = append(values, float(promql.StaleNaN))
+
+		case missingRegex.MatchString(token):
+			n, _ := strconv.Atoi(missingRegex.FindStringSubmatch(token)[1])
+			for i := 0; i < n; i++ {
+				values = append(values, nil)
+			}
+
+		case expandingRegex.MatchString(token):
+			match := expandingRegex.FindStringSubmatch(token)
+			start, _ := strconv.ParseFloat(match[1], 64)
+			increment := 0.0
+			if len(match[3]) > 0 {
+				increment, _ = strconv.ParseFloat(match[3], 64)
+				if match[2] == "-" {
+					increment = -increment
+				}
+			}
+			n, err := strconv.Atoi(match[4])
+			if err != nil {
+				return nil, fmt.Errorf("invalid repetition in %q: %w", token, err)
+			}
+			for i := 0; i <= n; i++ {
+				values = append(values, float(start+float64(i)*increment))
+			}
+
+		default:
+			v, err := strconv.ParseFloat(token, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", token)
+			}
+			values = append(values, float(v))
+		}
+	}
+	return values, nil
+}
+
+// Parses a series selector such as `up{app="myapp"}` into the labels of the series.
+func parseSeriesLabels(input string) (promql.Labels, error) {
+	expr, err := promql.ParseExpr(input)
+	if err != nil {
+		return nil, err
+	}
+	selector, ok := expr.(*promql.VectorSelector)
+	if !ok || selector.Offset != 0 || selector.At != nil {
+		return nil, fmt.Errorf("series must be a metric name with optional labels, such as `up{app=\"myapp\"}`")
+	}
+
+	labels := promql.Labels{}
+	if len(selector.Name) > 0 {
+		labels[promql.MetricNameLabel] = selector.Name
+	}
+	for _, matcher := range selector.Matchers {
+		if matcher.Type != promql.MatchEqual {
+			return nil, fmt.Errorf("series labels must use the %q operator, got %q", promql.MatchEqual, matcher.Type)
+		}
+		labels[matcher.Name] = matcher.Value
+	}
+	return labels, nil
+}
+
+func float(v float64) *float64 {
+	return &v
+}
@@ -1,6 +1,7 @@
 package aiven_nais_io_v1
 
 import (
+	"github.com/nais/liberator/pkg/conditions"
 	"github.com/nais/liberator/pkg/namegen"
 	"github.com/nais/liberator/pkg/strings"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ type AivenApplicationList struct {
 	Items           []AivenApplication `json:"items"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName={"aivenapp"}
 // +kubebuilder:subresource:status
@@ -47,6 +49,9 @@ type AivenApplicationSpec struct {
 	Protected bool `json:"protected,omitempty"`
 	// Kafka is a section configuring the kafka credentials to provision
 	Kafka KafkaSpec `json:"kafka,omitempty"`
+	// SecretKeyPrefix is an optional user-defined prefix applied to the keys in the secret output, replacing the default prefix.
+	// +kubebuilder:validation:Pattern=`^([A-Za-z]|[A-Za-z_][A-Za-z0-9_]*[A-Za-z0-9])$`
+	SecretKeyPrefix string `json:"secretKeyPrefix,omitempty"`
 }
 
 type KafkaSpec struct {
@@ -79,6 +84,8 @@ type AivenApplicationCondition struct {
 type AivenApplicationStatus struct {
 	// SynchronizationHash is the hash of the AivenApplication object most recently successfully synchronized
 	SynchronizationHash string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
 	// SynchronizationSecretName is the SecretName set in the last successful synchronization
 	SynchronizationSecretName string `json:"synchronizationSecretName,omitempty"`
 	// SynchronizationState denotes whether the provisioning of the AivenApplication has been successfully completed or not
@@ -120,7 +127,7 @@ func (in *AivenApplicationStatus) AddCondition(condition AivenApplicationConditi
 	for _, dropType := range dropTypes {
 		dropTypeStrings = append(dropTypeStrings, string(dropType))
 	}
-	condition.LastUpdateTime = metav1.Time{time.Now()}
+	condition.LastUpdateTime = metav1.Time{Time: time.Now()}
 	conditions := make([]AivenApplicationCondition, 0, len(in.Conditions))
 	for _, c := range in.Conditions {
 		if strings.ContainsString(dropTypeStrings, string(c.Type)) {
@@ -142,3 +149,43 @@ func (in *AivenApplicationStatus) GetConditionOfType(conditionType AivenApplicat
 	}
 	return nil
 }
+
+// GetConditions implements conditions.Status.
+// AivenApplication conditions predate the shared condition type; the last update time is reported as the transition time.
+func (in *AivenApplicationStatus) GetConditions() []conditions.Condition {
+	result := make([]conditions.Condition, len(in.Conditions))
+	for i, c := range in.Conditions {
+		result[i] = conditions.Condition{
+			Type:               conditions.ConditionType(c.Type),
+			Status:             c.Status,
+			ObservedGeneration: in.ObservedGeneration,
+			LastTransitionTime: c.LastUpdateTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		}
+	}
+	return result
+}
+
+// SetConditions implements conditions.Status.
+func (in *AivenApplicationStatus) SetConditions(conds []conditions.Condition) {
+	result := make([]AivenApplicationCondition, len(conds))
+	for i, c := range conds {
+		result[i] = AivenApplicationCondition{
+			Type:           AivenApplicationConditionType(c.Type),
+			Status:         c.Status,
+			LastUpdateTime: c.LastTransitionTime,
+			Reason:         c.Reason,
+			Message:        c.Message,
+		}
+	}
+	in.Conditions = result
+}
+
+func (in *AivenApplicationStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *AivenApplicationStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
+}
@@ -15,6 +15,12 @@ func Test_hash(t *testing.T) {
 				SecretName: "this-is-my-secret",
 			},
 		}, want: "a26742b533308093", wantErr: false},
+		{name: "AivenApplicationWithSecretKeyPrefix", aivenapp: &AivenApplication{
+			Spec: AivenApplicationSpec{
+				SecretName:      "this-is-my-secret",
+				SecretKeyPrefix: "MY_KAFKA",
+			},
+		}, want: "5b7282c5e7d78450", wantErr: false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,38 @@
+package aiven_nais_io_v1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/nais/liberator/pkg/credentials"
+)
+
+// +kubebuilder:webhook:path=/validate-aiven-nais-io-v1-aivenapplication,mutating=false,failurePolicy=fail,groups=aiven.nais.io,resources=aivenapplications,verbs=create;update,versions=v1,name=vaivenapplication.aiven.nais.io
+
+var _ webhook.Validator = &AivenApplication{}
+
+// ValidateSecretKeyPrefix checks that the secret key prefix yields valid environment variable names.
+func (in *AivenApplication) ValidateSecretKeyPrefix() field.ErrorList {
+	return credentials.ValidatePrefix(credentials.KafkaCredentials{}, in.Spec.SecretKeyPrefix, field.NewPath("spec", "secretKeyPrefix"))
+}
+
+// ValidateCreate implements webhook.Validator.
+func (in *AivenApplication) ValidateCreate() error {
+	errs := in.ValidateSecretKeyPrefix()
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("AivenApplication").GroupKind(), in.GetName(), errs)
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (in *AivenApplication) ValidateUpdate(old runtime.Object) error {
+	return in.ValidateCreate()
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is always allowed.
+func (in *AivenApplication) ValidateDelete() error {
+	return nil
+}
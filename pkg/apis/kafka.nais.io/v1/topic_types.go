@@ -4,8 +4,11 @@ import (
 	"fmt"
 	aiven_nais_io_v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
 	"strconv"
+	"time"
 
+	"github.com/nais/liberator/pkg/conditions"
 	"github.com/nais/liberator/pkg/namegen"
+	"github.com/nais/liberator/pkg/resync"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,6 +37,8 @@ type TopicList struct {
 	Items           []Topic `json:"items"`
 }
 
+// +genclient
+// +genclient:noStatus
 // +kubebuilder:object:root=true
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.synchronizationState"
@@ -94,6 +99,26 @@ type TopicStatus struct {
 	Errors                []string `json:"errors,omitempty"`
 	Message               string   `json:"message,omitempty"`
 	FullyQualifiedName    string   `json:"fullyQualifiedName,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *TopicStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *TopicStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *TopicStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *TopicStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
 }
 
 type TopicACLs []TopicACL
@@ -190,3 +215,43 @@ func (in *Topic) NeedsSynchronization(hash string) bool {
 	}
 	return in.Status.SynchronizationHash != hash
 }
+
+func (in *Topic) FinalizerName() string {
+	return Finalizer
+}
+
+func (in *Topic) GetSynchronizationHash() string {
+	if in.Status == nil {
+		return ""
+	}
+	return in.Status.SynchronizationHash
+}
+
+func (in *Topic) SetSynchronizationHash(hash string) {
+	if in.Status == nil {
+		in.Status = &TopicStatus{}
+	}
+	in.Status.SynchronizationHash = hash
+}
+
+func (in *Topic) SetSynchronizationState(state string) {
+	if in.Status == nil {
+		in.Status = &TopicStatus{}
+	}
+	in.Status.SynchronizationState = state
+	in.Status.SynchronizationTime = time.Now().Format(time.RFC3339)
+}
+
+func (in *Topic) StatusConditions() conditions.Status {
+	if in.Status == nil {
+		in.Status = &TopicStatus{}
+	}
+	return in.Status
+}
+
+func (in *Topic) VersionedStatus() resync.Versioned {
+	if in.Status == nil {
+		in.Status = &TopicStatus{}
+	}
+	return in.Status
+}
@@ -5,6 +5,7 @@
 package kafka_nais_io_v1
 
 import (
+	"github.com/nais/liberator/pkg/conditions"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -183,6 +184,13 @@ func (in *TopicStatus) DeepCopyInto(out *TopicStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicStatus.
@@ -4,9 +4,23 @@ import (
 	"github.com/imdario/mergo"
 )
 
+// Alert spec default values
+const (
+	DefaultPagerDutyURL      = "https://events.pagerduty.com/v2/enqueue"
+	DefaultPagerDutySeverity = "error"
+)
+
 // ApplyDefaults sets default values where they are missing from an Application spec.
 func (alert *Alert) ApplyDefaults() error {
-	return mergo.Merge(alert, getAlertDefaults())
+	err := mergo.Merge(alert, getAlertDefaults())
+	if err != nil {
+		return err
+	}
+	alert.Spec.Receivers.applyDefaults()
+	for i := range alert.Spec.AdditionalReceivers {
+		alert.Spec.AdditionalReceivers[i].applyDefaults()
+	}
+	return nil
 }
 
 func getAlertDefaults() *Alert {
@@ -14,3 +28,29 @@ func getAlertDefaults() *Alert {
 		Spec:       AlertSpec{},
 	}
 }
+
+// Optional receivers are only defaulted when configured.
+func (in *Receivers) applyDefaults() {
+	sendResolved := func(b **bool) {
+		if *b == nil {
+			t := true
+			*b = &t
+		}
+	}
+
+	if in.Webhook != nil {
+		sendResolved(&in.Webhook.SendResolved)
+	}
+	if in.Teams != nil {
+		sendResolved(&in.Teams.SendResolved)
+	}
+	if in.PagerDuty != nil {
+		sendResolved(&in.PagerDuty.SendResolved)
+		if len(in.PagerDuty.URL) == 0 {
+			in.PagerDuty.URL = DefaultPagerDutyURL
+		}
+		if len(in.PagerDuty.Severity) == 0 {
+			in.PagerDuty.Severity = DefaultPagerDutySeverity
+		}
+	}
+}
@@ -25,6 +25,34 @@ func ExampleAlertForDocumentation() *Alert {
 				GroupWait:      "30s",
 				GroupInterval:  "5m",
 				RepeatInterval: "3h",
+				Routes: []ChildRoute{
+					{
+						LeafRoute: LeafRoute{
+							Receiver: "on-call",
+							Match: map[string]string{
+								"severity": "danger",
+							},
+							Continue:       true,
+							RepeatInterval: "1h",
+						},
+					},
+					{
+						LeafRoute: LeafRoute{
+							Receiver: "database",
+							MatchRegex: map[string]string{
+								"component": "postgres|redis",
+							},
+						},
+						Routes: []LeafRoute{
+							{
+								Match: map[string]string{
+									"severity": "warning",
+								},
+								RepeatInterval: "12h",
+							},
+						},
+					},
+				},
 			},
 			Receivers: Receivers{
 				Slack: Slack{
@@ -43,6 +71,50 @@ func ExampleAlertForDocumentation() *Alert {
 					Recipients:   "12345678",
 					SendResolved: boolp(false),
 				},
+				Webhook: &Webhook{
+					URL:          "https://oncall.example.com/api/alerts",
+					SendResolved: boolp(true),
+					MaxAlerts:    10,
+					BasicAuth: &WebhookBasicAuth{
+						Username: "alertmanager",
+						Password: SecretKeyReference{
+							Name: "oncall-credentials",
+							Key:  "password",
+						},
+					},
+				},
+				Teams: &Teams{
+					URL:          "https://example.webhook.office.com/webhookb2/...",
+					SendResolved: boolp(true),
+					Title:        "Alerts for myteam",
+				},
+				PagerDuty: &PagerDuty{
+					RoutingKey: SecretKeyReference{
+						Name: "pagerduty",
+						Key:  "routing-key",
+					},
+					URL:          "https://events.pagerduty.com/v2/enqueue",
+					Severity:     "critical",
+					SendResolved: boolp(true),
+				},
+			},
+			AdditionalReceivers: []NamedReceivers{
+				{
+					Name: "on-call",
+					Receivers: Receivers{
+						SMS: SMS{
+							Recipients: "12345678",
+						},
+					},
+				},
+				{
+					Name: "database",
+					Receivers: Receivers{
+						Slack: Slack{
+							Channel: "#database-alerts",
+						},
+					},
+				},
 			},
 			Alerts: []Rule{
 				{
@@ -55,6 +127,9 @@ func ExampleAlertForDocumentation() *Alert {
 					SLA:           "Mellom 8 og 16",
 					Severity:      "danger",
 					Priority:      "0",
+					Labels: map[string]string{
+						"component": "postgres",
+					},
 				},
 			},
 			InhibitRules: []InhibitRules{
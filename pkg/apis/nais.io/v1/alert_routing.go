@@ -0,0 +1,116 @@
+package nais_io_v1
+
+import (
+	"regexp"
+)
+
+// Result of matching a route against the labels of a rule.
+// Labels that are not known until the rule is evaluated, such as those of the matching series, may or may not match.
+type routeMatch int
+
+const (
+	routeMatchNo routeMatch = iota
+	routeMatchMaybe
+	routeMatchYes
+)
+
+// Route tree of an Alert, in which the empty receiver name refers to the receivers of the top-level route.
+type routeNode struct {
+	LeafRoute
+	children []routeNode
+}
+
+func (in Route) tree() routeNode {
+	root := routeNode{}
+	for _, child := range in.Routes {
+		node := routeNode{LeafRoute: child.LeafRoute}
+		for _, leaf := range child.Routes {
+			node.children = append(node.children, routeNode{LeafRoute: leaf})
+		}
+		root.children = append(root.children, node)
+	}
+	return root
+}
+
+// Labels of every alert produced by a rule, regardless of the series it originates from.
+func (in Rule) staticLabels() map[string]string {
+	labels := make(map[string]string, len(in.Labels)+2)
+	for k, v := range in.Labels {
+		labels[k] = v
+	}
+	labels["alertname"] = in.Alert
+	labels[AlertSeverityLabel] = in.Severity
+	if len(in.Severity) == 0 {
+		labels[AlertSeverityLabel] = DefaultAlertSeverity
+	}
+	return labels
+}
+
+// Returns the names of all receivers that an alert with the given labels might be sent to, following Alertmanager semantics:
+// child routes are tried in order, and the receiver of a route is only used if none of its children match.
+func (in routeNode) receivers(parent string, labels map[string]string) []string {
+	receiver := in.Receiver
+	if len(receiver) == 0 {
+		receiver = parent
+	}
+
+	var result []string
+	matched := false
+	for _, child := range in.children {
+		match := child.matches(labels)
+		if match == routeMatchNo {
+			continue
+		}
+		result = append(result, child.receivers(receiver, labels)...)
+		if match == routeMatchYes {
+			matched = true
+			if !child.Continue {
+				break
+			}
+		}
+	}
+	if !matched {
+		result = append(result, receiver)
+	}
+	return result
+}
+
+func (in LeafRoute) matches(labels map[string]string) routeMatch {
+	result := routeMatchYes
+	for name, value := range in.Match {
+		actual, ok := labels[name]
+		switch {
+		case !ok:
+			result = routeMatchMaybe
+		case actual != value:
+			return routeMatchNo
+		}
+	}
+	for name, expr := range in.MatchRegex {
+		actual, ok := labels[name]
+		if !ok {
+			result = routeMatchMaybe
+			continue
+		}
+		re, err := anchoredRegexp(expr)
+		if err != nil || !re.MatchString(actual) {
+			return routeMatchNo
+		}
+	}
+	return result
+}
+
+// Alertmanager anchors regular expressions at both ends.
+func anchoredRegexp(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + expr + ")$")
+}
+
+// configured returns true if at least one notification channel is set up.
+func (in Receivers) configured() bool {
+	return len(in.Slack.Channel) > 0 ||
+		len(in.Email.To) > 0 ||
+		len(in.SMS.Recipients) > 0 ||
+		in.Webhook != nil ||
+		in.Teams != nil ||
+		in.PagerDuty != nil
+}
@@ -1,10 +1,13 @@
 package nais_io_v1
 
 import (
+	"reflect"
 	"strconv"
 	"time"
 
 	hash "github.com/mitchellh/hashstructure"
+	"github.com/nais/liberator/pkg/conditions"
+	liberator_hash "github.com/nais/liberator/pkg/hash"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -12,6 +15,13 @@ import (
 
 const LastSyncedHashAnnotation = "nais.io/lastSyncedHash"
 
+const (
+	// Label containing the severity of an alert, used for routing.
+	AlertSeverityLabel = "severity"
+	// Severity of rules that do not specify one.
+	DefaultAlertSeverity = "danger"
+)
+
 func init() {
 	SchemeBuilder.Register(
 		&Alert{},
@@ -47,6 +57,69 @@ type SMS struct {
 	SendResolved *bool `json:"send_resolved,omitempty"`
 }
 
+// SecretKeyReference selects a key of a secret in the same namespace as the Alert.
+type SecretKeyReference struct {
+	// Name of the secret.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+	// Key within the secret.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Key string `json:"key"`
+}
+
+type WebhookBasicAuth struct {
+	Username string `json:"username"`
+	// Secret key containing the password.
+	Password SecretKeyReference `json:"password"`
+}
+
+type Webhook struct {
+	// The endpoint to send HTTP POST requests to, using the Alertmanager webhook payload format.
+	// +kubebuilder:validation:Pattern="^https?://"
+	URL string `json:"url"`
+	// Whether or not to notify about resolved alerts.
+	// +nais:doc:Default="true"
+	SendResolved *bool `json:"send_resolved,omitempty"`
+	// The maximum number of alerts to include in a single notification. All alerts are included if not set.
+	// +kubebuilder:validation:Minimum=0
+	MaxAlerts int `json:"maxAlerts,omitempty"`
+	// Authenticate using HTTP basic authentication. Cannot be combined with `bearerToken`.
+	BasicAuth *WebhookBasicAuth `json:"basicAuth,omitempty"`
+	// Secret key containing a token sent in the `Authorization: Bearer` header. Cannot be combined with `basicAuth`.
+	BearerToken *SecretKeyReference `json:"bearerToken,omitempty"`
+}
+
+type Teams struct {
+	// Incoming webhook URL of the Microsoft Teams channel.
+	// +kubebuilder:validation:Pattern="^https://"
+	// +nais:doc:Link="https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/add-incoming-webhook"
+	URL string `json:"url"`
+	// Whether or not to notify about resolved alerts.
+	// +nais:doc:Default="true"
+	SendResolved *bool `json:"send_resolved,omitempty"`
+	// Title of the message card.
+	Title string `json:"title,omitempty"`
+}
+
+type PagerDuty struct {
+	// Secret key containing the integration key of the PagerDuty service, known as the routing key in the Events API v2.
+	RoutingKey SecretKeyReference `json:"routingKey"`
+	// Events API v2 endpoint. Change this to send incidents to a PagerDuty-compatible on-call system.
+	// +kubebuilder:validation:Pattern="^https?://"
+	// +nais:doc:Default="https://events.pagerduty.com/v2/enqueue"
+	// +nais:doc:Link="https://developer.pagerduty.com/docs/events-api-v2/trigger-events/"
+	URL string `json:"url,omitempty"`
+	// Severity of the incidents created.
+	// +kubebuilder:validation:Enum=critical;error;warning;info
+	// +nais:doc:Default="error"
+	Severity string `json:"severity,omitempty"`
+	// Whether or not to resolve incidents when the alert is resolved.
+	// +nais:doc:Default="true"
+	SendResolved *bool `json:"send_resolved,omitempty"`
+}
+
 type Receivers struct {
 	// Slack notifications are sent via Slack webhooks.
 	Slack Slack `json:"slack,omitempty"`
@@ -54,6 +127,20 @@ type Receivers struct {
 	Email Email `json:"email,omitempty"`
 	// Alerts via SMS
 	SMS SMS `json:"sms,omitempty"`
+	// Alerts sent to an HTTP endpoint, such as an on-call system.
+	Webhook *Webhook `json:"webhook,omitempty"`
+	// Alerts posted to a Microsoft Teams channel.
+	Teams *Teams `json:"teams,omitempty"`
+	// Alerts sent as incidents to PagerDuty, or any system compatible with the PagerDuty Events API v2.
+	PagerDuty *PagerDuty `json:"pagerDuty,omitempty"`
+}
+
+// NamedReceivers is a set of receivers that child routes can send alerts to.
+type NamedReceivers struct {
+	// Name referred to by the `receiver` field of a child route.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name      string `json:"name"`
+	Receivers `json:",inline"`
 }
 
 type Rule struct {
@@ -81,6 +168,9 @@ type Rule struct {
 	Severity string `json:"severity,omitempty"`
 	// Not in use
 	Priority string `json:"priority,omitempty"`
+	// Additional labels attached to the alert, which child routes can match on.
+	// The labels `alertname`, `alert`, `severity` and `team` are reserved.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type InhibitRules struct {
@@ -111,16 +201,50 @@ type Route struct {
 	// How long to wait before sending a notification again if it has already been sent successfully for an alert. (Usually ~3h or more).
 	// +kubebuilder:validation:Pattern="([0-9]+(ms|[smhdwy]))?"
 	RepeatInterval string `json:"repeatInterval,omitempty"`
+	// Child routes, tried in order. Alerts that match no child route are sent to the receivers of the parent route.
+	Routes []ChildRoute `json:"routes,omitempty"`
+}
+
+// LeafRoute sends the alerts matching all of its label matchers to a set of named receivers.
+// Timing settings that are not set are inherited from the parent route.
+type LeafRoute struct {
+	// Name of an entry in `additionalReceivers`. Alerts are sent to the receivers of the parent route if not set.
+	Receiver string `json:"receiver,omitempty"`
+	// Labels that must have exactly these values, such as `severity: warning`.
+	Match map[string]string `json:"match,omitempty"`
+	// Labels that must match these regular expressions. Expressions are anchored at both ends.
+	MatchRegex map[string]string `json:"matchRegex,omitempty"`
+	// Whether matching alerts should continue to be matched against the following sibling routes.
+	Continue bool `json:"continue,omitempty"`
+	// How long to initially wait to send a notification for a group of alerts.
+	// +kubebuilder:validation:Pattern="([0-9]+(ms|[smhdwy]))?"
+	GroupWait string `json:"groupWait,omitempty"`
+	// How long to wait before sending a notification about new alerts that are added to a group of alerts for which an initial notification has already been sent.
+	// +kubebuilder:validation:Pattern="([0-9]+(ms|[smhdwy]))?"
+	GroupInterval string `json:"groupInterval,omitempty"`
+	// How long to wait before sending a notification again if it has already been sent successfully for an alert.
+	// +kubebuilder:validation:Pattern="([0-9]+(ms|[smhdwy]))?"
+	RepeatInterval string `json:"repeatInterval,omitempty"`
+}
+
+// ChildRoute is a route below the top-level route, which can have child routes of its own.
+// The CRD schema cannot describe recursive types, so the route tree is limited to two levels below the top-level route.
+type ChildRoute struct {
+	LeafRoute `json:",inline"`
+	// Child routes, tried in order. Alerts that match no child route are sent to the receivers of this route.
+	Routes []LeafRoute `json:"routes,omitempty"`
 }
 
 type AlertSpec struct {
 	Route Route `json:"route,omitempty"`
-	// A list of notification recievers. You can use one or more of: e-mail, slack, sms.
+	// A list of notification recievers. You can use one or more of: e-mail, slack, sms, webhook, teams, pagerDuty.
 	// There needs to be at least one receiver.
 	// +kubebuilder:validation:Required
 	Receivers Receivers `json:"receivers,omitempty"`
 	// +kubebuilder:validation:Required
 	Alerts []Rule `json:"alerts,omitempty"`
+	// Receivers that child routes of `route` can send alerts to, in addition to `receivers`.
+	AdditionalReceivers []NamedReceivers `json:"additionalReceivers,omitempty"`
 	// A list of inhibit rules. Read more about it at [prometheus.io/docs](https://prometheus.io/docs/alerting/latest/configuration/#inhibit_rule).
 	InhibitRules []InhibitRules `json:"inhibitRules,omitempty"`
 }
@@ -130,8 +254,29 @@ type AlertStatus struct {
 	SynchronizationTime  int64  `json:"synchronizationTime,omitempty"`
 	SynchronizationState string `json:"synchronizationState,omitempty"`
 	SynchronizationHash  string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *AlertStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *AlertStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *AlertStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
 }
 
+func (in *AlertStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
+}
+
+// +genclient
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Slack channel",type="string",JSONPath=".spec.receivers.slack.channel"
 // +kubebuilder:object:root=true
@@ -151,6 +296,7 @@ type AlertList struct {
 	Items []Alert `json:"items"`
 }
 
+// Deprecated: use events.Recorder, which works for any liberator resource and aggregates repeated events.
 func (in *Alert) CreateEvent(reason, message, typeStr string) *corev1.Event {
 	return &corev1.Event{
 		ObjectMeta: metav1.ObjectMeta{
@@ -223,6 +369,67 @@ func (in Alert) Hash() (string, error) {
 	return strconv.FormatUint(h, 10), err
 }
 
+// HashInclude leaves the receivers added after Hash was introduced out of the hash while they are unset,
+// so that the hash of existing Alerts does not change.
+func (in Receivers) HashInclude(field string, v interface{}) (bool, error) {
+	return includeInLegacyHash(field, v, "Webhook", "Teams", "PagerDuty"), nil
+}
+
+// HashInclude leaves the additional receivers out of the hash while unset, see Receivers.HashInclude.
+func (in AlertSpec) HashInclude(field string, v interface{}) (bool, error) {
+	return includeInLegacyHash(field, v, "AdditionalReceivers"), nil
+}
+
+// HashInclude leaves the child routes out of the hash while unset, see Receivers.HashInclude.
+func (in Route) HashInclude(field string, v interface{}) (bool, error) {
+	return includeInLegacyHash(field, v, "Routes"), nil
+}
+
+// HashInclude leaves the rule labels out of the hash while unset, see Receivers.HashInclude.
+func (in Rule) HashInclude(field string, v interface{}) (bool, error) {
+	return includeInLegacyHash(field, v, "Labels"), nil
+}
+
+// Returns false for the given fields if they are unset. Empty slices and maps are unset.
+func includeInLegacyHash(field string, v interface{}, added ...string) bool {
+	value, ok := v.(reflect.Value)
+	if !ok {
+		return true
+	}
+	for _, name := range added {
+		if name != field {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.Slice, reflect.Map:
+			return value.Len() > 0
+		}
+		return !value.IsZero()
+	}
+	return true
+}
+
+// Digest returns an algorithm-prefixed hash of the fields that trigger a synchronization when changed.
+// See hash.Digest.
+func (in Alert) Digest() (string, error) {
+	return liberator_hash.Digest(in.digestInput())
+}
+
+// VerifyHash returns true if the stored hash matches the current state, whether it was produced by Digest or Hash.
+func (in Alert) VerifyHash(stored string) (bool, error) {
+	return liberator_hash.Verify(stored, in.digestInput(), in.Hash)
+}
+
+func (in Alert) digestInput() interface{} {
+	return struct {
+		Spec   AlertSpec         `json:"spec"`
+		Labels map[string]string `json:"labels"`
+	}{
+		Spec:   in.Spec,
+		Labels: in.Labels,
+	}
+}
+
 func (in *Alert) LastSyncedHash() string {
 	a := in.GetAnnotations()
 	if a == nil {
@@ -16,6 +16,48 @@ func TestApplication_Hash(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, a1, a2, "matches, as annotations is ignored")
 	assert.NotEqual(t, a2, a3, "must not match ")
+
+	alert := nais_io_v1.Alert{Spec: nais_io_v1.AlertSpec{Receivers: nais_io_v1.Receivers{Slack: nais_io_v1.Slack{Channel: "#alerts"}}}}
+	before, err := alert.Hash()
+	assert.NoError(t, err)
+	alert.Spec.Receivers.Webhook = &nais_io_v1.Webhook{URL: "https://example.com"}
+	after, err := alert.Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, before, after, "configured receivers are hashed")
+}
+
+func TestAlert_HashIsStable(t *testing.T) {
+	alert := nais_io_v1.Alert{
+		ObjectMeta: corev1.ObjectMeta{Labels: map[string]string{"team": "myteam"}},
+		Spec: nais_io_v1.AlertSpec{
+			Route: nais_io_v1.Route{GroupWait: "30s", RepeatInterval: "4h"},
+			Receivers: nais_io_v1.Receivers{
+				Slack: nais_io_v1.Slack{Channel: "#alerts"},
+				Email: nais_io_v1.Email{To: "team@example.com"},
+			},
+			Alerts:       []nais_io_v1.Rule{{Alert: "down", Expr: "up == 0", For: "2m", Description: "down", Action: "fix", Severity: "danger"}},
+			InhibitRules: []nais_io_v1.InhibitRules{{Targets: map[string]string{"a": "b"}, Labels: []string{"x"}}},
+		},
+	}
+
+	h, err := alert.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "12103013863571902716", h, "fields added to Alert must not change the hash of existing Alerts")
+
+	h, err = nais_io_v1.Alert{}.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "647995683867578803", h)
+
+	alert.Spec.Alerts[0].Labels = map[string]string{}
+	alert.Spec.Route.Routes = []nais_io_v1.ChildRoute{}
+	h, err = alert.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "12103013863571902716", h, "empty values are unset")
+
+	alert.Spec.Alerts[0].Labels = map[string]string{"tier": "1"}
+	h, err = alert.Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "12103013863571902716", h)
 }
 
 func TestNilFix(t *testing.T) {
@@ -25,3 +67,27 @@ func TestNilFix(t *testing.T) {
 	assert.NotNil(t, alert.Spec.Receivers)
 	assert.NotNil(t, alert.Spec.Alerts)
 }
+
+func TestAlert_ApplyDefaults(t *testing.T) {
+	alert := nais_io_v1.Alert{}
+	assert.NoError(t, alert.ApplyDefaults())
+	assert.Nil(t, alert.Spec.Receivers.Webhook, "unconfigured receivers are left out")
+	assert.Nil(t, alert.Spec.Receivers.Teams)
+	assert.Nil(t, alert.Spec.Receivers.PagerDuty)
+
+	alert.Spec.Receivers.Webhook = &nais_io_v1.Webhook{URL: "https://example.com"}
+	alert.Spec.Receivers.Teams = &nais_io_v1.Teams{URL: "https://example.com"}
+	alert.Spec.Receivers.PagerDuty = &nais_io_v1.PagerDuty{}
+	assert.NoError(t, alert.ApplyDefaults())
+	assert.True(t, *alert.Spec.Receivers.Webhook.SendResolved)
+	assert.True(t, *alert.Spec.Receivers.Teams.SendResolved)
+	assert.True(t, *alert.Spec.Receivers.PagerDuty.SendResolved)
+	assert.Equal(t, nais_io_v1.DefaultPagerDutyURL, alert.Spec.Receivers.PagerDuty.URL)
+	assert.Equal(t, nais_io_v1.DefaultPagerDutySeverity, alert.Spec.Receivers.PagerDuty.Severity)
+
+	alert.Spec.AdditionalReceivers = []nais_io_v1.NamedReceivers{
+		{Name: "on-call", Receivers: nais_io_v1.Receivers{PagerDuty: &nais_io_v1.PagerDuty{}}},
+	}
+	assert.NoError(t, alert.ApplyDefaults())
+	assert.Equal(t, nais_io_v1.DefaultPagerDutyURL, alert.Spec.AdditionalReceivers[0].PagerDuty.URL, "additional receivers are defaulted")
+}
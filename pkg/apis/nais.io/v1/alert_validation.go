@@ -0,0 +1,270 @@
+package nais_io_v1
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/nais/liberator/pkg/promql"
+)
+
+// Labels set on every rendered rule, which cannot be overridden by the labels of a rule.
+var reservedAlertLabels = []string{"alertname", "alert", AlertSeverityLabel, "team"}
+
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Validate performs semantic checks on an Alert spec that cannot be expressed through the OpenAPI schema alone.
+// Expressions are parsed as PromQL, durations are parsed as Prometheus durations, and templates may only
+// refer to the variables, fields and functions provided by Prometheus.
+// Every rule must reach at least one configured receiver through the route tree. The returned list is empty if the Alert is valid.
+func (in *Alert) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+
+	receivers := map[string]Receivers{"": in.Spec.Receivers}
+	for i, named := range in.Spec.AdditionalReceivers {
+		path := spec.Child("additionalReceivers").Index(i)
+		if len(named.Name) == 0 {
+			errs = append(errs, field.Required(path.Child("name"), "receiver name must be set"))
+		} else if _, ok := receivers[named.Name]; ok {
+			errs = append(errs, field.Duplicate(path.Child("name"), named.Name))
+		}
+		receivers[named.Name] = named.Receivers
+		errs = append(errs, validateReceivers(named.Receivers, path)...)
+	}
+
+	errs = append(errs, validateAlertRules(in.Spec.Alerts, spec.Child("alerts"))...)
+	errs = append(errs, validateAlertRoute(in.Spec.Route, receivers, spec.Child("route"))...)
+	errs = append(errs, validateReceivers(in.Spec.Receivers, spec.Child("receivers"))...)
+	errs = append(errs, validateRuleReachability(in.Spec.Alerts, in.Spec.Route, receivers, spec.Child("alerts"))...)
+
+	return errs
+}
+
+func validateAlertRules(rules []Rule, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]bool)
+
+	for i, rule := range rules {
+		rulePath := path.Index(i)
+
+		if len(rule.Alert) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("alert"), "alert name must be set"))
+		} else if seen[rule.Alert] {
+			errs = append(errs, field.Duplicate(rulePath.Child("alert"), rule.Alert))
+		}
+		seen[rule.Alert] = true
+
+		if len(rule.Expr) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("expr"), "expression must be set"))
+		} else if _, err := promql.ParseExpr(rule.Expr); err != nil {
+			errs = append(errs, field.Invalid(rulePath.Child("expr"), rule.Expr, fmt.Sprintf("invalid PromQL expression: %s", err)))
+		}
+
+		if len(rule.For) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("for"), "duration must be set"))
+		} else if _, err := promql.ParseDuration(rule.For); err != nil {
+			errs = append(errs, field.Invalid(rulePath.Child("for"), rule.For, err.Error()))
+		}
+
+		errs = append(errs, validateAlertTemplate(rule.Description, rulePath.Child("description"))...)
+		errs = append(errs, validateAlertTemplate(rule.Action, rulePath.Child("action"))...)
+
+		for name := range rule.Labels {
+			labelPath := rulePath.Child("labels").Key(name)
+			if !labelNameRegex.MatchString(name) {
+				errs = append(errs, field.Invalid(labelPath, name, "invalid label name"))
+			}
+			for _, reserved := range reservedAlertLabels {
+				if name == reserved {
+					errs = append(errs, field.Forbidden(labelPath, "label is reserved"))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// Templates are executed against sample data, so that references to fields that Prometheus does not provide,
+// such as a misspelled .Labels, are caught. Other execution errors depend on the alert and are not reported,
+// e.g. functions that query Prometheus, or humanize applied to a label that is not a number.
+func validateAlertTemplate(text string, path *field.Path) field.ErrorList {
+	tmpl, err := promql.ParseTemplate(path.String(), text)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, text, fmt.Sprintf("invalid template: %s", err))}
+	}
+
+	sample := promql.TemplateData{
+		Labels:         map[string]string{},
+		ExternalLabels: map[string]string{},
+	}
+	err = tmpl.Execute(ioutil.Discard, sample)
+	if err != nil && strings.Contains(err.Error(), "can't evaluate field") {
+		return field.ErrorList{field.Invalid(path, text, fmt.Sprintf("invalid template: %s", err))}
+	}
+	return nil
+}
+
+func validateAlertRoute(route Route, receivers map[string]Receivers, path *field.Path) field.ErrorList {
+	errs := validateRouteTimings(route.GroupWait, route.GroupInterval, route.RepeatInterval, path)
+
+	for i, child := range route.Routes {
+		childPath := path.Child("routes").Index(i)
+		errs = append(errs, validateLeafRoute(child.LeafRoute, receivers, childPath)...)
+		for j, leaf := range child.Routes {
+			errs = append(errs, validateLeafRoute(leaf, receivers, childPath.Child("routes").Index(j))...)
+		}
+	}
+
+	return errs
+}
+
+func validateLeafRoute(route LeafRoute, receivers map[string]Receivers, path *field.Path) field.ErrorList {
+	errs := validateRouteTimings(route.GroupWait, route.GroupInterval, route.RepeatInterval, path)
+
+	if _, ok := receivers[route.Receiver]; !ok {
+		errs = append(errs, field.NotFound(path.Child("receiver"), route.Receiver))
+	}
+	for name := range route.Match {
+		if !labelNameRegex.MatchString(name) {
+			errs = append(errs, field.Invalid(path.Child("match").Key(name), name, "invalid label name"))
+		}
+	}
+	for name, expr := range route.MatchRegex {
+		if !labelNameRegex.MatchString(name) {
+			errs = append(errs, field.Invalid(path.Child("matchRegex").Key(name), name, "invalid label name"))
+		}
+		if _, err := anchoredRegexp(expr); err != nil {
+			errs = append(errs, field.Invalid(path.Child("matchRegex").Key(name), expr, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+func validateRouteTimings(groupWait, groupInterval, repeatInterval string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	parse := func(value, name string, positive bool) time.Duration {
+		if len(value) == 0 {
+			return 0
+		}
+		duration, err := promql.ParseDuration(value)
+		switch {
+		case err != nil:
+			errs = append(errs, field.Invalid(path.Child(name), value, err.Error()))
+		case positive && duration == 0:
+			errs = append(errs, field.Invalid(path.Child(name), value, "duration must be greater than 0"))
+		}
+		return duration
+	}
+
+	parse(groupWait, "groupWait", false)
+	group := parse(groupInterval, "groupInterval", true)
+	repeat := parse(repeatInterval, "repeatInterval", true)
+
+	if group > 0 && repeat > 0 && repeat < group {
+		errs = append(errs, field.Invalid(path.Child("repeatInterval"), repeatInterval, "must not be shorter than groupInterval, as notifications are only sent once per group interval"))
+	}
+
+	return errs
+}
+
+// Rules that may end up at a receiver without any notification channels would fire without anyone being notified.
+func validateRuleReachability(rules []Rule, route Route, receivers map[string]Receivers, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	tree := route.tree()
+
+	for i, rule := range rules {
+		seen := make(map[string]bool)
+		for _, name := range tree.receivers("", rule.staticLabels()) {
+			target, ok := receivers[name]
+			if seen[name] || !ok || target.configured() {
+				continue
+			}
+			seen[name] = true
+			description := "the receivers of the top-level route"
+			if len(name) > 0 {
+				description = fmt.Sprintf("additional receiver %q", name)
+			}
+			errs = append(errs, field.Invalid(path.Index(i), rule.Alert, fmt.Sprintf("alert may be routed to %s, which has no notifications configured", description)))
+		}
+	}
+
+	return errs
+}
+
+func validateReceivers(receivers Receivers, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if webhook := receivers.Webhook; webhook != nil {
+		webhookPath := path.Child("webhook")
+		errs = append(errs, validateReceiverURL(webhook.URL, webhookPath.Child("url"))...)
+		if webhook.BasicAuth != nil && webhook.BearerToken != nil {
+			errs = append(errs, field.Forbidden(webhookPath.Child("bearerToken"), "cannot be combined with basicAuth"))
+		}
+		if webhook.BasicAuth != nil {
+			if len(webhook.BasicAuth.Username) == 0 {
+				errs = append(errs, field.Required(webhookPath.Child("basicAuth", "username"), "username must be set"))
+			}
+			errs = append(errs, validateSecretKeyReference(webhook.BasicAuth.Password, webhookPath.Child("basicAuth", "password"))...)
+		}
+		if webhook.BearerToken != nil {
+			errs = append(errs, validateSecretKeyReference(*webhook.BearerToken, webhookPath.Child("bearerToken"))...)
+		}
+	}
+
+	if teams := receivers.Teams; teams != nil {
+		errs = append(errs, validateReceiverURL(teams.URL, path.Child("teams", "url"))...)
+	}
+
+	if pagerDuty := receivers.PagerDuty; pagerDuty != nil {
+		errs = append(errs, validateSecretKeyReference(pagerDuty.RoutingKey, path.Child("pagerDuty", "routingKey"))...)
+		if len(pagerDuty.URL) > 0 {
+			errs = append(errs, validateReceiverURL(pagerDuty.URL, path.Child("pagerDuty", "url"))...)
+		}
+	}
+
+	return errs
+}
+
+func validateReceiverURL(value string, path *field.Path) field.ErrorList {
+	if len(value) == 0 {
+		return field.ErrorList{field.Required(path, "URL must be set")}
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return field.ErrorList{field.Invalid(path, value, "must be an absolute http or https URL")}
+	}
+	return nil
+}
+
+// Secret references are turned into file paths when rendered, so anything but a valid name and key is rejected.
+func validateSecretKeyReference(ref SecretKeyReference, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(ref.Name) == 0 {
+		errs = append(errs, field.Required(path.Child("name"), "secret name must be set"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			errs = append(errs, field.Invalid(path.Child("name"), ref.Name, msg))
+		}
+	}
+	if len(ref.Key) == 0 {
+		errs = append(errs, field.Required(path.Child("key"), "secret key must be set"))
+	} else {
+		for _, msg := range validation.IsConfigMapKey(ref.Key) {
+			errs = append(errs, field.Invalid(path.Child("key"), ref.Key, msg))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,213 @@
+package nais_io_v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+func TestAlert_Validate(t *testing.T) {
+	assert.Empty(t, nais_io_v1.ExampleAlertForDocumentation().Validate(), "documentation example is valid")
+
+	tests := []struct {
+		name   string
+		mutate func(alert *nais_io_v1.Alert)
+		field  string
+		typ    field.ErrorType
+	}{
+		{
+			name:   "invalid expression",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Expr = "sum(rate(x[5m])" },
+			field:  "spec.alerts[0].expr",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "range vector expression",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Expr = "x[5m]" },
+			field:  "spec.alerts[0].expr",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "missing expression",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Expr = "" },
+			field:  "spec.alerts[0].expr",
+			typ:    field.ErrorTypeRequired,
+		},
+		{
+			name:   "invalid for",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].For = "5mm" },
+			field:  "spec.alerts[0].for",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "unknown template variable in description",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Description = "{{ $lables.app }} is down" },
+			field:  "spec.alerts[0].description",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "unknown template field in description",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Description = "{{ .Lables.app }} is down" },
+			field:  "spec.alerts[0].description",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "unknown template function in action",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Action = "{{ $value | humanise }}" },
+			field:  "spec.alerts[0].action",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name: "duplicate alert name",
+			mutate: func(alert *nais_io_v1.Alert) {
+				alert.Spec.Alerts = append(alert.Spec.Alerts, alert.Spec.Alerts[0])
+			},
+			field: "spec.alerts[1].alert",
+			typ:   field.ErrorTypeDuplicate,
+		},
+		{
+			name: "webhook with both basic auth and bearer token",
+			mutate: func(alert *nais_io_v1.Alert) {
+				alert.Spec.Receivers.Webhook.BearerToken = &nais_io_v1.SecretKeyReference{Name: "token", Key: "token"}
+			},
+			field: "spec.receivers.webhook.bearerToken",
+			typ:   field.ErrorTypeForbidden,
+		},
+		{
+			name:   "relative webhook url",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Receivers.Webhook.URL = "/api/alerts" },
+			field:  "spec.receivers.webhook.url",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "missing teams url",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Receivers.Teams.URL = "" },
+			field:  "spec.receivers.teams.url",
+			typ:    field.ErrorTypeRequired,
+		},
+		{
+			name:   "pagerduty routing key without secret key",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Receivers.PagerDuty.RoutingKey.Key = "" },
+			field:  "spec.receivers.pagerDuty.routingKey.key",
+			typ:    field.ErrorTypeRequired,
+		},
+		{
+			name:   "secret name escaping the namespace",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Receivers.Webhook.BasicAuth.Password.Name = "../otherteam/oncall" },
+			field:  "spec.receivers.webhook.basicAuth.password.name",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "secret key escaping the secret",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Receivers.PagerDuty.RoutingKey.Key = ".." },
+			field:  "spec.receivers.pagerDuty.routingKey.key",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "unknown receiver in child route",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Route.Routes[0].Receiver = "pager" },
+			field:  "spec.route.routes[0].receiver",
+			typ:    field.ErrorTypeNotFound,
+		},
+		{
+			name:   "invalid regex matcher in nested route",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Route.Routes[1].Routes[0].MatchRegex = map[string]string{"component": "(postgres"} },
+			field:  "spec.route.routes[1].routes[0].matchRegex[component]",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "invalid repeat interval in child route",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Route.Routes[0].RepeatInterval = "0s" },
+			field:  "spec.route.routes[0].repeatInterval",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "reserved rule label",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Alerts[0].Labels["severity"] = "warning" },
+			field:  "spec.alerts[0].labels[severity]",
+			typ:    field.ErrorTypeForbidden,
+		},
+		{
+			name: "duplicate additional receiver",
+			mutate: func(alert *nais_io_v1.Alert) {
+				alert.Spec.AdditionalReceivers = append(alert.Spec.AdditionalReceivers, alert.Spec.AdditionalReceivers[0])
+			},
+			field: "spec.additionalReceivers[2].name",
+			typ:   field.ErrorTypeDuplicate,
+		},
+		{
+			name: "rule routed to receivers without notifications",
+			mutate: func(alert *nais_io_v1.Alert) {
+				alert.Spec.Receivers = nais_io_v1.Receivers{}
+				alert.Spec.Alerts[0].Severity = "warning"
+				alert.Spec.Alerts[0].Labels = nil
+			},
+			field: "spec.alerts[0]",
+			typ:   field.ErrorTypeInvalid,
+		},
+		{
+			name:   "invalid group wait",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Route.GroupWait = "1.5m" },
+			field:  "spec.route.groupWait",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "zero group interval",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Route.GroupInterval = "0s" },
+			field:  "spec.route.groupInterval",
+			typ:    field.ErrorTypeInvalid,
+		},
+		{
+			name:   "repeat interval shorter than group interval",
+			mutate: func(alert *nais_io_v1.Alert) { alert.Spec.Route.RepeatInterval = "1m" },
+			field:  "spec.route.repeatInterval",
+			typ:    field.ErrorTypeInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			alert := nais_io_v1.ExampleAlertForDocumentation()
+			test.mutate(alert)
+			errs := alert.Validate()
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, test.field, errs[0].Field)
+				assert.Equal(t, test.typ, errs[0].Type)
+			}
+		})
+	}
+
+	t.Run("templates failing on data only known to Prometheus are valid", func(t *testing.T) {
+		alert := nais_io_v1.ExampleAlertForDocumentation()
+		alert.Spec.Alerts[0].Description = `{{ $labels.app | humanize }} {{ with query "up" }}{{ . | first | value }}{{ end }}`
+		alert.Spec.Alerts[0].Action = "{{ .Labels.app }} {{ .ExternalLabels.cluster }} {{ .Value | humanizePercentage }}"
+		assert.Empty(t, alert.Validate())
+	})
+}
+
+func TestAlert_ValidateRouting(t *testing.T) {
+	alert := nais_io_v1.ExampleAlertForDocumentation()
+	alert.Spec.Receivers = nais_io_v1.Receivers{}
+	assert.Empty(t, alert.Validate(), "danger alerts for the database never reach the top-level route")
+
+	alert.Spec.Route.Routes[0].Continue = false
+	assert.Empty(t, alert.Validate(), "first matching route receives the alert")
+
+	alert.Spec.Alerts[0].Labels = nil
+	alert.Spec.Route.Routes[0].Match = map[string]string{"namespace": "myteam"}
+	errs := alert.Validate()
+	if assert.Len(t, errs, 1, "series labels are unknown, so the alert might fall through to the top-level route") {
+		assert.Equal(t, "spec.alerts[0]", errs[0].Field)
+		assert.Contains(t, errs[0].Detail, "top-level route")
+	}
+
+	alert.Spec.AdditionalReceivers[0].Receivers = nais_io_v1.Receivers{}
+	alert.Spec.Route.Routes[0].Match = nil
+	errs = alert.Validate()
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Detail, `additional receiver "on-call"`)
+	}
+}
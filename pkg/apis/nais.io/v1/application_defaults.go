@@ -0,0 +1,26 @@
+package nais_io_v1
+
+import (
+	"github.com/imdario/mergo"
+)
+
+// ApplyDefaults sets default values where they are missing from an Application spec.
+//
+// Any profiles given are layered, from least to most specific, on top of the built-in defaults.
+func (app *Application) ApplyDefaults(profiles ...*DefaultsProfile) error {
+	defaults, err := getAppDefaults(profiles)
+	if err != nil {
+		return err
+	}
+	return mergo.Merge(app, defaults)
+}
+
+func getAppDefaults(profiles []*DefaultsProfile) (*Application, error) {
+	profile, err := defaultsProfile(profiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Application{
+		Spec: *profile.Application,
+	}, nil
+}
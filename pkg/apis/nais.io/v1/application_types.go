@@ -0,0 +1,224 @@
+package nais_io_v1
+
+import (
+	"github.com/nais/liberator/pkg/conditions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&Application{},
+		&ApplicationList{},
+	)
+}
+
+// Application defines a NAIS application.
+//
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Team",type="string",JSONPath=".metadata.labels.team"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.synchronizationState"
+// +kubebuilder:resource:path="applications",shortName="app",singular="application"
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationSpec   `json:"spec"`
+	Status ApplicationStatus `json:"status,omitempty"`
+}
+
+// ApplicationSpec contains the NAIS manifest.
+// Please keep this list sorted for clarity.
+type ApplicationSpec struct {
+	// By default, no traffic is allowed between applications inside the cluster.
+	// Configure access policies to explicitly allow communication between applications.
+	// This is also used for granting inbound access in the context of Azure AD and TokenX clients.
+	// +nais:doc:Link="https://doc.nais.io/appendix/zero-trust/";"https://doc.nais.io/security/auth/azure-ad/access-policy";"https://doc.nais.io/security/auth/tokenx/#access-policies"
+	AccessPolicy *AccessPolicy `json:"accessPolicy,omitempty"`
+
+	// Provisions and configures Azure resources.
+	Azure *Azure `json:"azure,omitempty"`
+
+	// Override command when starting Docker image.
+	Command []string `json:"command,omitempty"`
+
+	// To get your own Elastic Search instance head over to the IaC-repo to provision each instance.
+	// See [navikt/aiven-iac](https://github.com/navikt/aiven-iac) repository.
+	Elastic *Elastic `json:"elastic,omitempty"`
+
+	// Custom environment variables injected into your container.
+	// Specify either `value` or `valueFrom`, but not both.
+	Env EnvVars `json:"env,omitempty"`
+
+	// EnvFrom exposes all variables in the ConfigMap or Secret resources as environment variables.
+	// One of `configMap` or `secret` is required.
+	//
+	// Environment variables will take the form `KEY=VALUE`, where `key` is the ConfigMap or Secret key.
+	// You can specify as many keys as you like in a single ConfigMap or Secret.
+	//
+	// The ConfigMap and Secret resources must live in the same Kubernetes namespace as the Application resource.
+	// +nais:doc:Availability="team namespaces"
+	EnvFrom []EnvFrom `json:"envFrom,omitempty"`
+
+	// List of ConfigMap or Secret resources that will have their contents mounted into the containers as files.
+	// Either `configMap` or `secret` is required.
+	//
+	// Files will take the path `<mountPath>/<key>`, where `key` is the ConfigMap or Secret key.
+	// You can specify as many keys as you like in a single ConfigMap or Secret, and they will all
+	// be mounted to the same directory.
+	//
+	// The ConfigMap and Secret resources must live in the same Kubernetes namespace as the Application resource.
+	// +nais:doc:Availability="team namespaces"
+	FilesFrom []FilesFrom `json:"filesFrom,omitempty"`
+
+	// +nais:doc:Availability="GCP"
+	GCP *GCP `json:"gcp,omitempty"`
+
+	// Configures an ID-porten client for this application.
+	// See [ID-porten](https://doc.nais.io/security/auth/idporten/) for more details.
+	IDPorten *IDPorten `json:"idporten,omitempty"`
+
+	// Your application's Docker image location and tag.
+	Image string `json:"image"`
+
+	// List of URLs that will route HTTPS traffic to the application.
+	// All URLs must start with `https://`. Domain availability differs according to which environment your application is running in.
+	// +nais:doc:Link="https://doc.nais.io/clusters/gcp/";"https://doc.nais.io/clusters/on-premises/"
+	Ingresses []Ingress `json:"ingresses,omitempty"`
+
+	// An InfluxDB via Aiven. A typical use case for influxdb is to store metrics from your application and visualize them in Grafana.
+	// +nais:doc:Availability="GCP"
+	Influx *Influx `json:"influx,omitempty"`
+
+	// Enable Aiven Kafka for your application.
+	Kafka *Kafka `json:"kafka,omitempty"`
+
+	// If true, an HTTP endpoint will be available at `$ELECTOR_PATH` that returns the current leader.
+	// +nais:doc:Link="https://doc.nais.io/addons/leader-election/"
+	LeaderElection bool `json:"leaderElection,omitempty"`
+
+	// Many applications running for long periods of time eventually transition to broken states,
+	// and cannot recover except by being restarted. Kubernetes provides liveness probes to detect
+	// and remedy such situations. Read more about this over at the
+	// [Kubernetes probes documentation](https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/).
+	Liveness *Probe `json:"liveness,omitempty"`
+
+	// Format of the logs from the container. Use this if the container doesn't support
+	// JSON logging and the log is in a special format that need to be parsed.
+	// +kubebuilder:validation:Enum="";accesslog;accesslog_with_processing_time;accesslog_with_referer_useragent;capnslog;logrus;gokit;redis;glog;simple;influxdb;log15
+	Logformat string `json:"logformat,omitempty"`
+
+	// Extra filters for modifying log content. This can e.g. be used for setting loglevel based on http status code.
+	// +kubebuilder:validation:Enum=http_loglevel;dns_loglevel
+	Logtransform string `json:"logtransform,omitempty"`
+
+	// Configures a Maskinporten client for this application.
+	// See [Maskinporten](https://doc.nais.io/security/auth/maskinporten/) for more details.
+	Maskinporten *Maskinporten `json:"maskinporten,omitempty"`
+
+	// The port number which is exposed by the container and should receive traffic.
+	Port int `json:"port,omitempty"`
+
+	// PreStopHook is called immediately before a container is terminated due to an API request or management event such as liveness/startup probe failure, preemption, resource contention, etc.
+	// The handler is not called if the container crashes or exits by itself.
+	// The reason for termination is passed to the handler.
+	// +nais:doc:Link="https://doc.nais.io/naisjob/#handles-termination-gracefully";"https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/#container-hooks"
+	PreStopHook *PreStopHook `json:"preStopHook,omitempty"`
+
+	// Prometheus is used to [scrape metrics from the pod](https://doc.nais.io/observability/metrics/).
+	// Use this configuration to override the default values.
+	Prometheus *PrometheusConfig `json:"prometheus,omitempty"`
+
+	// Sometimes, applications are temporarily unable to serve traffic. For example, an application might need
+	// to load large data or configuration files during startup, or depend on external services after startup.
+	// In such cases, you don't want to kill the application, but you don’t want to send it requests either.
+	// Kubernetes provides readiness probes to detect and mitigate these situations. A pod with containers
+	// reporting that they are not ready does not receive traffic through Kubernetes Services.
+	// Read more about this over at the [Kubernetes readiness documentation](https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/).
+	Readiness *Probe `json:"readiness,omitempty"`
+
+	// The numbers of pods to run in parallel.
+	Replicas *Replicas `json:"replicas,omitempty"`
+
+	// When Containers have [resource requests](http://kubernetes.io/docs/user-guide/compute-resources/) specified,
+	// the Kubernetes scheduler can make better decisions about which nodes to place pods on.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Whether or not to enable a sidecar container for secure logging.
+	SecureLogs *SecureLogs `json:"secureLogs,omitempty"`
+
+	// Specify which port and protocol is used to connect to the application in the container.
+	// Defaults to HTTP on port 80.
+	Service *Service `json:"service,omitempty"`
+
+	// Whether to skip injection of NAV certificate authority bundle or not. Defaults to false.
+	SkipCaBundle bool `json:"skipCaBundle,omitempty"`
+
+	// Kubernetes uses startup probes to know when a container application has started. If such a probe is configured,
+	// it disables liveness and readiness checks until it succeeds, making sure those probes don't interfere with the
+	// application startup. This can be used to adopt liveness checks on slow starting containers, avoiding them getting
+	// killed by Kubernetes before they are up and running.
+	Startup *Probe `json:"startup,omitempty"`
+
+	// Specifies the strategy used to replace old Pods by new ones.
+	Strategy *Strategy `json:"strategy,omitempty"`
+
+	// Provisions and configures a TokenX client for your application.
+	// +nais:doc:Link="https://doc.nais.io/security/auth/tokenx/"
+	TokenX *TokenX `json:"tokenx,omitempty"`
+
+	// Provides secrets management, identity-based access, and encrypting application data for auditing of secrets
+	// for applications, systems, and users.
+	// +nais:doc:Link="https://github.com/navikt/vault-iac/tree/master/doc"
+	// +nais:doc:Availability="on-premises"
+	Vault *Vault `json:"vault,omitempty"`
+
+	// Inject on-premises web proxy configuration into the application pod.
+	// Most Linux applications should auto-detect these settings from the `$HTTP_PROXY`, `$HTTPS_PROXY` and `$NO_PROXY` environment variables (and their lowercase counterparts).
+	// Java applications can start the JVM using parameters from the `$JAVA_PROXY_OPTIONS` environment variable.
+	// +nais:doc:Availability="on-premises"
+	WebProxy bool `json:"webproxy,omitempty"`
+}
+
+// ApplicationStatus contains different NAIS status properties
+type ApplicationStatus struct {
+	SynchronizationTime     int64  `json:"synchronizationTime,omitempty"`
+	RolloutCompleteTime     int64  `json:"rolloutCompleteTime,omitempty"`
+	CorrelationID           string `json:"correlationID,omitempty"`
+	DeploymentRolloutStatus string `json:"deploymentRolloutStatus,omitempty"`
+	SynchronizationState    string `json:"synchronizationState,omitempty"`
+	SynchronizationHash     string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *ApplicationStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *ApplicationStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *ApplicationStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *ApplicationStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Application `json:"items"`
+}
+
+// Hub marks this type as the conversion hub for all Application versions.
+// The deprecated `preStopHookPath` field from nais.io/v1alpha1 is expressed as `preStopHook.http.path`.
+func (*Application) Hub() {}
@@ -1,6 +1,7 @@
 package nais_io_v1
 
 import (
+	"github.com/nais/liberator/pkg/conditions"
 	"github.com/nais/liberator/pkg/hash"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +27,7 @@ const (
 // +kubebuilder:subresource:status
 
 // AzureAdApplication is the Schema for the AzureAdApplications API
+// +genclient
 // +kubebuilder:printcolumn:name="Client ID",type=string,JSONPath=`.status.clientId`
 // +kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.status.synchronizationTenantName`
 // +kubebuilder:printcolumn:name="Tenant ID",type=string,JSONPath=`.status.synchronizationTenant`,priority=1
@@ -65,7 +67,13 @@ type AzureAdApplicationSpec struct {
 	Tenant string `json:"tenant,omitempty"`
 	// Claims defines additional configuration of the emitted claims in tokens returned to the AzureAdApplication
 	Claims *AzureAdClaims `json:"claims,omitempty"`
+
+	// Breaking change: unlike the other credential resources, AzureAdApplication accepted any secretKeyPrefix before
+	// the pattern below, which is credentials.PrefixPattern, was added. Prefixes such as `MY-APP` or `AZURE_` are now
+	// rejected, and existing resources using them must change their prefix before they can be updated.
+
 	// SecretKeyPrefix is an optional user-defined prefix applied to the keys in the secret output, replacing the default prefix.
+	// +kubebuilder:validation:Pattern=`^([A-Za-z]|[A-Za-z_][A-Za-z0-9_]*[A-Za-z0-9])$`
 	SecretKeyPrefix string `json:"secretKeyPrefix,omitempty"`
 }
 
@@ -85,6 +93,8 @@ type AzureAdApplicationStatus struct {
 	ServicePrincipalId string `json:"servicePrincipalId,omitempty"`
 	// SynchronizationHash is the hash of the AzureAdApplication object
 	SynchronizationHash string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
 	// SynchronizationSecretName is the SecretName set in the last successful synchronization
 	SynchronizationSecretName string `json:"synchronizationSecretName,omitempty"`
 	// SynchronizationSecretRotationTime is the last time the AzureAdApplication had its keys rotated.
@@ -99,6 +109,24 @@ type AzureAdApplicationStatus struct {
 	SynchronizationTime *metav1.Time `json:"synchronizationTime,omitempty"`
 	// PreAuthorizedApps contains the list of desired pre-authorized apps defined in the spec, separated by their actual status in Azure AD.
 	PreAuthorizedApps *AzureAdPreAuthorizedAppsStatus `json:"preAuthorizedApps,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *AzureAdApplicationStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *AzureAdApplicationStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *AzureAdApplicationStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *AzureAdApplicationStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
 }
 
 type AzureAdPreAuthorizedAppsStatus struct {
@@ -0,0 +1,335 @@
+package nais_io_v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/imdario/mergo"
+)
+
+// Application spec default values
+const (
+	DefaultPortName                 = "http"
+	DefaultServicePort              = 80
+	DefaultAppPort                  = 8080
+	DeploymentStrategyRollingUpdate = "RollingUpdate"
+	DeploymentStrategyRecreate      = "Recreate"
+)
+
+// DefaultsProfile contains default values for Application and Naisjob resources.
+//
+// Profiles are typically loaded from YAML files and layered on top of each other,
+// e.g. global, then cluster, then namespace, where the most specific profile wins.
+// Values are merged field by field, so a profile only needs to contain the values it overrides.
+type DefaultsProfile struct {
+	// Default values for Application resources.
+	Application *ApplicationSpec `json:"application,omitempty"`
+	// Default values for Naisjob resources.
+	Naisjob *NaisjobSpec `json:"naisjob,omitempty"`
+	// Paths of fields explicitly set to false, 0 or the empty string, such as `application.replicas.min`.
+	// These values cannot be told apart from unset fields in the specs, so they are recorded by ParseDefaultsProfile
+	// and override earlier profiles when layered.
+	ZeroValues []string `json:"-"`
+}
+
+// BuiltinDefaultsProfile returns the default values shipped with liberator.
+// Changing these values will trigger a re-synchronization of all resources in all clusters.
+func BuiltinDefaultsProfile() *DefaultsProfile {
+	return &DefaultsProfile{
+		Application: &ApplicationSpec{
+			Azure: &Azure{
+				Application: &AzureApplication{
+					Enabled: false,
+				},
+			},
+			Replicas: &Replicas{
+				Min:                    2,
+				Max:                    4,
+				CpuThresholdPercentage: 50,
+			},
+			Liveness: &Probe{
+				PeriodSeconds:    DefaultProbePeriodSeconds,
+				Timeout:          DefaultProbeTimeoutSeconds,
+				FailureThreshold: DefaultProbeFailureThreshold,
+			},
+			Port: DefaultAppPort,
+			Strategy: &Strategy{
+				Type: DeploymentStrategyRollingUpdate,
+			},
+			Prometheus: &PrometheusConfig{
+				Path: "/metrics",
+			},
+			Ingresses: []Ingress{},
+			Resources: &ResourceRequirements{
+				Limits: &ResourceSpec{
+					Cpu:    "500m",
+					Memory: "512Mi",
+				},
+				Requests: &ResourceSpec{
+					Cpu:    "200m",
+					Memory: "256Mi",
+				},
+			},
+			Vault: &Vault{
+				Enabled: false,
+				Paths:   []SecretPath{},
+			},
+			Service: &Service{
+				Port:     DefaultServicePort,
+				Protocol: DefaultPortName,
+			},
+			SecureLogs: &SecureLogs{
+				Enabled: false,
+			},
+			AccessPolicy: &AccessPolicy{
+				Inbound: &AccessPolicyInbound{
+					Rules: []AccessPolicyInboundRule{},
+				},
+				Outbound: &AccessPolicyOutbound{
+					Rules:    []AccessPolicyRule{},
+					External: []AccessPolicyExternalRule{},
+				},
+			},
+			TokenX: &TokenX{
+				Enabled:                 false,
+				MountSecretsAsFilesOnly: false,
+			},
+		},
+		Naisjob: &NaisjobSpec{
+			Azure: &Azure{
+				Application: &AzureApplication{
+					Enabled: false,
+				},
+			},
+			BackoffLimit:           DefaultBackoffLimit,
+			FailedJobsHistoryLimit: DefaultFailedJobsHistoryLimit,
+			Liveness: &Probe{
+				PeriodSeconds:    DefaultProbePeriodSeconds,
+				Timeout:          DefaultProbeTimeoutSeconds,
+				FailureThreshold: DefaultProbeFailureThreshold,
+			},
+			Resources: &ResourceRequirements{
+				Limits: &ResourceSpec{
+					Cpu:    "500m",
+					Memory: "512Mi",
+				},
+				Requests: &ResourceSpec{
+					Cpu:    "200m",
+					Memory: "256Mi",
+				},
+			},
+			RestartPolicy:              "Never",
+			SuccessfulJobsHistoryLimit: DefaultSuccessfulJobsHistoryLimit,
+			Vault: &Vault{
+				Enabled: false,
+				Paths:   []SecretPath{},
+			},
+			SecureLogs: &SecureLogs{
+				Enabled: false,
+			},
+			AccessPolicy: &AccessPolicy{
+				Inbound: &AccessPolicyInbound{
+					Rules: []AccessPolicyInboundRule{},
+				},
+				Outbound: &AccessPolicyOutbound{
+					Rules:    []AccessPolicyRule{},
+					External: []AccessPolicyExternalRule{},
+				},
+			},
+		},
+	}
+}
+
+// ParseDefaultsProfile parses a YAML or JSON document into a DefaultsProfile.
+// Unknown fields are rejected to catch typos in profile files.
+func ParseDefaultsProfile(data []byte) (*DefaultsProfile, error) {
+	profile := &DefaultsProfile{}
+
+	js, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse defaults profile: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(js))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(profile)
+	if err != nil {
+		return nil, fmt.Errorf("parse defaults profile: %w", err)
+	}
+
+	document := make(map[string]interface{})
+	err = json.Unmarshal(js, &document)
+	if err != nil {
+		return nil, fmt.Errorf("parse defaults profile: %w", err)
+	}
+	profile.ZeroValues = zeroValuePaths(document, "")
+	sort.Strings(profile.ZeroValues)
+
+	return profile, nil
+}
+
+// LoadDefaultsProfile reads a DefaultsProfile from a YAML or JSON file.
+func LoadDefaultsProfile(path string) (*DefaultsProfile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load defaults profile: %w", err)
+	}
+	return ParseDefaultsProfile(data)
+}
+
+// LayerDefaultsProfiles merges a list of profiles, ordered from least to most specific,
+// into a single profile. Values from later profiles take precedence over earlier ones,
+// including the false, 0 and empty values listed in ZeroValues. Nil profiles are skipped.
+func LayerDefaultsProfiles(profiles ...*DefaultsProfile) (*DefaultsProfile, error) {
+	result := &DefaultsProfile{}
+	var zeroValues []string
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+		src := profile.DeepCopy()
+		src.ZeroValues = nil
+		err := mergo.Merge(result, src, mergo.WithOverride, mergo.WithTransformers(layerTransformers{}))
+		if err != nil {
+			return nil, fmt.Errorf("layer defaults profiles: %w", err)
+		}
+		for _, path := range profile.ZeroValues {
+			field, err := profileField(reflect.ValueOf(result), path, true)
+			if err != nil {
+				return nil, fmt.Errorf("layer defaults profiles: %w", err)
+			}
+			setZero(field)
+			zeroValues = append(zeroValues, path)
+		}
+	}
+
+	// Keep the paths that are still zero, so that the result can be layered again.
+	seen := make(map[string]bool)
+	for _, path := range zeroValues {
+		field, err := profileField(reflect.ValueOf(result), path, false)
+		if err == nil && isZero(field) && !seen[path] {
+			result.ZeroValues = append(result.ZeroValues, path)
+		}
+		seen[path] = true
+	}
+	sort.Strings(result.ZeroValues)
+
+	return result, nil
+}
+
+// layerTransformers merges structs behind pointers field by field.
+// With mergo.WithOverride, mergo would otherwise replace the whole struct, dropping values from earlier profiles.
+type layerTransformers struct{}
+
+func (layerTransformers) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		if src.IsNil() {
+			return nil
+		}
+		return mergo.Merge(dst.Interface(), src.Interface(), mergo.WithOverride, mergo.WithTransformers(layerTransformers{}))
+	}
+}
+
+// Returns the paths of all false, 0 and empty string values in a JSON document. Lists are not descended into.
+func zeroValuePaths(document map[string]interface{}, prefix string) []string {
+	var paths []string
+	for key, value := range document {
+		path := prefix + key
+		switch v := value.(type) {
+		case map[string]interface{}:
+			paths = append(paths, zeroValuePaths(v, path+".")...)
+		case bool:
+			if !v {
+				paths = append(paths, path)
+			}
+		case float64:
+			if v == 0 {
+				paths = append(paths, path)
+			}
+		case string:
+			if len(v) == 0 {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// Returns the field at a path of JSON field names, allocating nil structs along the way if allocate is set.
+// Paths into maps return the map, as map entries keep zero values when merged.
+func profileField(v reflect.Value, path string, allocate bool) (reflect.Value, error) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !allocate {
+					return v, fmt.Errorf("%s is not set", path)
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Map {
+			return v, nil
+		}
+		if v.Kind() != reflect.Struct {
+			return v, fmt.Errorf("%s: %s is not an object", path, name)
+		}
+		field, ok := fieldByJSONName(v, name)
+		if !ok {
+			return v, fmt.Errorf("%s: unknown field %s", path, name)
+		}
+		v = field
+	}
+	return v, nil
+}
+
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name || (len(tag) == 0 && field.Name == name) {
+			return v.Field(i), true
+		}
+		if field.Anonymous && len(tag) == 0 && v.Field(i).Kind() == reflect.Struct {
+			if inner, ok := fieldByJSONName(v.Field(i), name); ok {
+				return inner, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// Pointers are set to point to a zero value rather than nil, as they are set in the profile.
+func setZero(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Map:
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map:
+		return false
+	case reflect.Ptr:
+		return !v.IsNil() && v.Elem().IsZero()
+	}
+	return v.IsZero()
+}
+
+// Returns the built-in profile with the given profiles layered on top.
+func defaultsProfile(profiles []*DefaultsProfile) (*DefaultsProfile, error) {
+	return LayerDefaultsProfiles(append([]*DefaultsProfile{BuiltinDefaultsProfile()}, profiles...)...)
+}
@@ -0,0 +1,143 @@
+package nais_io_v1_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const clusterProfile = `
+application:
+  azure:
+    application:
+      tenant: trygdeetaten.no
+  replicas:
+    min: 1
+  liveness:
+    timeout: 5
+naisjob:
+  backoffLimit: 2
+`
+
+const namespaceProfile = `
+application:
+  replicas:
+    max: 10
+  resources:
+    requests:
+      cpu: 50m
+`
+
+func TestParseDefaultsProfile(t *testing.T) {
+	profile, err := nais_io_v1.ParseDefaultsProfile([]byte(clusterProfile))
+	assert.NoError(t, err)
+	assert.Equal(t, "trygdeetaten.no", profile.Application.Azure.Application.Tenant)
+	assert.Equal(t, int32(2), profile.Naisjob.BackoffLimit)
+
+	_, err = nais_io_v1.ParseDefaultsProfile([]byte("application:\n  replica:\n    min: 1\n"))
+	assert.Error(t, err, "unknown fields are rejected")
+}
+
+func TestLoadDefaultsProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "defaults-profile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "profile.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(clusterProfile), 0644))
+
+	profile, err := nais_io_v1.LoadDefaultsProfile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, profile.Application.Replicas.Min)
+
+	_, err = nais_io_v1.LoadDefaultsProfile(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLayerDefaultsProfiles(t *testing.T) {
+	cluster, err := nais_io_v1.ParseDefaultsProfile([]byte(clusterProfile))
+	assert.NoError(t, err)
+	namespace, err := nais_io_v1.ParseDefaultsProfile([]byte(namespaceProfile))
+	assert.NoError(t, err)
+
+	global := nais_io_v1.BuiltinDefaultsProfile()
+	profile, err := nais_io_v1.LayerDefaultsProfiles(global, cluster, nil, namespace)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &nais_io_v1.Replicas{Min: 1, Max: 10, CpuThresholdPercentage: 50}, profile.Application.Replicas)
+	assert.Equal(t, "50m", profile.Application.Resources.Requests.Cpu)
+	assert.Equal(t, "256Mi", profile.Application.Resources.Requests.Memory)
+	assert.Equal(t, 5, profile.Application.Liveness.Timeout)
+	assert.Equal(t, nais_io_v1.DefaultProbePeriodSeconds, profile.Application.Liveness.PeriodSeconds)
+	assert.Equal(t, int32(2), profile.Naisjob.BackoffLimit)
+
+	assert.Equal(t, nais_io_v1.BuiltinDefaultsProfile(), global, "input profiles are not modified")
+}
+
+func TestLayerDefaultsProfiles_ZeroValues(t *testing.T) {
+	cluster, err := nais_io_v1.ParseDefaultsProfile([]byte(`
+application:
+  replicas:
+    min: 4
+  tokenx:
+    enabled: true
+naisjob:
+  backoffLimit: 2
+`))
+	assert.NoError(t, err)
+	assert.Empty(t, cluster.ZeroValues)
+
+	namespace, err := nais_io_v1.ParseDefaultsProfile([]byte(`
+application:
+  replicas:
+    min: 0
+  tokenx:
+    enabled: false
+naisjob:
+  backoffLimit: 0
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"application.replicas.min", "application.tokenx.enabled", "naisjob.backoffLimit"}, namespace.ZeroValues)
+
+	profile, err := nais_io_v1.LayerDefaultsProfiles(nais_io_v1.BuiltinDefaultsProfile(), cluster, namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, profile.Application.Replicas.Min, "zero overrides earlier profiles")
+	assert.Equal(t, 4, profile.Application.Replicas.Max)
+	assert.False(t, profile.Application.TokenX.Enabled, "false overrides earlier profiles")
+	assert.Equal(t, int32(0), profile.Naisjob.BackoffLimit)
+	assert.Equal(t, namespace.ZeroValues, profile.ZeroValues, "layered profiles can be layered again")
+
+	profile, err = nais_io_v1.LayerDefaultsProfiles(nais_io_v1.BuiltinDefaultsProfile(), namespace, cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, profile.Application.Replicas.Min)
+	assert.True(t, profile.Application.TokenX.Enabled)
+	assert.Equal(t, int32(2), profile.Naisjob.BackoffLimit)
+	assert.Empty(t, profile.ZeroValues, "zero values overridden by later profiles are dropped")
+
+	profile, err = nais_io_v1.LayerDefaultsProfiles(nais_io_v1.BuiltinDefaultsProfile(), cluster, namespace)
+	assert.NoError(t, err)
+	app := &nais_io_v1.Application{}
+	app.Spec.Replicas = &nais_io_v1.Replicas{Max: 3}
+	assert.NoError(t, app.ApplyDefaults(profile))
+	assert.Equal(t, 0, app.Spec.Replicas.Min, "zero values in profiles are applied to resources")
+	assert.Equal(t, 3, app.Spec.Replicas.Max)
+}
+
+func TestNaisjob_ApplyDefaultsWithProfile(t *testing.T) {
+	cluster, err := nais_io_v1.ParseDefaultsProfile([]byte(clusterProfile))
+	assert.NoError(t, err)
+
+	job := minimalNaisjob()
+	job.Spec.BackoffLimit = 4
+	assert.NoError(t, job.ApplyDefaults(cluster))
+	assert.Equal(t, int32(4), job.Spec.BackoffLimit, "explicit values are kept")
+	assert.Equal(t, int32(nais_io_v1.DefaultSuccessfulJobsHistoryLimit), job.Spec.SuccessfulJobsHistoryLimit)
+
+	job = minimalNaisjob()
+	assert.NoError(t, job.ApplyDefaults(cluster))
+	assert.Equal(t, int32(2), job.Spec.BackoffLimit)
+}
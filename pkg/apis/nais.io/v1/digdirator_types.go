@@ -1,6 +1,7 @@
 package nais_io_v1
 
 import (
+	"github.com/nais/liberator/pkg/conditions"
 	"github.com/nais/liberator/pkg/hash"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -13,6 +14,8 @@ type DigdiratorStatus struct {
 	SynchronizationTime *metav1.Time `json:"synchronizationTime,omitempty"`
 	// SynchronizationHash is the hash of the Instance object
 	SynchronizationHash string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
 	// SynchronizationSecretName is the SecretName set in the last successful synchronization
 	SynchronizationSecretName string `json:"synchronizationSecretName,omitempty"`
 	// ClientID is the corresponding client ID for this client at Digdir
@@ -21,6 +24,24 @@ type DigdiratorStatus struct {
 	CorrelationID string `json:"correlationID,omitempty"`
 	// KeyIDs is the list of key IDs for valid JWKs registered for the client at Digdir
 	KeyIDs []string `json:"keyIDs,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *DigdiratorStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *DigdiratorStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *DigdiratorStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *DigdiratorStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
 }
 
 func (in *DigdiratorStatus) GetSynchronizationHash() string {
@@ -87,6 +108,7 @@ func init() {
 
 // MaskinportenClient is the Schema for the MaskinportenClient API
 // +genclient
+// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type MaskinportenClient struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -102,6 +124,9 @@ type MaskinportenClientSpec struct {
 	Scopes MaskinportenScope `json:"scopes,omitempty"`
 	// SecretName is the name of the resulting Secret resource to be created
 	SecretName string `json:"secretName"`
+	// SecretKeyPrefix is an optional user-defined prefix applied to the keys in the secret output, replacing the default prefix.
+	// +kubebuilder:validation:Pattern=`^([A-Za-z]|[A-Za-z_][A-Za-z0-9_]*[A-Za-z0-9])$`
+	SecretKeyPrefix string `json:"secretKeyPrefix,omitempty"`
 }
 
 // MaskinportenClientList contains a list of MaskinportenClient
@@ -216,6 +241,7 @@ func init() {
 
 // IDPortenClient is the Schema for the IDPortenClients API
 // +genclient
+// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type IDPortenClient struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -254,6 +280,9 @@ type IDPortenClientSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=3600
 	AccessTokenLifetime *int `json:"accessTokenLifetime,omitempty"`
+	// SecretKeyPrefix is an optional user-defined prefix applied to the keys in the secret output, replacing the default prefix.
+	// +kubebuilder:validation:Pattern=`^([A-Za-z]|[A-Za-z_][A-Za-z0-9_]*[A-Za-z0-9])$`
+	SecretKeyPrefix string `json:"secretKeyPrefix,omitempty"`
 }
 
 func (in *IDPortenClient) Hash() (string, error) {
@@ -1,6 +1,7 @@
 package nais_io_v1
 
 import (
+	"github.com/nais/liberator/pkg/conditions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,6 +21,9 @@ func init() {
 type JwkerSpec struct {
 	AccessPolicy *AccessPolicy `json:"accessPolicy"` // fixme: access policy should not have rules required, but cluster and namespace. doesn't need external.
 	SecretName   string        `json:"secretName"`
+	// SecretKeyPrefix is an optional user-defined prefix applied to the keys in the secret output, replacing the default prefix.
+	// +kubebuilder:validation:Pattern=`^([A-Za-z]|[A-Za-z_][A-Za-z0-9_]*[A-Za-z0-9])$`
+	SecretKeyPrefix string `json:"secretKeyPrefix,omitempty"`
 }
 
 // JwkerStatus defines the observed state of Jwker
@@ -28,9 +32,30 @@ type JwkerStatus struct {
 	SynchronizationState      string `json:"synchronizationState,omitempty"`
 	SynchronizationHash       string `json:"synchronizationHash,omitempty"`
 	SynchronizationSecretName string `json:"synchronizationSecretName,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *JwkerStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *JwkerStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *JwkerStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *JwkerStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
 }
 
 // +genclient
+// +genclient:noStatus
 // +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretName"
 // +kubebuilder:object:root=true
 
@@ -15,53 +15,22 @@ const (
 )
 
 // ApplyDefaults sets default values where they are missing from an Application spec.
-func (job *Naisjob) ApplyDefaults() error {
-	return mergo.Merge(job, getNaisjobDefaults())
+//
+// Any profiles given are layered, from least to most specific, on top of the built-in defaults.
+func (job *Naisjob) ApplyDefaults(profiles ...*DefaultsProfile) error {
+	defaults, err := getNaisjobDefaults(profiles)
+	if err != nil {
+		return err
+	}
+	return mergo.Merge(job, defaults)
 }
 
-func getNaisjobDefaults() *Naisjob {
-	return &Naisjob{
-		Spec: NaisjobSpec{
-			Azure: &Azure{
-				Application: &AzureApplication{
-					Enabled: false,
-				},
-			},
-			BackoffLimit:           DefaultBackoffLimit,
-			FailedJobsHistoryLimit: DefaultFailedJobsHistoryLimit,
-			Liveness: &Probe{
-				PeriodSeconds:    DefaultProbePeriodSeconds,
-				Timeout:          DefaultProbeTimeoutSeconds,
-				FailureThreshold: DefaultProbeFailureThreshold,
-			},
-			Resources: &ResourceRequirements{
-				Limits: &ResourceSpec{
-					Cpu:    "500m",
-					Memory: "512Mi",
-				},
-				Requests: &ResourceSpec{
-					Cpu:    "200m",
-					Memory: "256Mi",
-				},
-			},
-			RestartPolicy: "Never",
-			SuccessfulJobsHistoryLimit: DefaultSuccessfulJobsHistoryLimit,
-			Vault: &Vault{
-				Enabled: false,
-				Paths:   []SecretPath{},
-			},
-			SecureLogs: &SecureLogs{
-				Enabled: false,
-			},
-			AccessPolicy: &AccessPolicy{
-				Inbound: &AccessPolicyInbound{
-					Rules: []AccessPolicyInboundRule{},
-				},
-				Outbound: &AccessPolicyOutbound{
-					Rules:    []AccessPolicyRule{},
-					External: []AccessPolicyExternalRule{},
-				},
-			},
-		},
+func getNaisjobDefaults(profiles []*DefaultsProfile) (*Naisjob, error) {
+	profile, err := defaultsProfile(profiles)
+	if err != nil {
+		return nil, err
 	}
+	return &Naisjob{
+		Spec: *profile.Naisjob,
+	}, nil
 }
@@ -27,6 +27,8 @@ var ignoredApplicationFields = []string{
 	`.ObjectMeta.SelfLink`,
 	`.ObjectMeta.UID`,
 	`.Status`,
+	`.Status.Conditions`,
+	`.Status.LiberatorVersion`,
 	`.Status.CorrelationID`,
 	`.Status.DeploymentRolloutStatus`,
 	`.Status.RolloutCompleteTime`,
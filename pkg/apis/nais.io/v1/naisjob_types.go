@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/google/uuid"
+	"github.com/nais/liberator/pkg/conditions"
 	"github.com/nais/liberator/pkg/hash"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +23,7 @@ func init() {
 // Naisjob defines a NAIS Naisjob.
 //
 // +genclient
+// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
@@ -192,6 +194,26 @@ type NaisjobStatus struct {
 	DeploymentRolloutStatus string `json:"deploymentRolloutStatus,omitempty"`
 	SynchronizationState    string `json:"synchronizationState,omitempty"`
 	SynchronizationHash     string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *NaisjobStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *NaisjobStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *NaisjobStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *NaisjobStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
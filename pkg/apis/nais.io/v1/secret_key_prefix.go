@@ -0,0 +1,115 @@
+package nais_io_v1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/nais/liberator/pkg/credentials"
+)
+
+var secretKeyPrefixPath = field.NewPath("spec", "secretKeyPrefix")
+
+// Credential resources are validated by their webhooks, see webhook.SetupCredentialWebhooks.
+var (
+	_ webhook.Validator = &AzureAdApplication{}
+	_ webhook.Validator = &MaskinportenClient{}
+	_ webhook.Validator = &IDPortenClient{}
+	_ webhook.Validator = &Jwker{}
+)
+
+// ValidateSecretKeyPrefix checks that the secret key prefix yields valid environment variable names.
+func (in *AzureAdApplication) ValidateSecretKeyPrefix() field.ErrorList {
+	return credentials.ValidatePrefix(credentials.AzureCredentials{}, in.Spec.SecretKeyPrefix, secretKeyPrefixPath)
+}
+
+// ValidateSecretKeyPrefix checks that the secret key prefix yields valid environment variable names.
+func (in *MaskinportenClient) ValidateSecretKeyPrefix() field.ErrorList {
+	return credentials.ValidatePrefix(credentials.MaskinportenCredentials{}, in.Spec.SecretKeyPrefix, secretKeyPrefixPath)
+}
+
+// ValidateSecretKeyPrefix checks that the secret key prefix yields valid environment variable names.
+func (in *IDPortenClient) ValidateSecretKeyPrefix() field.ErrorList {
+	return credentials.ValidatePrefix(credentials.IDPortenCredentials{}, in.Spec.SecretKeyPrefix, secretKeyPrefixPath)
+}
+
+// ValidateSecretKeyPrefix checks that the secret key prefix yields valid environment variable names.
+func (in *Jwker) ValidateSecretKeyPrefix() field.ErrorList {
+	return credentials.ValidatePrefix(credentials.TokenXCredentials{}, in.Spec.SecretKeyPrefix, secretKeyPrefixPath)
+}
+
+// +kubebuilder:webhook:path=/validate-nais-io-v1-azureadapplication,mutating=false,failurePolicy=fail,groups=nais.io,resources=azureadapplications,verbs=create;update,versions=v1,name=vazureadapplication.nais.io
+
+// ValidateCreate implements webhook.Validator.
+func (in *AzureAdApplication) ValidateCreate() error {
+	return invalid("AzureAdApplication", in.GetName(), in.ValidateSecretKeyPrefix())
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (in *AzureAdApplication) ValidateUpdate(old runtime.Object) error {
+	return in.ValidateCreate()
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is always allowed.
+func (in *AzureAdApplication) ValidateDelete() error {
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-nais-io-v1-maskinportenclient,mutating=false,failurePolicy=fail,groups=nais.io,resources=maskinportenclients,verbs=create;update,versions=v1,name=vmaskinportenclient.nais.io
+
+// ValidateCreate implements webhook.Validator.
+func (in *MaskinportenClient) ValidateCreate() error {
+	return invalid("MaskinportenClient", in.GetName(), in.ValidateSecretKeyPrefix())
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (in *MaskinportenClient) ValidateUpdate(old runtime.Object) error {
+	return in.ValidateCreate()
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is always allowed.
+func (in *MaskinportenClient) ValidateDelete() error {
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-nais-io-v1-idportenclient,mutating=false,failurePolicy=fail,groups=nais.io,resources=idportenclients,verbs=create;update,versions=v1,name=vidportenclient.nais.io
+
+// ValidateCreate implements webhook.Validator.
+func (in *IDPortenClient) ValidateCreate() error {
+	return invalid("IDPortenClient", in.GetName(), in.ValidateSecretKeyPrefix())
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (in *IDPortenClient) ValidateUpdate(old runtime.Object) error {
+	return in.ValidateCreate()
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is always allowed.
+func (in *IDPortenClient) ValidateDelete() error {
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-nais-io-v1-jwker,mutating=false,failurePolicy=fail,groups=nais.io,resources=jwkers,verbs=create;update,versions=v1,name=vjwker.nais.io
+
+// ValidateCreate implements webhook.Validator.
+func (in *Jwker) ValidateCreate() error {
+	return invalid("Jwker", in.GetName(), in.ValidateSecretKeyPrefix())
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (in *Jwker) ValidateUpdate(old runtime.Object) error {
+	return in.ValidateCreate()
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is always allowed.
+func (in *Jwker) ValidateDelete() error {
+	return nil
+}
+
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
@@ -0,0 +1,75 @@
+package nais_io_v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+func TestValidateSecretKeyPrefix(t *testing.T) {
+	maskinporten := minimalMaskinportenClient()
+	assert.Empty(t, maskinporten.ValidateSecretKeyPrefix(), "default prefix")
+
+	maskinporten.Spec.SecretKeyPrefix = "MY_MASKINPORTEN"
+	assert.Empty(t, maskinporten.ValidateSecretKeyPrefix())
+
+	for _, invalid := range []string{"MY-MASKINPORTEN", "1MASKINPORTEN", "MASKINPORTEN_"} {
+		maskinporten.Spec.SecretKeyPrefix = invalid
+		errs := maskinporten.ValidateSecretKeyPrefix()
+		if assert.Len(t, errs, 1, invalid) {
+			assert.Equal(t, "spec.secretKeyPrefix", errs[0].Field)
+		}
+	}
+
+	idporten := minimalIDPortenClient()
+	idporten.Spec.SecretKeyPrefix = "ID-PORTEN"
+	assert.Len(t, idporten.ValidateSecretKeyPrefix(), 1)
+
+	jwker := &nais_io_v1.Jwker{Spec: nais_io_v1.JwkerSpec{SecretKeyPrefix: "TOKENX"}}
+	assert.Empty(t, jwker.ValidateSecretKeyPrefix())
+
+	azure := &nais_io_v1.AzureAdApplication{Spec: nais_io_v1.AzureAdApplicationSpec{SecretKeyPrefix: "AZURE AD"}}
+	assert.Len(t, azure.ValidateSecretKeyPrefix(), 1)
+}
+
+func TestSecretKeyPrefix_Validator(t *testing.T) {
+	jwker := &nais_io_v1.Jwker{Spec: nais_io_v1.JwkerSpec{SecretKeyPrefix: "TOKENX"}}
+	jwker.Name = "myapp"
+	assert.NoError(t, jwker.ValidateCreate())
+
+	jwker.Spec.SecretKeyPrefix = "TOKEN-X"
+	err := jwker.ValidateUpdate(jwker.DeepCopy())
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.secretKeyPrefix")
+	assert.NoError(t, jwker.ValidateDelete(), "invalid resources can be deleted")
+
+	azure := &nais_io_v1.AzureAdApplication{Spec: nais_io_v1.AzureAdApplicationSpec{SecretKeyPrefix: "AZURE AD"}}
+	assert.True(t, apierrors.IsInvalid(azure.ValidateCreate()))
+}
+
+func TestSecretKeyPrefixHash(t *testing.T) {
+	hashes := func(prefix string) []string {
+		maskinporten := minimalMaskinportenClient()
+		maskinporten.Spec.SecretKeyPrefix = prefix
+		idporten := minimalIDPortenClient()
+		idporten.Spec.SecretKeyPrefix = prefix
+		jwker := nais_io_v1.JwkerSpec{AccessPolicy: accessPolicy, SecretName: secretName, SecretKeyPrefix: prefix}
+
+		result := make([]string, 0)
+		for _, fn := range []func() (string, error){maskinporten.Hash, idporten.Hash, jwker.Hash} {
+			h, err := fn()
+			assert.NoError(t, err)
+			result = append(result, h)
+		}
+		return result
+	}
+
+	unprefixed := hashes("")
+	prefixed := hashes("MY_PREFIX")
+	for i := range unprefixed {
+		assert.NotEqual(t, unprefixed[i], prefixed[i])
+	}
+}
@@ -5,6 +5,7 @@
 package nais_io_v1
 
 import (
+	"github.com/nais/liberator/pkg/conditions"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -224,7 +225,7 @@ func (in *Alert) DeepCopyInto(out *Alert) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Alert.
@@ -280,12 +281,21 @@ func (in *AlertList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertSpec) DeepCopyInto(out *AlertSpec) {
 	*out = *in
-	out.Route = in.Route
+	in.Route.DeepCopyInto(&out.Route)
 	in.Receivers.DeepCopyInto(&out.Receivers)
 	if in.Alerts != nil {
 		in, out := &in.Alerts, &out.Alerts
 		*out = make([]Rule, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalReceivers != nil {
+		in, out := &in.AdditionalReceivers, &out.AdditionalReceivers
+		*out = make([]NamedReceivers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.InhibitRules != nil {
 		in, out := &in.InhibitRules, &out.InhibitRules
@@ -309,6 +319,13 @@ func (in *AlertSpec) DeepCopy() *AlertSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertStatus) DeepCopyInto(out *AlertStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertStatus.
@@ -321,6 +338,229 @@ func (in *AlertStatus) DeepCopy() *AlertStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Application) DeepCopyInto(out *Application) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Application.
+func (in *Application) DeepCopy() *Application {
+	if in == nil {
+		return nil
+	}
+	out := new(Application)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Application) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationList) DeepCopyInto(out *ApplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Application, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationList.
+func (in *ApplicationList) DeepCopy() *ApplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
+	*out = *in
+	if in.AccessPolicy != nil {
+		in, out := &in.AccessPolicy, &out.AccessPolicy
+		*out = new(AccessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(Azure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Elastic != nil {
+		in, out := &in.Elastic, &out.Elastic
+		*out = new(Elastic)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(EnvVars, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]EnvFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilesFrom != nil {
+		in, out := &in.FilesFrom, &out.FilesFrom
+		*out = make([]FilesFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IDPorten != nil {
+		in, out := &in.IDPorten, &out.IDPorten
+		*out = new(IDPorten)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingresses != nil {
+		in, out := &in.Ingresses, &out.Ingresses
+		*out = make([]Ingress, len(*in))
+		copy(*out, *in)
+	}
+	if in.Influx != nil {
+		in, out := &in.Influx, &out.Influx
+		*out = new(Influx)
+		**out = **in
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(Kafka)
+		**out = **in
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(Probe)
+		**out = **in
+	}
+	if in.Maskinporten != nil {
+		in, out := &in.Maskinporten, &out.Maskinporten
+		*out = new(Maskinporten)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreStopHook != nil {
+		in, out := &in.PreStopHook, &out.PreStopHook
+		*out = new(PreStopHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusConfig)
+		**out = **in
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(Probe)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(Replicas)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecureLogs != nil {
+		in, out := &in.SecureLogs, &out.SecureLogs
+		*out = new(SecureLogs)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(Service)
+		**out = **in
+	}
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(Probe)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(Strategy)
+		**out = **in
+	}
+	if in.TokenX != nil {
+		in, out := &in.TokenX, &out.TokenX
+		*out = new(TokenX)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(Vault)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
+func (in *ApplicationSpec) DeepCopy() *ApplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationStatus.
+func (in *ApplicationStatus) DeepCopy() *ApplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Azure) DeepCopyInto(out *Azure) {
 	*out = *in
@@ -458,6 +698,13 @@ func (in *AzureAdApplicationStatus) DeepCopyInto(out *AzureAdApplicationStatus)
 		*out = new(AzureAdPreAuthorizedAppsStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureAdApplicationStatus.
@@ -609,6 +856,29 @@ func (in *AzureApplication) DeepCopy() *AzureApplication {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChildRoute) DeepCopyInto(out *ChildRoute) {
+	*out = *in
+	in.LeafRoute.DeepCopyInto(&out.LeafRoute)
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]LeafRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChildRoute.
+func (in *ChildRoute) DeepCopy() *ChildRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ChildRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudBigQueryDataset) DeepCopyInto(out *CloudBigQueryDataset) {
 	*out = *in
@@ -762,6 +1032,36 @@ func (in *ConsumedScope) DeepCopy() *ConsumedScope {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultsProfile) DeepCopyInto(out *DefaultsProfile) {
+	*out = *in
+	if in.Application != nil {
+		in, out := &in.Application, &out.Application
+		*out = new(ApplicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naisjob != nil {
+		in, out := &in.Naisjob, &out.Naisjob
+		*out = new(NaisjobSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZeroValues != nil {
+		in, out := &in.ZeroValues, &out.ZeroValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultsProfile.
+func (in *DefaultsProfile) DeepCopy() *DefaultsProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultsProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DigdiratorStatus) DeepCopyInto(out *DigdiratorStatus) {
 	*out = *in
@@ -774,6 +1074,13 @@ func (in *DigdiratorStatus) DeepCopyInto(out *DigdiratorStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigdiratorStatus.
@@ -1215,7 +1522,7 @@ func (in *Jwker) DeepCopyInto(out *Jwker) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jwker.
@@ -1291,6 +1598,13 @@ func (in *JwkerSpec) DeepCopy() *JwkerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JwkerStatus) DeepCopyInto(out *JwkerStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JwkerStatus.
@@ -1318,6 +1632,35 @@ func (in *Kafka) DeepCopy() *Kafka {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeafRoute) DeepCopyInto(out *LeafRoute) {
+	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchRegex != nil {
+		in, out := &in.MatchRegex, &out.MatchRegex
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeafRoute.
+func (in *LeafRoute) DeepCopy() *LeafRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(LeafRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LifecycleCondition) DeepCopyInto(out *LifecycleCondition) {
 	*out = *in
@@ -1477,7 +1820,7 @@ func (in *Naisjob) DeepCopyInto(out *Naisjob) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Naisjob.
@@ -1650,6 +1993,13 @@ func (in *NaisjobSpec) DeepCopy() *NaisjobSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NaisjobStatus) DeepCopyInto(out *NaisjobStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]conditions.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NaisjobStatus.
@@ -1662,6 +2012,22 @@ func (in *NaisjobStatus) DeepCopy() *NaisjobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedReceivers) DeepCopyInto(out *NamedReceivers) {
+	*out = *in
+	in.Receivers.DeepCopyInto(&out.Receivers)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedReceivers.
+func (in *NamedReceivers) DeepCopy() *NamedReceivers {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedReceivers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectFieldSelector) DeepCopyInto(out *ObjectFieldSelector) {
 	*out = *in
@@ -1677,6 +2043,27 @@ func (in *ObjectFieldSelector) DeepCopy() *ObjectFieldSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDuty) DeepCopyInto(out *PagerDuty) {
+	*out = *in
+	out.RoutingKey = in.RoutingKey
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDuty.
+func (in *PagerDuty) DeepCopy() *PagerDuty {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDuty)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreStopHook) DeepCopyInto(out *PreStopHook) {
 	*out = *in
@@ -1738,6 +2125,21 @@ func (in *Receivers) DeepCopyInto(out *Receivers) {
 	in.Slack.DeepCopyInto(&out.Slack)
 	out.Email = in.Email
 	in.SMS.DeepCopyInto(&out.SMS)
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(Webhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(Teams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDuty)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Receivers.
@@ -1808,6 +2210,13 @@ func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ChildRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
@@ -1823,6 +2232,13 @@ func (in *Route) DeepCopy() *Route {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rule.
@@ -1855,6 +2271,21 @@ func (in *SMS) DeepCopy() *SMS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretPath) DeepCopyInto(out *SecretPath) {
 	*out = *in
@@ -1935,6 +2366,26 @@ func (in *Strategy) DeepCopy() *Strategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Teams) DeepCopyInto(out *Teams) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Teams.
+func (in *Teams) DeepCopy() *Teams {
+	if in == nil {
+		return nil
+	}
+	out := new(Teams)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenX) DeepCopyInto(out *TokenX) {
 	*out = *in
@@ -1969,3 +2420,49 @@ func (in *Vault) DeepCopy() *Vault {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Webhook) DeepCopyInto(out *Webhook) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(WebhookBasicAuth)
+		**out = **in
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Webhook.
+func (in *Webhook) DeepCopy() *Webhook {
+	if in == nil {
+		return nil
+	}
+	out := new(Webhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookBasicAuth) DeepCopyInto(out *WebhookBasicAuth) {
+	*out = *in
+	out.Password = in.Password
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookBasicAuth.
+func (in *WebhookBasicAuth) DeepCopy() *WebhookBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
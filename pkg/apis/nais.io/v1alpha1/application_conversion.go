@@ -0,0 +1,149 @@
+package nais_io_v1alpha1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// PreStopHookPathAnnotation retains `spec.preStopHookPath` when an Application is converted to nais.io/v1,
+// where the field has been replaced by `spec.preStopHook.http.path`.
+const PreStopHookPathAnnotation = "nais.io/v1alpha1.preStopHookPath"
+
+var _ conversion.Convertible = &Application{}
+
+// ConvertTo converts this Application to the hub version (nais.io/v1).
+func (in *Application) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*nais_io_v1.Application)
+	if !ok {
+		return fmt.Errorf("unsupported conversion from Application to %T", hub)
+	}
+
+	src := in.DeepCopy()
+
+	dst.TypeMeta = src.TypeMeta
+	dst.APIVersion = nais_io_v1.GroupVersion.String()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = nais_io_v1.ApplicationStatus(src.Status)
+	dst.Spec = nais_io_v1.ApplicationSpec{
+		AccessPolicy:   src.Spec.AccessPolicy,
+		Azure:          src.Spec.Azure,
+		Command:        src.Spec.Command,
+		Elastic:        src.Spec.Elastic,
+		Env:            src.Spec.Env,
+		EnvFrom:        src.Spec.EnvFrom,
+		FilesFrom:      src.Spec.FilesFrom,
+		GCP:            src.Spec.GCP,
+		IDPorten:       src.Spec.IDPorten,
+		Image:          src.Spec.Image,
+		Ingresses:      src.Spec.Ingresses,
+		Influx:         src.Spec.Influx,
+		Kafka:          src.Spec.Kafka,
+		LeaderElection: src.Spec.LeaderElection,
+		Liveness:       src.Spec.Liveness,
+		Logformat:      src.Spec.Logformat,
+		Logtransform:   src.Spec.Logtransform,
+		Maskinporten:   src.Spec.Maskinporten,
+		Port:           src.Spec.Port,
+		PreStopHook:    src.Spec.PreStopHook,
+		Prometheus:     src.Spec.Prometheus,
+		Readiness:      src.Spec.Readiness,
+		Replicas:       src.Spec.Replicas,
+		Resources:      src.Spec.Resources,
+		SecureLogs:     src.Spec.SecureLogs,
+		Service:        src.Spec.Service,
+		SkipCaBundle:   src.Spec.SkipCaBundle,
+		Startup:        src.Spec.Startup,
+		Strategy:       src.Spec.Strategy,
+		TokenX:         src.Spec.TokenX,
+		Vault:          src.Spec.Vault,
+		WebProxy:       src.Spec.WebProxy,
+	}
+
+	// The annotation records that the hook was synthesized, and is only needed if there is no hook already.
+	if len(src.Spec.PreStopHookPath) > 0 && dst.Spec.PreStopHook == nil {
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[PreStopHookPathAnnotation] = src.Spec.PreStopHookPath
+		dst.Spec.PreStopHook = &nais_io_v1.PreStopHook{
+			Http: &nais_io_v1.HttpGetAction{
+				Path: src.Spec.PreStopHookPath,
+			},
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (nais.io/v1) to this version.
+func (in *Application) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*nais_io_v1.Application)
+	if !ok {
+		return fmt.Errorf("unsupported conversion from %T to Application", hub)
+	}
+
+	src = src.DeepCopy()
+
+	in.TypeMeta = src.TypeMeta
+	in.APIVersion = GroupVersion.String()
+	in.ObjectMeta = src.ObjectMeta
+	in.Status = ApplicationStatus(src.Status)
+	in.Spec = ApplicationSpec{
+		AccessPolicy:   src.Spec.AccessPolicy,
+		Azure:          src.Spec.Azure,
+		Command:        src.Spec.Command,
+		Elastic:        src.Spec.Elastic,
+		Env:            src.Spec.Env,
+		EnvFrom:        src.Spec.EnvFrom,
+		FilesFrom:      src.Spec.FilesFrom,
+		GCP:            src.Spec.GCP,
+		IDPorten:       src.Spec.IDPorten,
+		Image:          src.Spec.Image,
+		Ingresses:      src.Spec.Ingresses,
+		Influx:         src.Spec.Influx,
+		Kafka:          src.Spec.Kafka,
+		LeaderElection: src.Spec.LeaderElection,
+		Liveness:       src.Spec.Liveness,
+		Logformat:      src.Spec.Logformat,
+		Logtransform:   src.Spec.Logtransform,
+		Maskinporten:   src.Spec.Maskinporten,
+		Port:           src.Spec.Port,
+		PreStopHook:    src.Spec.PreStopHook,
+		Prometheus:     src.Spec.Prometheus,
+		Readiness:      src.Spec.Readiness,
+		Replicas:       src.Spec.Replicas,
+		Resources:      src.Spec.Resources,
+		SecureLogs:     src.Spec.SecureLogs,
+		Service:        src.Spec.Service,
+		SkipCaBundle:   src.Spec.SkipCaBundle,
+		Startup:        src.Spec.Startup,
+		Strategy:       src.Spec.Strategy,
+		TokenX:         src.Spec.TokenX,
+		Vault:          src.Spec.Vault,
+		WebProxy:       src.Spec.WebProxy,
+	}
+
+	path, found := in.Annotations[PreStopHookPathAnnotation]
+	if !found {
+		return nil
+	}
+
+	delete(in.Annotations, PreStopHookPathAnnotation)
+	if len(in.Annotations) == 0 {
+		in.Annotations = nil
+	}
+
+	// preStopHookPath is only restored if the hook is still the one synthesized from it by ConvertTo.
+	// If the hook was changed or removed through nais.io/v1, the hook takes precedence, as setting
+	// both preStopHookPath and preStopHook is invalid.
+	hook := in.Spec.PreStopHook
+	if hook != nil && hook.Exec == nil && hook.Http != nil && hook.Http.Port == nil && hook.Http.Path == path {
+		in.Spec.PreStopHookPath = path
+		in.Spec.PreStopHook = nil
+	}
+
+	return nil
+}
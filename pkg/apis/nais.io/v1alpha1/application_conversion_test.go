@@ -0,0 +1,122 @@
+package nais_io_v1alpha1_test
+
+import (
+	"testing"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func roundTrip(t *testing.T, app *nais_io_v1alpha1.Application) (*nais_io_v1.Application, *nais_io_v1alpha1.Application) {
+	hub := &nais_io_v1.Application{}
+	err := app.ConvertTo(hub)
+	assert.NoError(t, err)
+
+	converted := &nais_io_v1alpha1.Application{}
+	err = converted.ConvertFrom(hub)
+	assert.NoError(t, err)
+
+	return hub, converted
+}
+
+func TestApplication_ConvertRoundTrip(t *testing.T) {
+	t.Run("documentation example survives a round trip", func(t *testing.T) {
+		app := nais_io_v1alpha1.ExampleApplicationForDocumentation()
+		// The example documents both preStopHook and preStopHookPath, which is rejected by validation.
+		app.Spec.PreStopHookPath = ""
+		hub, converted := roundTrip(t, app)
+
+		assert.Equal(t, "nais.io/v1", hub.APIVersion)
+		assert.Equal(t, app.Spec.Strategy, hub.Spec.Strategy)
+		assert.Equal(t, app.Spec.PreStopHook, hub.Spec.PreStopHook)
+		assert.Equal(t, app, converted)
+	})
+
+	t.Run("preStopHookPath is expressed as preStopHook in the hub", func(t *testing.T) {
+		app := &nais_io_v1alpha1.Application{
+			Spec: nais_io_v1alpha1.ApplicationSpec{
+				PreStopHookPath: "/stop",
+				Strategy: &nais_io_v1.Strategy{
+					Type: nais_io_v1alpha1.DeploymentStrategyRecreate,
+				},
+			},
+		}
+		hub, converted := roundTrip(t, app)
+
+		assert.Equal(t, "/stop", hub.Spec.PreStopHook.Http.Path)
+		assert.Equal(t, "/stop", hub.Annotations[nais_io_v1alpha1.PreStopHookPathAnnotation])
+
+		app.APIVersion = "nais.io/v1alpha1"
+		assert.Equal(t, app, converted)
+	})
+
+	t.Run("source object is not modified", func(t *testing.T) {
+		app := nais_io_v1alpha1.ExampleApplicationForDocumentation()
+		hub := &nais_io_v1.Application{}
+		assert.NoError(t, app.ConvertTo(hub))
+
+		hub.Spec.Env[0].Value = "changed"
+		assert.NotEqual(t, "changed", app.Spec.Env[0].Value)
+		assert.Empty(t, app.Annotations)
+	})
+}
+
+func TestApplication_ConvertFromHub(t *testing.T) {
+	hub := &nais_io_v1.Application{
+		Spec: nais_io_v1.ApplicationSpec{
+			Image: "image:tag",
+			PreStopHook: &nais_io_v1.PreStopHook{
+				Http: &nais_io_v1.HttpGetAction{Path: "/stop"},
+			},
+		},
+	}
+
+	app := &nais_io_v1alpha1.Application{}
+	assert.NoError(t, app.ConvertFrom(hub))
+	assert.Equal(t, "image:tag", app.Spec.Image)
+	assert.Empty(t, app.Spec.PreStopHookPath, "hooks that did not originate from preStopHookPath are kept as-is")
+	assert.Equal(t, hub.Spec.PreStopHook, app.Spec.PreStopHook)
+}
+
+func TestApplication_ConvertFromHubAfterEdit(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			Image:           "image:tag",
+			PreStopHookPath: "/stop",
+		},
+	}
+	hub := &nais_io_v1.Application{}
+	assert.NoError(t, app.ConvertTo(hub))
+
+	t.Run("edited hook replaces preStopHookPath", func(t *testing.T) {
+		edited := hub.DeepCopy()
+		edited.Spec.PreStopHook.Http.Path = "/shutdown"
+
+		converted := &nais_io_v1alpha1.Application{}
+		assert.NoError(t, converted.ConvertFrom(edited))
+		assert.Empty(t, converted.Spec.PreStopHookPath)
+		assert.Equal(t, "/shutdown", converted.Spec.PreStopHook.Http.Path)
+		assert.NotContains(t, converted.Annotations, nais_io_v1alpha1.PreStopHookPathAnnotation)
+		assert.Empty(t, converted.Validate(), "only one of preStopHookPath and preStopHook is set")
+	})
+
+	t.Run("removed hook is not restored", func(t *testing.T) {
+		edited := hub.DeepCopy()
+		edited.Spec.PreStopHook = nil
+
+		converted := &nais_io_v1alpha1.Application{}
+		assert.NoError(t, converted.ConvertFrom(edited))
+		assert.Empty(t, converted.Spec.PreStopHookPath)
+		assert.Nil(t, converted.Spec.PreStopHook)
+	})
+
+	t.Run("annotation is not set when a hook exists", func(t *testing.T) {
+		both := app.DeepCopy()
+		both.Spec.PreStopHook = &nais_io_v1.PreStopHook{Http: &nais_io_v1.HttpGetAction{Path: "/other"}}
+		hub := &nais_io_v1.Application{}
+		assert.NoError(t, both.ConvertTo(hub))
+		assert.NotContains(t, hub.Annotations, nais_io_v1alpha1.PreStopHookPathAnnotation)
+		assert.Equal(t, "/other", hub.Spec.PreStopHook.Http.Path)
+	})
+}
@@ -7,81 +7,40 @@ import (
 
 // Application spec default values
 const (
-	DefaultPortName                 = "http"
-	DefaultServicePort              = 80
-	DefaultAppPort                  = 8080
-	DefaultProbePeriodSeconds       = 10
-	DefaultProbeTimeoutSeconds      = 1
-	DefaultProbeFailureThreshold    = 3
-	DeploymentStrategyRollingUpdate = "RollingUpdate"
-	DeploymentStrategyRecreate      = "Recreate"
+	DefaultPortName                 = nais_io_v1.DefaultPortName
+	DefaultServicePort              = nais_io_v1.DefaultServicePort
+	DefaultAppPort                  = nais_io_v1.DefaultAppPort
+	DefaultProbePeriodSeconds       = nais_io_v1.DefaultProbePeriodSeconds
+	DefaultProbeTimeoutSeconds      = nais_io_v1.DefaultProbeTimeoutSeconds
+	DefaultProbeFailureThreshold    = nais_io_v1.DefaultProbeFailureThreshold
+	DeploymentStrategyRollingUpdate = nais_io_v1.DeploymentStrategyRollingUpdate
+	DeploymentStrategyRecreate      = nais_io_v1.DeploymentStrategyRecreate
 )
 
 // ApplyDefaults sets default values where they are missing from an Application spec.
-func (app *Application) ApplyDefaults() error {
-	return mergo.Merge(app, getAppDefaults())
+//
+// Any profiles given are layered, from least to most specific, on top of the built-in defaults.
+func (app *Application) ApplyDefaults(profiles ...*nais_io_v1.DefaultsProfile) error {
+	defaults, err := getAppDefaults(profiles)
+	if err != nil {
+		return err
+	}
+	return mergo.Merge(app, defaults)
 }
 
-func getAppDefaults() *Application {
-	return &Application{
-		Spec: ApplicationSpec{
-			Azure: &nais_io_v1.Azure{
-				Application: &nais_io_v1.AzureApplication{
-					Enabled: false,
-				},
-			},
-			Replicas: &nais_io_v1.Replicas{
-				Min:                    2,
-				Max:                    4,
-				CpuThresholdPercentage: 50,
-			},
-			Liveness: &nais_io_v1.Probe{
-				PeriodSeconds:    DefaultProbePeriodSeconds,
-				Timeout:          DefaultProbeTimeoutSeconds,
-				FailureThreshold: DefaultProbeFailureThreshold,
-			},
-			Port: DefaultAppPort,
-			Strategy: &nais_io_v1.Strategy{
-				Type: DeploymentStrategyRollingUpdate,
-			},
-			Prometheus: &nais_io_v1.PrometheusConfig{
-				Path: "/metrics",
-			},
-			Ingresses: []nais_io_v1.Ingress{},
-			Resources: &nais_io_v1.ResourceRequirements{
-				Limits: &nais_io_v1.ResourceSpec{
-					Cpu:    "500m",
-					Memory: "512Mi",
-				},
-				Requests: &nais_io_v1.ResourceSpec{
-					Cpu:    "200m",
-					Memory: "256Mi",
-				},
-			},
-			Vault: &nais_io_v1.Vault{
-				Enabled: false,
-				Paths:   []nais_io_v1.SecretPath{},
-			},
-			Service: &nais_io_v1.Service{
-				Port:     DefaultServicePort,
-				Protocol: DefaultPortName,
-			},
-			SecureLogs: &nais_io_v1.SecureLogs{
-				Enabled: false,
-			},
-			AccessPolicy: &nais_io_v1.AccessPolicy{
-				Inbound: &nais_io_v1.AccessPolicyInbound{
-					Rules: []nais_io_v1.AccessPolicyInboundRule{},
-				},
-				Outbound: &nais_io_v1.AccessPolicyOutbound{
-					Rules:    []nais_io_v1.AccessPolicyRule{},
-					External: []nais_io_v1.AccessPolicyExternalRule{},
-				},
-			},
-			TokenX: &nais_io_v1.TokenX{
-				Enabled:                 false,
-				MountSecretsAsFilesOnly: false,
-			},
-		},
+// Defaults are resolved using the nais.io/v1 Application, and converted back to this version.
+func getAppDefaults(profiles []*nais_io_v1.DefaultsProfile) (*Application, error) {
+	hub := &nais_io_v1.Application{}
+	err := hub.ApplyDefaults(profiles...)
+	if err != nil {
+		return nil, err
 	}
+	defaults := &Application{}
+	err = defaults.ConvertFrom(hub)
+	if err != nil {
+		return nil, err
+	}
+	return &Application{
+		Spec: defaults.Spec,
+	}, nil
 }
@@ -14,6 +14,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/nais/liberator/pkg/conditions"
+	liberator_hash "github.com/nais/liberator/pkg/hash"
 )
 
 const (
@@ -39,11 +41,13 @@ func GetDefaultMountPath(name string) string {
 // Application defines a NAIS application.
 //
 // +genclient
+// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Team",type="string",JSONPath=".metadata.labels.team"
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.synchronizationState"
 // +kubebuilder:resource:path="applications",shortName="app",singular="application"
+// +kubebuilder:storageversion
 type Application struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -218,6 +222,26 @@ type ApplicationStatus struct {
 	DeploymentRolloutStatus string `json:"deploymentRolloutStatus,omitempty"`
 	SynchronizationState    string `json:"synchronizationState,omitempty"`
 	SynchronizationHash     string `json:"synchronizationHash,omitempty"`
+	// LiberatorVersion is the version of the liberator hash and defaults implementation used to compute SynchronizationHash.
+	LiberatorVersion int `json:"liberatorVersion,omitempty"`
+	// Conditions describe the current state of the resource.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+func (in *ApplicationStatus) GetConditions() []conditions.Condition {
+	return in.Conditions
+}
+
+func (in *ApplicationStatus) SetConditions(conditions []conditions.Condition) {
+	in.Conditions = conditions
+}
+
+func (in *ApplicationStatus) GetLiberatorVersion() int {
+	return in.LiberatorVersion
+}
+
+func (in *ApplicationStatus) SetLiberatorVersion(version int) {
+	in.LiberatorVersion = version
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -289,6 +313,36 @@ func (in Application) Hash() (string, error) {
 	return fmt.Sprintf("%x", h), err
 }
 
+// Digest returns an algorithm-prefixed hash of the fields that trigger a synchronization when changed.
+// See hash.Digest.
+func (in Application) Digest() (string, error) {
+	return liberator_hash.Digest(in.digestInput())
+}
+
+// VerifyHash returns true if the stored hash matches the current state, whether it was produced by Digest or Hash.
+func (in Application) VerifyHash(stored string) (bool, error) {
+	return liberator_hash.Verify(stored, in.digestInput(), in.Hash)
+}
+
+func (in Application) digestInput() interface{} {
+	// Labels starting with 'nais.io/' are set by automated NAIS processes, and must not trigger a resync.
+	labels := make(map[string]string)
+	for k, v := range in.Labels {
+		if !strings.HasPrefix(k, "nais.io/") {
+			labels[k] = v
+		}
+	}
+	return struct {
+		Spec        ApplicationSpec   `json:"spec"`
+		Labels      map[string]string `json:"labels"`
+		ChangeCause string            `json:"changeCause"`
+	}{
+		Spec:        in.Spec,
+		Labels:      labels,
+		ChangeCause: in.Annotations["kubernetes.io/change-cause"],
+	}
+}
+
 func (in *Application) LogFields() log.Fields {
 	return log.Fields{
 		"namespace":       in.GetNamespace(),
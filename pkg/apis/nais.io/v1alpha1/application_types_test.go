@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mitchellh/hashstructure"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
 	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -69,3 +70,57 @@ func TestNewCRD(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equalf(t, applicationHash, hash, "Your Application default value changes will trigger a FULL REDEPLOY of ALL APPLICATIONS in ALL NAMESPACES across ALL CLUSTERS. If this is what you really want, change the `applicationHash` constant in this test file to `%s`.", hash)
 }
+
+func TestApplication_VerifyHash(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "banan", "nais.io/created-by": "deploy"}},
+	}
+	err := app.ApplyDefaults()
+	assert.NoError(t, err)
+
+	legacyHash, err := app.Hash()
+	assert.NoError(t, err)
+	digest, err := app.Digest()
+	assert.NoError(t, err)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", digest)
+
+	for _, stored := range []string{legacyHash, digest} {
+		ok, err := app.VerifyHash(stored)
+		assert.NoError(t, err)
+		assert.True(t, ok, stored)
+	}
+
+	app.Labels["nais.io/created-by"] = "someone-else"
+	ok, err := app.VerifyHash(digest)
+	assert.NoError(t, err)
+	assert.True(t, ok, "nais.io labels are ignored")
+
+	app.Spec.Image = "foo:bar"
+	for _, stored := range []string{legacyHash, digest} {
+		ok, err := app.VerifyHash(stored)
+		assert.NoError(t, err)
+		assert.False(t, ok, stored)
+	}
+}
+
+func TestApplication_ApplyDefaultsWithProfile(t *testing.T) {
+	profile := &nais_io_v1.DefaultsProfile{
+		Application: &nais_io_v1.ApplicationSpec{
+			Azure: &nais_io_v1.Azure{
+				Application: &nais_io_v1.AzureApplication{
+					Tenant: "nav.no",
+				},
+			},
+			Replicas: &nais_io_v1.Replicas{
+				Min: 1,
+			},
+		},
+	}
+
+	app := &nais_io_v1alpha1.Application{}
+	err := app.ApplyDefaults(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, "nav.no", app.Spec.Azure.Application.Tenant)
+	assert.Equal(t, &nais_io_v1.Replicas{Min: 1, Max: 4, CpuThresholdPercentage: 50}, app.Spec.Replicas)
+	assert.Equal(t, nais_io_v1alpha1.DefaultAppPort, app.Spec.Port)
+}
@@ -0,0 +1,200 @@
+package nais_io_v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+var envVarNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// Validate performs semantic checks on an Application spec that cannot be expressed
+// through the OpenAPI schema alone. The returned list is empty if the Application is valid.
+//
+// Ingress hosts are not checked against a list of allowed domains here,
+// as that list is cluster specific. Use ValidateIngressDomains for that.
+func (in *Application) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+
+	errs = append(errs, validateEnvVars(in.Spec.Env, spec.Child("env"))...)
+	errs = append(errs, validateEnvFrom(in.Spec.EnvFrom, spec.Child("envFrom"))...)
+	errs = append(errs, validateFilesFrom(in.Spec.FilesFrom, spec.Child("filesFrom"))...)
+	errs = append(errs, validateIngresses(in.Spec.Ingresses, spec.Child("ingresses"))...)
+	errs = append(errs, validateReplicas(in.Spec.Replicas, spec.Child("replicas"))...)
+
+	if in.Spec.PreStopHook != nil {
+		if len(in.Spec.PreStopHookPath) > 0 {
+			errs = append(errs, field.Forbidden(spec.Child("preStopHookPath"), "cannot be combined with preStopHook; use preStopHook.http.path instead"))
+		}
+		if in.Spec.PreStopHook.Exec != nil && in.Spec.PreStopHook.Http != nil {
+			errs = append(errs, field.Invalid(spec.Child("preStopHook"), "exec, http", "only one of exec or http may be specified"))
+		}
+	}
+
+	return errs
+}
+
+// ValidateUpdateFrom validates an Application that is about to replace an existing one.
+// In addition to the checks performed by Validate, fields that cannot be changed after creation are enforced.
+func (in *Application) ValidateUpdateFrom(old *Application) field.ErrorList {
+	errs := in.Validate()
+
+	if old == nil || old.Spec.GCP == nil || in.Spec.GCP == nil {
+		return errs
+	}
+
+	path := field.NewPath("spec", "gcp", "bigQueryDatasets")
+	existing := make(map[string]nais_io_v1.CloudBigQueryDataset)
+	for _, dataset := range old.Spec.GCP.BigQueryDatasets {
+		existing[dataset.Name] = dataset
+	}
+	for i, dataset := range in.Spec.GCP.BigQueryDatasets {
+		previous, found := existing[dataset.Name]
+		if found && !reflect.DeepEqual(previous, dataset) {
+			errs = append(errs, field.Forbidden(path.Index(i), "BigQuery datasets are immutable and cannot be changed"))
+		}
+	}
+
+	return errs
+}
+
+// ValidateIngressDomains checks that every ingress host is equal to, or a subdomain of,
+// one of the allowed domains. If no domains are given, all hosts are accepted.
+func (in *Application) ValidateIngressDomains(allowedDomains []string) field.ErrorList {
+	var errs field.ErrorList
+
+	if len(allowedDomains) == 0 {
+		return nil
+	}
+
+	path := field.NewPath("spec", "ingresses")
+	for i, ingress := range in.Spec.Ingresses {
+		u, err := url.Parse(string(ingress))
+		if err != nil {
+			// reported by Validate
+			continue
+		}
+		if !hostInDomains(u.Hostname(), allowedDomains) {
+			errs = append(errs, field.NotSupported(path.Index(i), u.Hostname(), allowedDomains))
+		}
+	}
+
+	return errs
+}
+
+func hostInDomains(host string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(domain, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateEnvVars(envVars nais_io_v1.EnvVars, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := make(map[string]bool)
+	for i, envVar := range envVars {
+		idx := path.Index(i)
+		switch {
+		case len(envVar.Name) == 0:
+			errs = append(errs, field.Required(idx.Child("name"), ""))
+		case !envVarNameRegex.MatchString(envVar.Name):
+			errs = append(errs, field.Invalid(idx.Child("name"), envVar.Name, "may only contain letters, digits, and the underscore `_` character"))
+		case seen[envVar.Name]:
+			errs = append(errs, field.Duplicate(idx.Child("name"), envVar.Name))
+		}
+		seen[envVar.Name] = true
+
+		if envVar.ValueFrom != nil && len(envVar.ValueFrom.FieldRef.FieldPath) > 0 && len(envVar.Value) > 0 {
+			errs = append(errs, field.Invalid(idx, envVar.Name, "specify either `value` or `valueFrom`, but not both"))
+		}
+	}
+
+	return errs
+}
+
+func validateEnvFrom(envFrom []nais_io_v1.EnvFrom, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, source := range envFrom {
+		errs = append(errs, validateConfigMapOrSecret(source.ConfigMap, source.Secret, path.Index(i))...)
+	}
+
+	return errs
+}
+
+func validateFilesFrom(filesFrom []nais_io_v1.FilesFrom, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, source := range filesFrom {
+		errs = append(errs, validateConfigMapOrSecret(source.ConfigMap, source.Secret, path.Index(i))...)
+	}
+
+	return errs
+}
+
+func validateConfigMapOrSecret(configMap, secret string, path *field.Path) field.ErrorList {
+	switch {
+	case len(configMap) == 0 && len(secret) == 0:
+		return field.ErrorList{field.Required(path, "one of `configmap` or `secret` is required")}
+	case len(configMap) > 0 && len(secret) > 0:
+		return field.ErrorList{field.Invalid(path, fmt.Sprintf("configmap=%s, secret=%s", configMap, secret), "specify either `configmap` or `secret`, but not both")}
+	}
+	return nil
+}
+
+func validateIngresses(ingresses []nais_io_v1.Ingress, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := make(map[nais_io_v1.Ingress]bool)
+	for i, ingress := range ingresses {
+		idx := path.Index(i)
+		u, err := url.Parse(string(ingress))
+		switch {
+		case err != nil:
+			errs = append(errs, field.Invalid(idx, ingress, err.Error()))
+			continue
+		case u.Scheme != "https":
+			errs = append(errs, field.Invalid(idx, ingress, "URL must start with `https://`"))
+		case len(u.Hostname()) == 0:
+			errs = append(errs, field.Invalid(idx, ingress, "URL must contain a host name"))
+		case seen[ingress]:
+			errs = append(errs, field.Duplicate(idx, ingress))
+		}
+		seen[ingress] = true
+	}
+
+	return errs
+}
+
+func validateReplicas(replicas *nais_io_v1.Replicas, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if replicas == nil {
+		return nil
+	}
+	if replicas.Min < 0 {
+		errs = append(errs, field.Invalid(path.Child("min"), replicas.Min, "must be greater than or equal to 0"))
+	}
+	if replicas.Max < 0 {
+		errs = append(errs, field.Invalid(path.Child("max"), replicas.Max, "must be greater than or equal to 0"))
+	}
+	if replicas.Max > 0 && replicas.Min > replicas.Max {
+		errs = append(errs, field.Invalid(path.Child("min"), replicas.Min, fmt.Sprintf("must be less than or equal to max (%d)", replicas.Max)))
+	}
+	if replicas.CpuThresholdPercentage < 0 || replicas.CpuThresholdPercentage > 100 {
+		errs = append(errs, field.Invalid(path.Child("cpuThresholdPercentage"), replicas.CpuThresholdPercentage, "must be between 0 and 100"))
+	}
+
+	return errs
+}
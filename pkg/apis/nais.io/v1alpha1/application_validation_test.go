@@ -0,0 +1,162 @@
+package nais_io_v1alpha1_test
+
+import (
+	"testing"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func errorFields(errs field.ErrorList) []string {
+	fields := make([]string, len(errs))
+	for i, err := range errs {
+		fields[i] = err.Field
+	}
+	return fields
+}
+
+func TestApplication_Validate(t *testing.T) {
+	t.Run("example application is valid", func(t *testing.T) {
+		app := nais_io_v1alpha1.ExampleApplicationForDocumentation()
+		// the documentation example fills in every field, including mutually exclusive ones
+		app.Spec.PreStopHookPath = ""
+		app.Spec.PreStopHook.Exec = nil
+		assert.Empty(t, app.Validate())
+	})
+
+	t.Run("application with defaults is valid", func(t *testing.T) {
+		app := &nais_io_v1alpha1.Application{}
+		assert.NoError(t, app.ApplyDefaults())
+		assert.Empty(t, app.Validate())
+	})
+
+	tests := []struct {
+		name   string
+		spec   nais_io_v1alpha1.ApplicationSpec
+		fields []string
+	}{
+		{
+			name: "duplicate environment variables",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				Env: nais_io_v1.EnvVars{
+					{Name: "FOO", Value: "bar"},
+					{Name: "BAR", Value: "baz"},
+					{Name: "FOO", Value: "baz"},
+				},
+			},
+			fields: []string{"spec.env[2].name"},
+		},
+		{
+			name: "invalid environment variable name",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				Env: nais_io_v1.EnvVars{
+					{Name: "FOO-BAR", Value: "bar"},
+				},
+			},
+			fields: []string{"spec.env[0].name"},
+		},
+		{
+			name: "environment variable with both value and valueFrom",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				Env: nais_io_v1.EnvVars{
+					{Name: "FOO", Value: "bar", ValueFrom: &nais_io_v1.EnvVarSource{FieldRef: nais_io_v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+				},
+			},
+			fields: []string{"spec.env[0]"},
+		},
+		{
+			name: "min replicas greater than max",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				Replicas: &nais_io_v1.Replicas{Min: 4, Max: 2},
+			},
+			fields: []string{"spec.replicas.min"},
+		},
+		{
+			name: "ingresses must be unique https urls",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				Ingresses: []nais_io_v1.Ingress{
+					"https://myapp.nav.no",
+					"http://myapp.nav.no",
+					"https://myapp.nav.no",
+				},
+			},
+			fields: []string{"spec.ingresses[1]", "spec.ingresses[2]"},
+		},
+		{
+			name: "preStopHook combined with preStopHookPath",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				PreStopHookPath: "/stop",
+				PreStopHook: &nais_io_v1.PreStopHook{
+					Http: &nais_io_v1.HttpGetAction{Path: "/stop"},
+				},
+			},
+			fields: []string{"spec.preStopHookPath"},
+		},
+		{
+			name: "preStopHook with both exec and http",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				PreStopHook: &nais_io_v1.PreStopHook{
+					Exec: &nais_io_v1.ExecAction{Command: []string{"./stop"}},
+					Http: &nais_io_v1.HttpGetAction{Path: "/stop"},
+				},
+			},
+			fields: []string{"spec.preStopHook"},
+		},
+		{
+			name: "envFrom and filesFrom require exactly one source",
+			spec: nais_io_v1alpha1.ApplicationSpec{
+				EnvFrom:   []nais_io_v1.EnvFrom{{}},
+				FilesFrom: []nais_io_v1.FilesFrom{{ConfigMap: "foo", Secret: "bar"}},
+			},
+			fields: []string{"spec.envFrom[0]", "spec.filesFrom[0]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := &nais_io_v1alpha1.Application{Spec: test.spec}
+			assert.Equal(t, test.fields, errorFields(app.Validate()))
+		})
+	}
+}
+
+func TestApplication_ValidateUpdateFrom(t *testing.T) {
+	old := &nais_io_v1alpha1.Application{
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			GCP: &nais_io_v1.GCP{
+				BigQueryDatasets: []nais_io_v1.CloudBigQueryDataset{
+					{Name: "dataset", Permission: nais_io_v1.BigQueryPermissionRead},
+				},
+			},
+		},
+	}
+
+	app := old.DeepCopy()
+	app.Spec.GCP.BigQueryDatasets = append(app.Spec.GCP.BigQueryDatasets, nais_io_v1.CloudBigQueryDataset{
+		Name:       "other",
+		Permission: nais_io_v1.BigQueryPermissionRead,
+	})
+	assert.Empty(t, app.ValidateUpdateFrom(old), "adding a dataset is allowed")
+
+	app.Spec.GCP.BigQueryDatasets[0].Permission = nais_io_v1.BigQueryPermissionReadWrite
+	assert.Equal(t, []string{"spec.gcp.bigQueryDatasets[0]"}, errorFields(app.ValidateUpdateFrom(old)))
+}
+
+func TestApplication_ValidateIngressDomains(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			Ingresses: []nais_io_v1.Ingress{
+				"https://myapp.nav.no",
+				"https://myapp.dev.nav.no/path",
+				"https://nav.no",
+				"https://myapp.example.com",
+				"https://evilnav.no",
+			},
+		},
+	}
+
+	assert.Empty(t, app.ValidateIngressDomains(nil))
+	assert.Equal(t, []string{"spec.ingresses[3]", "spec.ingresses[4]"}, errorFields(app.ValidateIngressDomains([]string{"nav.no"})))
+}
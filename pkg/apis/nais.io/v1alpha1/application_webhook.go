@@ -0,0 +1,35 @@
+package nais_io_v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/validate-nais-io-v1alpha1-application,mutating=false,failurePolicy=fail,groups=nais.io,resources=applications,verbs=create;update,versions=v1alpha1,name=vapplication.nais.io
+
+var _ webhook.Validator = &Application{}
+
+// ValidateCreate implements webhook.Validator, and rejects new Applications that fail Validate.
+func (in *Application) ValidateCreate() error {
+	return in.invalid(in.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator, and rejects Applications that fail ValidateUpdateFrom.
+func (in *Application) ValidateUpdate(old runtime.Object) error {
+	previous, _ := old.(*Application)
+	return in.invalid(in.ValidateUpdateFrom(previous))
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is always allowed.
+func (in *Application) ValidateDelete() error {
+	return nil
+}
+
+func (in *Application) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("Application").GroupKind(), in.GetName(), errs)
+}
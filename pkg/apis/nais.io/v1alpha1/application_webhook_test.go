@@ -0,0 +1,45 @@
+package nais_io_v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+func TestApplication_Validator(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			Image: "image:tag",
+			GCP: &nais_io_v1.GCP{
+				BigQueryDatasets: []nais_io_v1.CloudBigQueryDataset{
+					{Name: "dataset", Permission: nais_io_v1.BigQueryPermissionRead},
+				},
+			},
+		},
+	}
+	app.SetName("myapplication")
+
+	assert.NoError(t, app.ValidateCreate())
+	assert.NoError(t, app.ValidateUpdate(app.DeepCopy()))
+	assert.NoError(t, app.ValidateDelete())
+
+	t.Run("invalid application is rejected on create", func(t *testing.T) {
+		invalid := app.DeepCopy()
+		invalid.Spec.Env = nais_io_v1.EnvVars{{Name: "FOO"}, {Name: "FOO"}}
+		err := invalid.ValidateCreate()
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.env[1].name")
+	})
+
+	t.Run("immutable fields are enforced on update", func(t *testing.T) {
+		changed := app.DeepCopy()
+		changed.Spec.GCP.BigQueryDatasets[0].Permission = nais_io_v1.BigQueryPermissionReadWrite
+		err := changed.ValidateUpdate(app)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.gcp.bigQueryDatasets[0]")
+	})
+}
@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kafka_nais_io_v1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/client/clientset/versioned/fake"
+	"github.com/nais/liberator/pkg/client/informers/externalversions"
+)
+
+func TestClientset(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam"},
+	}
+	client := fake.NewSimpleClientset(app)
+
+	fetched, err := client.NaisV1alpha1().Applications("myteam").Get("myapp", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, app.Spec, fetched.Spec)
+
+	topic := &kafka_nais_io_v1.Topic{
+		ObjectMeta: metav1.ObjectMeta{Name: "mytopic", Namespace: "myteam"},
+	}
+	_, err = client.KafkaV1().Topics("myteam").Create(topic)
+	require.NoError(t, err)
+
+	topics, err := client.KafkaV1().Topics("myteam").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, topics.Items, 1)
+}
+
+func TestInformers(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam"},
+	}
+	client := fake.NewSimpleClientset(app)
+	factory := externalversions.NewSharedInformerFactory(client, time.Minute)
+	informer := factory.Nais().V1alpha1().Applications()
+	lister := informer.Lister()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	factory.Start(stop)
+	require.True(t, cache.WaitForCacheSync(stop, informer.Informer().HasSynced))
+
+	cached, err := lister.Applications("myteam").Get("myapp")
+	require.NoError(t, err)
+	assert.Equal(t, "myapp", cached.Name)
+
+	_, err = lister.Applications("myteam").Get("unknown")
+	assert.True(t, errors.IsNotFound(err))
+}
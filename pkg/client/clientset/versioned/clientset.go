@@ -0,0 +1,123 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package versioned
+
+import (
+	"fmt"
+
+	aivenv1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/aiven.nais.io/v1"
+	kafkav1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/kafka.nais.io/v1"
+	naisv1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/nais.io/v1"
+	naisv1alpha1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/nais.io/v1alpha1"
+	discovery "k8s.io/client-go/discovery"
+	rest "k8s.io/client-go/rest"
+	flowcontrol "k8s.io/client-go/util/flowcontrol"
+)
+
+type Interface interface {
+	Discovery() discovery.DiscoveryInterface
+	AivenV1() aivenv1.AivenV1Interface
+	KafkaV1() kafkav1.KafkaV1Interface
+	NaisV1() naisv1.NaisV1Interface
+	NaisV1alpha1() naisv1alpha1.NaisV1alpha1Interface
+}
+
+// Clientset contains the clients for groups. Each group has exactly one
+// version included in a Clientset.
+type Clientset struct {
+	*discovery.DiscoveryClient
+	aivenV1      *aivenv1.AivenV1Client
+	kafkaV1      *kafkav1.KafkaV1Client
+	naisV1       *naisv1.NaisV1Client
+	naisV1alpha1 *naisv1alpha1.NaisV1alpha1Client
+}
+
+// AivenV1 retrieves the AivenV1Client
+func (c *Clientset) AivenV1() aivenv1.AivenV1Interface {
+	return c.aivenV1
+}
+
+// KafkaV1 retrieves the KafkaV1Client
+func (c *Clientset) KafkaV1() kafkav1.KafkaV1Interface {
+	return c.kafkaV1
+}
+
+// NaisV1 retrieves the NaisV1Client
+func (c *Clientset) NaisV1() naisv1.NaisV1Interface {
+	return c.naisV1
+}
+
+// NaisV1alpha1 retrieves the NaisV1alpha1Client
+func (c *Clientset) NaisV1alpha1() naisv1alpha1.NaisV1alpha1Interface {
+	return c.naisV1alpha1
+}
+
+// Discovery retrieves the DiscoveryClient
+func (c *Clientset) Discovery() discovery.DiscoveryInterface {
+	if c == nil {
+		return nil
+	}
+	return c.DiscoveryClient
+}
+
+// NewForConfig creates a new Clientset for the given config.
+// If config's RateLimiter is not set and QPS and Burst are acceptable,
+// NewForConfig will generate a rate-limiter in configShallowCopy.
+func NewForConfig(c *rest.Config) (*Clientset, error) {
+	configShallowCopy := *c
+	if configShallowCopy.RateLimiter == nil && configShallowCopy.QPS > 0 {
+		if configShallowCopy.Burst <= 0 {
+			return nil, fmt.Errorf("Burst is required to be greater than 0 when RateLimiter is not set and QPS is set to greater than 0")
+		}
+		configShallowCopy.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(configShallowCopy.QPS, configShallowCopy.Burst)
+	}
+	var cs Clientset
+	var err error
+	cs.aivenV1, err = aivenv1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.kafkaV1, err = kafkav1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.naisV1, err = naisv1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.naisV1alpha1, err = naisv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+// NewForConfigOrDie creates a new Clientset for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *Clientset {
+	var cs Clientset
+	cs.aivenV1 = aivenv1.NewForConfigOrDie(c)
+	cs.kafkaV1 = kafkav1.NewForConfigOrDie(c)
+	cs.naisV1 = naisv1.NewForConfigOrDie(c)
+	cs.naisV1alpha1 = naisv1alpha1.NewForConfigOrDie(c)
+
+	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
+	return &cs
+}
+
+// New creates a new Clientset for the given RESTClient.
+func New(c rest.Interface) *Clientset {
+	var cs Clientset
+	cs.aivenV1 = aivenv1.New(c)
+	cs.kafkaV1 = kafkav1.New(c)
+	cs.naisV1 = naisv1.New(c)
+	cs.naisV1alpha1 = naisv1alpha1.New(c)
+
+	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
+	return &cs
+}
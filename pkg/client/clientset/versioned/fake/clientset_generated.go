@@ -0,0 +1,87 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	clientset "github.com/nais/liberator/pkg/client/clientset/versioned"
+	aivenv1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/aiven.nais.io/v1"
+	fakeaivenv1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/aiven.nais.io/v1/fake"
+	kafkav1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/kafka.nais.io/v1"
+	fakekafkav1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/kafka.nais.io/v1/fake"
+	naisv1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/nais.io/v1"
+	fakenaisv1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/nais.io/v1/fake"
+	naisv1alpha1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/nais.io/v1alpha1"
+	fakenaisv1alpha1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/nais.io/v1alpha1/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/testing"
+)
+
+// NewSimpleClientset returns a clientset that will respond with the provided objects.
+// It's backed by a very simple object tracker that processes creates, updates and deletions as-is,
+// without applying any validations and/or defaults. It shouldn't be considered a replacement
+// for a real clientset and is mostly useful in simple unit tests.
+func NewSimpleClientset(objects ...runtime.Object) *Clientset {
+	o := testing.NewObjectTracker(scheme, codecs.UniversalDecoder())
+	for _, obj := range objects {
+		if err := o.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	cs := &Clientset{tracker: o}
+	cs.discovery = &fakediscovery.FakeDiscovery{Fake: &cs.Fake}
+	cs.AddReactor("*", "*", testing.ObjectReaction(o))
+	cs.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
+		gvr := action.GetResource()
+		ns := action.GetNamespace()
+		watch, err := o.Watch(gvr, ns)
+		if err != nil {
+			return false, nil, err
+		}
+		return true, watch, nil
+	})
+
+	return cs
+}
+
+// Clientset implements clientset.Interface. Meant to be embedded into a
+// struct to get a default implementation. This makes faking out just the method
+// you want to test easier.
+type Clientset struct {
+	testing.Fake
+	discovery *fakediscovery.FakeDiscovery
+	tracker   testing.ObjectTracker
+}
+
+func (c *Clientset) Discovery() discovery.DiscoveryInterface {
+	return c.discovery
+}
+
+func (c *Clientset) Tracker() testing.ObjectTracker {
+	return c.tracker
+}
+
+var _ clientset.Interface = &Clientset{}
+
+// AivenV1 retrieves the AivenV1Client
+func (c *Clientset) AivenV1() aivenv1.AivenV1Interface {
+	return &fakeaivenv1.FakeAivenV1{Fake: &c.Fake}
+}
+
+// KafkaV1 retrieves the KafkaV1Client
+func (c *Clientset) KafkaV1() kafkav1.KafkaV1Interface {
+	return &fakekafkav1.FakeKafkaV1{Fake: &c.Fake}
+}
+
+// NaisV1 retrieves the NaisV1Client
+func (c *Clientset) NaisV1() naisv1.NaisV1Interface {
+	return &fakenaisv1.FakeNaisV1{Fake: &c.Fake}
+}
+
+// NaisV1alpha1 retrieves the NaisV1alpha1Client
+func (c *Clientset) NaisV1alpha1() naisv1alpha1.NaisV1alpha1Interface {
+	return &fakenaisv1alpha1.FakeNaisV1alpha1{Fake: &c.Fake}
+}
@@ -0,0 +1,73 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	"github.com/nais/liberator/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type AivenV1Interface interface {
+	RESTClient() rest.Interface
+	AivenApplicationsGetter
+}
+
+// AivenV1Client is used to interact with features provided by the aiven.nais.io group.
+type AivenV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *AivenV1Client) AivenApplications(namespace string) AivenApplicationInterface {
+	return newAivenApplications(c, namespace)
+}
+
+// NewForConfig creates a new AivenV1Client for the given config.
+func NewForConfig(c *rest.Config) (*AivenV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &AivenV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new AivenV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *AivenV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new AivenV1Client for the given RESTClient.
+func New(c rest.Interface) *AivenV1Client {
+	return &AivenV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *AivenV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
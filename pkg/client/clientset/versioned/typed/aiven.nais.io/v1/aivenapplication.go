@@ -0,0 +1,175 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	scheme "github.com/nais/liberator/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// AivenApplicationsGetter has a method to return a AivenApplicationInterface.
+// A group's client should implement this interface.
+type AivenApplicationsGetter interface {
+	AivenApplications(namespace string) AivenApplicationInterface
+}
+
+// AivenApplicationInterface has methods to work with AivenApplication resources.
+type AivenApplicationInterface interface {
+	Create(*v1.AivenApplication) (*v1.AivenApplication, error)
+	Update(*v1.AivenApplication) (*v1.AivenApplication, error)
+	UpdateStatus(*v1.AivenApplication) (*v1.AivenApplication, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.AivenApplication, error)
+	List(opts metav1.ListOptions) (*v1.AivenApplicationList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.AivenApplication, err error)
+	AivenApplicationExpansion
+}
+
+// aivenApplications implements AivenApplicationInterface
+type aivenApplications struct {
+	client rest.Interface
+	ns     string
+}
+
+// newAivenApplications returns a AivenApplications
+func newAivenApplications(c *AivenV1Client, namespace string) *aivenApplications {
+	return &aivenApplications{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the aivenApplication, and returns the corresponding aivenApplication object, and an error if there is any.
+func (c *aivenApplications) Get(name string, options metav1.GetOptions) (result *v1.AivenApplication, err error) {
+	result = &v1.AivenApplication{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of AivenApplications that match those selectors.
+func (c *aivenApplications) List(opts metav1.ListOptions) (result *v1.AivenApplicationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.AivenApplicationList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested aivenApplications.
+func (c *aivenApplications) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a aivenApplication and creates it.  Returns the server's representation of the aivenApplication, and an error, if there is any.
+func (c *aivenApplications) Create(aivenApplication *v1.AivenApplication) (result *v1.AivenApplication, err error) {
+	result = &v1.AivenApplication{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		Body(aivenApplication).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a aivenApplication and updates it. Returns the server's representation of the aivenApplication, and an error, if there is any.
+func (c *aivenApplications) Update(aivenApplication *v1.AivenApplication) (result *v1.AivenApplication, err error) {
+	result = &v1.AivenApplication{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		Name(aivenApplication.Name).
+		Body(aivenApplication).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *aivenApplications) UpdateStatus(aivenApplication *v1.AivenApplication) (result *v1.AivenApplication, err error) {
+	result = &v1.AivenApplication{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		Name(aivenApplication.Name).
+		SubResource("status").
+		Body(aivenApplication).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the aivenApplication and deletes it. Returns an error if one occurs.
+func (c *aivenApplications) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *aivenApplications) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched aivenApplication.
+func (c *aivenApplications) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.AivenApplication, err error) {
+	result = &v1.AivenApplication{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("aivenapplications").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,24 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/aiven.nais.io/v1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeAivenV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeAivenV1) AivenApplications(namespace string) v1.AivenApplicationInterface {
+	return &FakeAivenApplications{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeAivenV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
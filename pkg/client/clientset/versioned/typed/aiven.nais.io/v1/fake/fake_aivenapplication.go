@@ -0,0 +1,124 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	aivennaisiov1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAivenApplications implements AivenApplicationInterface
+type FakeAivenApplications struct {
+	Fake *FakeAivenV1
+	ns   string
+}
+
+var aivenapplicationsResource = schema.GroupVersionResource{Group: "aiven.nais.io", Version: "v1", Resource: "aivenapplications"}
+
+var aivenapplicationsKind = schema.GroupVersionKind{Group: "aiven.nais.io", Version: "v1", Kind: "AivenApplication"}
+
+// Get takes name of the aivenApplication, and returns the corresponding aivenApplication object, and an error if there is any.
+func (c *FakeAivenApplications) Get(name string, options v1.GetOptions) (result *aivennaisiov1.AivenApplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(aivenapplicationsResource, c.ns, name), &aivennaisiov1.AivenApplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*aivennaisiov1.AivenApplication), err
+}
+
+// List takes label and field selectors, and returns the list of AivenApplications that match those selectors.
+func (c *FakeAivenApplications) List(opts v1.ListOptions) (result *aivennaisiov1.AivenApplicationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(aivenapplicationsResource, aivenapplicationsKind, c.ns, opts), &aivennaisiov1.AivenApplicationList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &aivennaisiov1.AivenApplicationList{ListMeta: obj.(*aivennaisiov1.AivenApplicationList).ListMeta}
+	for _, item := range obj.(*aivennaisiov1.AivenApplicationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested aivenApplications.
+func (c *FakeAivenApplications) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(aivenapplicationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a aivenApplication and creates it.  Returns the server's representation of the aivenApplication, and an error, if there is any.
+func (c *FakeAivenApplications) Create(aivenApplication *aivennaisiov1.AivenApplication) (result *aivennaisiov1.AivenApplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(aivenapplicationsResource, c.ns, aivenApplication), &aivennaisiov1.AivenApplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*aivennaisiov1.AivenApplication), err
+}
+
+// Update takes the representation of a aivenApplication and updates it. Returns the server's representation of the aivenApplication, and an error, if there is any.
+func (c *FakeAivenApplications) Update(aivenApplication *aivennaisiov1.AivenApplication) (result *aivennaisiov1.AivenApplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(aivenapplicationsResource, c.ns, aivenApplication), &aivennaisiov1.AivenApplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*aivennaisiov1.AivenApplication), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAivenApplications) UpdateStatus(aivenApplication *aivennaisiov1.AivenApplication) (*aivennaisiov1.AivenApplication, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(aivenapplicationsResource, "status", c.ns, aivenApplication), &aivennaisiov1.AivenApplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*aivennaisiov1.AivenApplication), err
+}
+
+// Delete takes name of the aivenApplication and deletes it. Returns an error if one occurs.
+func (c *FakeAivenApplications) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(aivenapplicationsResource, c.ns, name), &aivennaisiov1.AivenApplication{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAivenApplications) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(aivenapplicationsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &aivennaisiov1.AivenApplicationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched aivenApplication.
+func (c *FakeAivenApplications) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *aivennaisiov1.AivenApplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(aivenapplicationsResource, c.ns, name, pt, data, subresources...), &aivennaisiov1.AivenApplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*aivennaisiov1.AivenApplication), err
+}
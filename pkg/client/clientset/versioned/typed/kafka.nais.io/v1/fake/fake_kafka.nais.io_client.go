@@ -0,0 +1,24 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/nais/liberator/pkg/client/clientset/versioned/typed/kafka.nais.io/v1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeKafkaV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeKafkaV1) Topics(namespace string) v1.TopicInterface {
+	return &FakeTopics{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeKafkaV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
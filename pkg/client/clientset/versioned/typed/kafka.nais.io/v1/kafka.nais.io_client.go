@@ -0,0 +1,73 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	"github.com/nais/liberator/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type KafkaV1Interface interface {
+	RESTClient() rest.Interface
+	TopicsGetter
+}
+
+// KafkaV1Client is used to interact with features provided by the kafka.nais.io group.
+type KafkaV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *KafkaV1Client) Topics(namespace string) TopicInterface {
+	return newTopics(c, namespace)
+}
+
+// NewForConfig creates a new KafkaV1Client for the given config.
+func NewForConfig(c *rest.Config) (*KafkaV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new KafkaV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *KafkaV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new KafkaV1Client for the given RESTClient.
+func New(c rest.Interface) *KafkaV1Client {
+	return &KafkaV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *KafkaV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,124 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	naisiov1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAlerts implements AlertInterface
+type FakeAlerts struct {
+	Fake *FakeNaisV1
+	ns   string
+}
+
+var alertsResource = schema.GroupVersionResource{Group: "nais.io", Version: "v1", Resource: "alerts"}
+
+var alertsKind = schema.GroupVersionKind{Group: "nais.io", Version: "v1", Kind: "Alert"}
+
+// Get takes name of the alert, and returns the corresponding alert object, and an error if there is any.
+func (c *FakeAlerts) Get(name string, options v1.GetOptions) (result *naisiov1.Alert, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(alertsResource, c.ns, name), &naisiov1.Alert{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Alert), err
+}
+
+// List takes label and field selectors, and returns the list of Alerts that match those selectors.
+func (c *FakeAlerts) List(opts v1.ListOptions) (result *naisiov1.AlertList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(alertsResource, alertsKind, c.ns, opts), &naisiov1.AlertList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &naisiov1.AlertList{ListMeta: obj.(*naisiov1.AlertList).ListMeta}
+	for _, item := range obj.(*naisiov1.AlertList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested alerts.
+func (c *FakeAlerts) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(alertsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a alert and creates it.  Returns the server's representation of the alert, and an error, if there is any.
+func (c *FakeAlerts) Create(alert *naisiov1.Alert) (result *naisiov1.Alert, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(alertsResource, c.ns, alert), &naisiov1.Alert{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Alert), err
+}
+
+// Update takes the representation of a alert and updates it. Returns the server's representation of the alert, and an error, if there is any.
+func (c *FakeAlerts) Update(alert *naisiov1.Alert) (result *naisiov1.Alert, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(alertsResource, c.ns, alert), &naisiov1.Alert{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Alert), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAlerts) UpdateStatus(alert *naisiov1.Alert) (*naisiov1.Alert, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(alertsResource, "status", c.ns, alert), &naisiov1.Alert{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Alert), err
+}
+
+// Delete takes name of the alert and deletes it. Returns an error if one occurs.
+func (c *FakeAlerts) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(alertsResource, c.ns, name), &naisiov1.Alert{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAlerts) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(alertsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &naisiov1.AlertList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched alert.
+func (c *FakeAlerts) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *naisiov1.Alert, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(alertsResource, c.ns, name, pt, data, subresources...), &naisiov1.Alert{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Alert), err
+}
@@ -0,0 +1,112 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	naisiov1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNaisjobs implements NaisjobInterface
+type FakeNaisjobs struct {
+	Fake *FakeNaisV1
+	ns   string
+}
+
+var naisjobsResource = schema.GroupVersionResource{Group: "nais.io", Version: "v1", Resource: "naisjobs"}
+
+var naisjobsKind = schema.GroupVersionKind{Group: "nais.io", Version: "v1", Kind: "Naisjob"}
+
+// Get takes name of the naisjob, and returns the corresponding naisjob object, and an error if there is any.
+func (c *FakeNaisjobs) Get(name string, options v1.GetOptions) (result *naisiov1.Naisjob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(naisjobsResource, c.ns, name), &naisiov1.Naisjob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Naisjob), err
+}
+
+// List takes label and field selectors, and returns the list of Naisjobs that match those selectors.
+func (c *FakeNaisjobs) List(opts v1.ListOptions) (result *naisiov1.NaisjobList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(naisjobsResource, naisjobsKind, c.ns, opts), &naisiov1.NaisjobList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &naisiov1.NaisjobList{ListMeta: obj.(*naisiov1.NaisjobList).ListMeta}
+	for _, item := range obj.(*naisiov1.NaisjobList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested naisjobs.
+func (c *FakeNaisjobs) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(naisjobsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a naisjob and creates it.  Returns the server's representation of the naisjob, and an error, if there is any.
+func (c *FakeNaisjobs) Create(naisjob *naisiov1.Naisjob) (result *naisiov1.Naisjob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(naisjobsResource, c.ns, naisjob), &naisiov1.Naisjob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Naisjob), err
+}
+
+// Update takes the representation of a naisjob and updates it. Returns the server's representation of the naisjob, and an error, if there is any.
+func (c *FakeNaisjobs) Update(naisjob *naisiov1.Naisjob) (result *naisiov1.Naisjob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(naisjobsResource, c.ns, naisjob), &naisiov1.Naisjob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Naisjob), err
+}
+
+// Delete takes name of the naisjob and deletes it. Returns an error if one occurs.
+func (c *FakeNaisjobs) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(naisjobsResource, c.ns, name), &naisiov1.Naisjob{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNaisjobs) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(naisjobsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &naisiov1.NaisjobList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched naisjob.
+func (c *FakeNaisjobs) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *naisiov1.Naisjob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(naisjobsResource, c.ns, name, pt, data, subresources...), &naisiov1.Naisjob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*naisiov1.Naisjob), err
+}
@@ -0,0 +1,17 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+type AlertExpansion interface{}
+
+type ApplicationExpansion interface{}
+
+type AzureAdApplicationExpansion interface{}
+
+type IDPortenClientExpansion interface{}
+
+type JwkerExpansion interface{}
+
+type MaskinportenClientExpansion interface{}
+
+type NaisjobExpansion interface{}
@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	scheme "github.com/nais/liberator/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// MaskinportenClientsGetter has a method to return a MaskinportenClientInterface.
+// A group's client should implement this interface.
+type MaskinportenClientsGetter interface {
+	MaskinportenClients(namespace string) MaskinportenClientInterface
+}
+
+// MaskinportenClientInterface has methods to work with MaskinportenClient resources.
+type MaskinportenClientInterface interface {
+	Create(*v1.MaskinportenClient) (*v1.MaskinportenClient, error)
+	Update(*v1.MaskinportenClient) (*v1.MaskinportenClient, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.MaskinportenClient, error)
+	List(opts metav1.ListOptions) (*v1.MaskinportenClientList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.MaskinportenClient, err error)
+	MaskinportenClientExpansion
+}
+
+// maskinportenClients implements MaskinportenClientInterface
+type maskinportenClients struct {
+	client rest.Interface
+	ns     string
+}
+
+// newMaskinportenClients returns a MaskinportenClients
+func newMaskinportenClients(c *NaisV1Client, namespace string) *maskinportenClients {
+	return &maskinportenClients{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the maskinportenClient, and returns the corresponding maskinportenClient object, and an error if there is any.
+func (c *maskinportenClients) Get(name string, options metav1.GetOptions) (result *v1.MaskinportenClient, err error) {
+	result = &v1.MaskinportenClient{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of MaskinportenClients that match those selectors.
+func (c *maskinportenClients) List(opts metav1.ListOptions) (result *v1.MaskinportenClientList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.MaskinportenClientList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested maskinportenClients.
+func (c *maskinportenClients) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a maskinportenClient and creates it.  Returns the server's representation of the maskinportenClient, and an error, if there is any.
+func (c *maskinportenClients) Create(maskinportenClient *v1.MaskinportenClient) (result *v1.MaskinportenClient, err error) {
+	result = &v1.MaskinportenClient{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		Body(maskinportenClient).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a maskinportenClient and updates it. Returns the server's representation of the maskinportenClient, and an error, if there is any.
+func (c *maskinportenClients) Update(maskinportenClient *v1.MaskinportenClient) (result *v1.MaskinportenClient, err error) {
+	result = &v1.MaskinportenClient{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		Name(maskinportenClient.Name).
+		Body(maskinportenClient).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the maskinportenClient and deletes it. Returns an error if one occurs.
+func (c *maskinportenClients) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *maskinportenClients) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched maskinportenClient.
+func (c *maskinportenClients) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.MaskinportenClient, err error) {
+	result = &v1.MaskinportenClient{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("maskinportenclients").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,103 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/nais/liberator/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type NaisV1Interface interface {
+	RESTClient() rest.Interface
+	AlertsGetter
+	ApplicationsGetter
+	AzureAdApplicationsGetter
+	IDPortenClientsGetter
+	JwkersGetter
+	MaskinportenClientsGetter
+	NaisjobsGetter
+}
+
+// NaisV1Client is used to interact with features provided by the nais.io group.
+type NaisV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *NaisV1Client) Alerts(namespace string) AlertInterface {
+	return newAlerts(c, namespace)
+}
+
+func (c *NaisV1Client) Applications(namespace string) ApplicationInterface {
+	return newApplications(c, namespace)
+}
+
+func (c *NaisV1Client) AzureAdApplications(namespace string) AzureAdApplicationInterface {
+	return newAzureAdApplications(c, namespace)
+}
+
+func (c *NaisV1Client) IDPortenClients(namespace string) IDPortenClientInterface {
+	return newIDPortenClients(c, namespace)
+}
+
+func (c *NaisV1Client) Jwkers(namespace string) JwkerInterface {
+	return newJwkers(c, namespace)
+}
+
+func (c *NaisV1Client) MaskinportenClients(namespace string) MaskinportenClientInterface {
+	return newMaskinportenClients(c, namespace)
+}
+
+func (c *NaisV1Client) Naisjobs(namespace string) NaisjobInterface {
+	return newNaisjobs(c, namespace)
+}
+
+// NewForConfig creates a new NaisV1Client for the given config.
+func NewForConfig(c *rest.Config) (*NaisV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &NaisV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new NaisV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *NaisV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new NaisV1Client for the given RESTClient.
+func New(c rest.Interface) *NaisV1Client {
+	return &NaisV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *NaisV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
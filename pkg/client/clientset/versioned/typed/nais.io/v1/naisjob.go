@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	scheme "github.com/nais/liberator/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NaisjobsGetter has a method to return a NaisjobInterface.
+// A group's client should implement this interface.
+type NaisjobsGetter interface {
+	Naisjobs(namespace string) NaisjobInterface
+}
+
+// NaisjobInterface has methods to work with Naisjob resources.
+type NaisjobInterface interface {
+	Create(*v1.Naisjob) (*v1.Naisjob, error)
+	Update(*v1.Naisjob) (*v1.Naisjob, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.Naisjob, error)
+	List(opts metav1.ListOptions) (*v1.NaisjobList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.Naisjob, err error)
+	NaisjobExpansion
+}
+
+// naisjobs implements NaisjobInterface
+type naisjobs struct {
+	client rest.Interface
+	ns     string
+}
+
+// newNaisjobs returns a Naisjobs
+func newNaisjobs(c *NaisV1Client, namespace string) *naisjobs {
+	return &naisjobs{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the naisjob, and returns the corresponding naisjob object, and an error if there is any.
+func (c *naisjobs) Get(name string, options metav1.GetOptions) (result *v1.Naisjob, err error) {
+	result = &v1.Naisjob{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Naisjobs that match those selectors.
+func (c *naisjobs) List(opts metav1.ListOptions) (result *v1.NaisjobList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.NaisjobList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested naisjobs.
+func (c *naisjobs) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a naisjob and creates it.  Returns the server's representation of the naisjob, and an error, if there is any.
+func (c *naisjobs) Create(naisjob *v1.Naisjob) (result *v1.Naisjob, err error) {
+	result = &v1.Naisjob{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		Body(naisjob).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a naisjob and updates it. Returns the server's representation of the naisjob, and an error, if there is any.
+func (c *naisjobs) Update(naisjob *v1.Naisjob) (result *v1.Naisjob, err error) {
+	result = &v1.Naisjob{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		Name(naisjob.Name).
+		Body(naisjob).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the naisjob and deletes it. Returns an error if one occurs.
+func (c *naisjobs) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *naisjobs) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("naisjobs").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched naisjob.
+func (c *naisjobs) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.Naisjob, err error) {
+	result = &v1.Naisjob{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("naisjobs").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
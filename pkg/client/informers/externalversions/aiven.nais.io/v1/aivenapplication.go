@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	aivennaisiov1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	versioned "github.com/nais/liberator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/nais/liberator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/nais/liberator/pkg/client/listers/aiven.nais.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AivenApplicationInformer provides access to a shared informer and lister for
+// AivenApplications.
+type AivenApplicationInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.AivenApplicationLister
+}
+
+type aivenApplicationInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAivenApplicationInformer constructs a new informer for AivenApplication type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAivenApplicationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAivenApplicationInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAivenApplicationInformer constructs a new informer for AivenApplication type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAivenApplicationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AivenV1().AivenApplications(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AivenV1().AivenApplications(namespace).Watch(options)
+			},
+		},
+		&aivennaisiov1.AivenApplication{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *aivenApplicationInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAivenApplicationInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *aivenApplicationInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&aivennaisiov1.AivenApplication{}, f.defaultInformer)
+}
+
+func (f *aivenApplicationInformer) Lister() v1.AivenApplicationLister {
+	return v1.NewAivenApplicationLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	kafkanaisiov1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	naisiov1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=aiven.nais.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("aivenapplications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aiven().V1().AivenApplications().Informer()}, nil
+
+		// Group=kafka.nais.io, Version=v1
+	case kafkanaisiov1.SchemeGroupVersion.WithResource("topics"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kafka().V1().Topics().Informer()}, nil
+
+		// Group=nais.io, Version=v1
+	case naisiov1.SchemeGroupVersion.WithResource("alerts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().Alerts().Informer()}, nil
+	case naisiov1.SchemeGroupVersion.WithResource("applications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().Applications().Informer()}, nil
+	case naisiov1.SchemeGroupVersion.WithResource("azureadapplications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().AzureAdApplications().Informer()}, nil
+	case naisiov1.SchemeGroupVersion.WithResource("idportenclients"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().IDPortenClients().Informer()}, nil
+	case naisiov1.SchemeGroupVersion.WithResource("jwkers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().Jwkers().Informer()}, nil
+	case naisiov1.SchemeGroupVersion.WithResource("maskinportenclients"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().MaskinportenClients().Informer()}, nil
+	case naisiov1.SchemeGroupVersion.WithResource("naisjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1().Naisjobs().Informer()}, nil
+
+		// Group=nais.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("applications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Nais().V1alpha1().Applications().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
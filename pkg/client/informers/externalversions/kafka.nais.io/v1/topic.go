@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	kafkanaisiov1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	versioned "github.com/nais/liberator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/nais/liberator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/nais/liberator/pkg/client/listers/kafka.nais.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// TopicInformer provides access to a shared informer and lister for
+// Topics.
+type TopicInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.TopicLister
+}
+
+type topicInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewTopicInformer constructs a new informer for Topic type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewTopicInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredTopicInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredTopicInformer constructs a new informer for Topic type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredTopicInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KafkaV1().Topics(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KafkaV1().Topics(namespace).Watch(options)
+			},
+		},
+		&kafkanaisiov1.Topic{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *topicInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredTopicInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *topicInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&kafkanaisiov1.Topic{}, f.defaultInformer)
+}
+
+func (f *topicInformer) Lister() v1.TopicLister {
+	return v1.NewTopicLister(f.Informer().GetIndexer())
+}
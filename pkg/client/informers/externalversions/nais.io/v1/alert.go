@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	naisiov1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	versioned "github.com/nais/liberator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/nais/liberator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/nais/liberator/pkg/client/listers/nais.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AlertInformer provides access to a shared informer and lister for
+// Alerts.
+type AlertInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.AlertLister
+}
+
+type alertInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAlertInformer constructs a new informer for Alert type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAlertInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAlertInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAlertInformer constructs a new informer for Alert type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAlertInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NaisV1().Alerts(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NaisV1().Alerts(namespace).Watch(options)
+			},
+		},
+		&naisiov1.Alert{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *alertInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAlertInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *alertInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&naisiov1.Alert{}, f.defaultInformer)
+}
+
+func (f *alertInformer) Lister() v1.AlertLister {
+	return v1.NewAlertLister(f.Informer().GetIndexer())
+}
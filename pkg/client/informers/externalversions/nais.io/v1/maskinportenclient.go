@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	naisiov1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	versioned "github.com/nais/liberator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/nais/liberator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/nais/liberator/pkg/client/listers/nais.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// MaskinportenClientInformer provides access to a shared informer and lister for
+// MaskinportenClients.
+type MaskinportenClientInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.MaskinportenClientLister
+}
+
+type maskinportenClientInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewMaskinportenClientInformer constructs a new informer for MaskinportenClient type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewMaskinportenClientInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredMaskinportenClientInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredMaskinportenClientInformer constructs a new informer for MaskinportenClient type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredMaskinportenClientInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NaisV1().MaskinportenClients(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NaisV1().MaskinportenClients(namespace).Watch(options)
+			},
+		},
+		&naisiov1.MaskinportenClient{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *maskinportenClientInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredMaskinportenClientInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *maskinportenClientInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&naisiov1.MaskinportenClient{}, f.defaultInformer)
+}
+
+func (f *maskinportenClientInformer) Lister() v1.MaskinportenClientLister {
+	return v1.NewMaskinportenClientLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// AivenApplicationLister helps list AivenApplications.
+type AivenApplicationLister interface {
+	// List lists all AivenApplications in the indexer.
+	List(selector labels.Selector) (ret []*v1.AivenApplication, err error)
+	// AivenApplications returns an object that can list and get AivenApplications.
+	AivenApplications(namespace string) AivenApplicationNamespaceLister
+	AivenApplicationListerExpansion
+}
+
+// aivenApplicationLister implements the AivenApplicationLister interface.
+type aivenApplicationLister struct {
+	indexer cache.Indexer
+}
+
+// NewAivenApplicationLister returns a new AivenApplicationLister.
+func NewAivenApplicationLister(indexer cache.Indexer) AivenApplicationLister {
+	return &aivenApplicationLister{indexer: indexer}
+}
+
+// List lists all AivenApplications in the indexer.
+func (s *aivenApplicationLister) List(selector labels.Selector) (ret []*v1.AivenApplication, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.AivenApplication))
+	})
+	return ret, err
+}
+
+// AivenApplications returns an object that can list and get AivenApplications.
+func (s *aivenApplicationLister) AivenApplications(namespace string) AivenApplicationNamespaceLister {
+	return aivenApplicationNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// AivenApplicationNamespaceLister helps list and get AivenApplications.
+type AivenApplicationNamespaceLister interface {
+	// List lists all AivenApplications in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.AivenApplication, err error)
+	// Get retrieves the AivenApplication from the indexer for a given namespace and name.
+	Get(name string) (*v1.AivenApplication, error)
+	AivenApplicationNamespaceListerExpansion
+}
+
+// aivenApplicationNamespaceLister implements the AivenApplicationNamespaceLister
+// interface.
+type aivenApplicationNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all AivenApplications in the indexer for a given namespace.
+func (s aivenApplicationNamespaceLister) List(selector labels.Selector) (ret []*v1.AivenApplication, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.AivenApplication))
+	})
+	return ret, err
+}
+
+// Get retrieves the AivenApplication from the indexer for a given namespace and name.
+func (s aivenApplicationNamespaceLister) Get(name string) (*v1.AivenApplication, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("aivenapplication"), name)
+	}
+	return obj.(*v1.AivenApplication), nil
+}
@@ -0,0 +1,11 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// AivenApplicationListerExpansion allows custom methods to be added to
+// AivenApplicationLister.
+type AivenApplicationListerExpansion interface{}
+
+// AivenApplicationNamespaceListerExpansion allows custom methods to be added to
+// AivenApplicationNamespaceLister.
+type AivenApplicationNamespaceListerExpansion interface{}
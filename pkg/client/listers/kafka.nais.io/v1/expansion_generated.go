@@ -0,0 +1,11 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// TopicListerExpansion allows custom methods to be added to
+// TopicLister.
+type TopicListerExpansion interface{}
+
+// TopicNamespaceListerExpansion allows custom methods to be added to
+// TopicNamespaceLister.
+type TopicNamespaceListerExpansion interface{}
@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// AzureAdApplicationLister helps list AzureAdApplications.
+type AzureAdApplicationLister interface {
+	// List lists all AzureAdApplications in the indexer.
+	List(selector labels.Selector) (ret []*v1.AzureAdApplication, err error)
+	// AzureAdApplications returns an object that can list and get AzureAdApplications.
+	AzureAdApplications(namespace string) AzureAdApplicationNamespaceLister
+	AzureAdApplicationListerExpansion
+}
+
+// azureAdApplicationLister implements the AzureAdApplicationLister interface.
+type azureAdApplicationLister struct {
+	indexer cache.Indexer
+}
+
+// NewAzureAdApplicationLister returns a new AzureAdApplicationLister.
+func NewAzureAdApplicationLister(indexer cache.Indexer) AzureAdApplicationLister {
+	return &azureAdApplicationLister{indexer: indexer}
+}
+
+// List lists all AzureAdApplications in the indexer.
+func (s *azureAdApplicationLister) List(selector labels.Selector) (ret []*v1.AzureAdApplication, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.AzureAdApplication))
+	})
+	return ret, err
+}
+
+// AzureAdApplications returns an object that can list and get AzureAdApplications.
+func (s *azureAdApplicationLister) AzureAdApplications(namespace string) AzureAdApplicationNamespaceLister {
+	return azureAdApplicationNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// AzureAdApplicationNamespaceLister helps list and get AzureAdApplications.
+type AzureAdApplicationNamespaceLister interface {
+	// List lists all AzureAdApplications in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.AzureAdApplication, err error)
+	// Get retrieves the AzureAdApplication from the indexer for a given namespace and name.
+	Get(name string) (*v1.AzureAdApplication, error)
+	AzureAdApplicationNamespaceListerExpansion
+}
+
+// azureAdApplicationNamespaceLister implements the AzureAdApplicationNamespaceLister
+// interface.
+type azureAdApplicationNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all AzureAdApplications in the indexer for a given namespace.
+func (s azureAdApplicationNamespaceLister) List(selector labels.Selector) (ret []*v1.AzureAdApplication, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.AzureAdApplication))
+	})
+	return ret, err
+}
+
+// Get retrieves the AzureAdApplication from the indexer for a given namespace and name.
+func (s azureAdApplicationNamespaceLister) Get(name string) (*v1.AzureAdApplication, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("azureadapplication"), name)
+	}
+	return obj.(*v1.AzureAdApplication), nil
+}
@@ -0,0 +1,59 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// AlertListerExpansion allows custom methods to be added to
+// AlertLister.
+type AlertListerExpansion interface{}
+
+// AlertNamespaceListerExpansion allows custom methods to be added to
+// AlertNamespaceLister.
+type AlertNamespaceListerExpansion interface{}
+
+// ApplicationListerExpansion allows custom methods to be added to
+// ApplicationLister.
+type ApplicationListerExpansion interface{}
+
+// ApplicationNamespaceListerExpansion allows custom methods to be added to
+// ApplicationNamespaceLister.
+type ApplicationNamespaceListerExpansion interface{}
+
+// AzureAdApplicationListerExpansion allows custom methods to be added to
+// AzureAdApplicationLister.
+type AzureAdApplicationListerExpansion interface{}
+
+// AzureAdApplicationNamespaceListerExpansion allows custom methods to be added to
+// AzureAdApplicationNamespaceLister.
+type AzureAdApplicationNamespaceListerExpansion interface{}
+
+// IDPortenClientListerExpansion allows custom methods to be added to
+// IDPortenClientLister.
+type IDPortenClientListerExpansion interface{}
+
+// IDPortenClientNamespaceListerExpansion allows custom methods to be added to
+// IDPortenClientNamespaceLister.
+type IDPortenClientNamespaceListerExpansion interface{}
+
+// JwkerListerExpansion allows custom methods to be added to
+// JwkerLister.
+type JwkerListerExpansion interface{}
+
+// JwkerNamespaceListerExpansion allows custom methods to be added to
+// JwkerNamespaceLister.
+type JwkerNamespaceListerExpansion interface{}
+
+// MaskinportenClientListerExpansion allows custom methods to be added to
+// MaskinportenClientLister.
+type MaskinportenClientListerExpansion interface{}
+
+// MaskinportenClientNamespaceListerExpansion allows custom methods to be added to
+// MaskinportenClientNamespaceLister.
+type MaskinportenClientNamespaceListerExpansion interface{}
+
+// NaisjobListerExpansion allows custom methods to be added to
+// NaisjobLister.
+type NaisjobListerExpansion interface{}
+
+// NaisjobNamespaceListerExpansion allows custom methods to be added to
+// NaisjobNamespaceLister.
+type NaisjobNamespaceListerExpansion interface{}
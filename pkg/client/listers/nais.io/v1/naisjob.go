@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NaisjobLister helps list Naisjobs.
+type NaisjobLister interface {
+	// List lists all Naisjobs in the indexer.
+	List(selector labels.Selector) (ret []*v1.Naisjob, err error)
+	// Naisjobs returns an object that can list and get Naisjobs.
+	Naisjobs(namespace string) NaisjobNamespaceLister
+	NaisjobListerExpansion
+}
+
+// naisjobLister implements the NaisjobLister interface.
+type naisjobLister struct {
+	indexer cache.Indexer
+}
+
+// NewNaisjobLister returns a new NaisjobLister.
+func NewNaisjobLister(indexer cache.Indexer) NaisjobLister {
+	return &naisjobLister{indexer: indexer}
+}
+
+// List lists all Naisjobs in the indexer.
+func (s *naisjobLister) List(selector labels.Selector) (ret []*v1.Naisjob, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Naisjob))
+	})
+	return ret, err
+}
+
+// Naisjobs returns an object that can list and get Naisjobs.
+func (s *naisjobLister) Naisjobs(namespace string) NaisjobNamespaceLister {
+	return naisjobNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// NaisjobNamespaceLister helps list and get Naisjobs.
+type NaisjobNamespaceLister interface {
+	// List lists all Naisjobs in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.Naisjob, err error)
+	// Get retrieves the Naisjob from the indexer for a given namespace and name.
+	Get(name string) (*v1.Naisjob, error)
+	NaisjobNamespaceListerExpansion
+}
+
+// naisjobNamespaceLister implements the NaisjobNamespaceLister
+// interface.
+type naisjobNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all Naisjobs in the indexer for a given namespace.
+func (s naisjobNamespaceLister) List(selector labels.Selector) (ret []*v1.Naisjob, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Naisjob))
+	})
+	return ret, err
+}
+
+// Get retrieves the Naisjob from the indexer for a given namespace and name.
+func (s naisjobNamespaceLister) Get(name string) (*v1.Naisjob, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("naisjob"), name)
+	}
+	return obj.(*v1.Naisjob), nil
+}
@@ -0,0 +1,148 @@
+// Package conditions provides status conditions shared by all liberator CRDs.
+//
+// Conditions follow the Kubernetes API conventions, so that dashboards and
+// `kubectl wait --for=condition=Ready` behave the same for every resource.
+// +kubebuilder:object:generate=true
+package conditions
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConditionType string
+
+const (
+	// Ready means that the resource is fully reconciled and its dependents are available.
+	Ready ConditionType = "Ready"
+	// Synchronized means that the most recent spec has been applied successfully.
+	Synchronized ConditionType = "Synchronized"
+	// Degraded means that the resource is operational, but with reduced functionality or redundancy.
+	Degraded ConditionType = "Degraded"
+)
+
+// Condition describes one aspect of a resource's state at a certain point.
+type Condition struct {
+	// Type of condition.
+	Type ConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// The metadata.generation of the resource this condition was set for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Machine readable, CamelCase reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about the transition.
+	Message string `json:"message,omitempty"`
+}
+
+// Status is implemented by the status of every liberator CRD.
+// +k8s:deepcopy-gen=false
+type Status interface {
+	GetConditions() []Condition
+	SetConditions(conditions []Condition)
+}
+
+// Get returns the condition of the given type, or nil if it is not set.
+func Get(status Status, conditionType ConditionType) *Condition {
+	for _, condition := range status.GetConditions() {
+		if condition.Type == conditionType {
+			return &condition
+		}
+	}
+	return nil
+}
+
+// Set adds or replaces the condition of the same type.
+//
+// The last transition time is only changed if the condition's status changes.
+// If the condition has no transition time, the current time is used.
+func Set(status Status, condition Condition) {
+	existing := status.GetConditions()
+	conditions := make([]Condition, len(existing), len(existing)+1)
+	copy(conditions, existing)
+
+	for i, c := range conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+		conditions[i] = withTransitionTime(condition)
+		status.SetConditions(conditions)
+		return
+	}
+
+	status.SetConditions(append(conditions, withTransitionTime(condition)))
+}
+
+func withTransitionTime(condition Condition) Condition {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	return condition
+}
+
+// Remove deletes the condition of the given type, if present.
+func Remove(status Status, conditionType ConditionType) {
+	existing := status.GetConditions()
+	result := make([]Condition, 0, len(existing))
+	for _, c := range existing {
+		if c.Type != conditionType {
+			result = append(result, c)
+		}
+	}
+	status.SetConditions(result)
+}
+
+// IsTrue returns true if the condition of the given type is set and has status True.
+func IsTrue(status Status, conditionType ConditionType) bool {
+	condition := Get(status, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// IsCurrent returns true if the condition of the given type has status True,
+// and was set while reconciling the given generation of the resource or a later one.
+func IsCurrent(status Status, conditionType ConditionType, generation int64) bool {
+	condition := Get(status, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue && condition.ObservedGeneration >= generation
+}
+
+// MarkReady sets the Ready condition to True, and clears the Degraded condition.
+func MarkReady(status Status, generation int64, reason, message string) {
+	set(status, Ready, corev1.ConditionTrue, generation, reason, message)
+	set(status, Degraded, corev1.ConditionFalse, generation, reason, "")
+}
+
+// MarkNotReady sets the Ready condition to False.
+func MarkNotReady(status Status, generation int64, reason, message string) {
+	set(status, Ready, corev1.ConditionFalse, generation, reason, message)
+}
+
+// MarkSynchronized sets the Synchronized condition to True.
+func MarkSynchronized(status Status, generation int64, reason, message string) {
+	set(status, Synchronized, corev1.ConditionTrue, generation, reason, message)
+}
+
+// MarkSynchronizationFailed sets the Synchronized condition to False.
+func MarkSynchronizationFailed(status Status, generation int64, reason, message string) {
+	set(status, Synchronized, corev1.ConditionFalse, generation, reason, message)
+}
+
+// MarkDegraded sets the Degraded condition to True. The Ready condition is left untouched.
+func MarkDegraded(status Status, generation int64, reason, message string) {
+	set(status, Degraded, corev1.ConditionTrue, generation, reason, message)
+}
+
+func set(status Status, conditionType ConditionType, conditionStatus corev1.ConditionStatus, generation int64, reason, message string) {
+	Set(status, Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,107 @@
+package conditions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	aiven_nais_io_v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	kafka_nais_io_v1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/conditions"
+)
+
+var _ = []conditions.Status{
+	&nais_io_v1alpha1.ApplicationStatus{},
+	&nais_io_v1.ApplicationStatus{},
+	&nais_io_v1.NaisjobStatus{},
+	&nais_io_v1.AlertStatus{},
+	&nais_io_v1.JwkerStatus{},
+	&nais_io_v1.DigdiratorStatus{},
+	&nais_io_v1.AzureAdApplicationStatus{},
+	&kafka_nais_io_v1.TopicStatus{},
+	&aiven_nais_io_v1.AivenApplicationStatus{},
+}
+
+func TestSet(t *testing.T) {
+	status := &nais_io_v1alpha1.ApplicationStatus{}
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	conditions.Set(status, conditions.Condition{
+		Type:               conditions.Ready,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: past,
+		Reason:             "Rolling",
+	})
+	conditions.Set(status, conditions.Condition{Type: conditions.Synchronized, Status: corev1.ConditionTrue})
+	require.Len(t, status.Conditions, 2)
+
+	// same status keeps the transition time, but updates everything else
+	conditions.Set(status, conditions.Condition{
+		Type:               conditions.Ready,
+		Status:             corev1.ConditionFalse,
+		ObservedGeneration: 2,
+		Reason:             "StillRolling",
+	})
+	require.Len(t, status.Conditions, 2)
+	ready := conditions.Get(status, conditions.Ready)
+	assert.Equal(t, past, ready.LastTransitionTime)
+	assert.Equal(t, "StillRolling", ready.Reason)
+	assert.Equal(t, int64(2), ready.ObservedGeneration)
+	assert.Equal(t, conditions.Ready, status.Conditions[0].Type, "order is preserved")
+
+	// status change bumps the transition time
+	conditions.Set(status, conditions.Condition{Type: conditions.Ready, Status: corev1.ConditionTrue})
+	ready = conditions.Get(status, conditions.Ready)
+	assert.True(t, ready.LastTransitionTime.After(past.Time))
+	assert.True(t, conditions.IsTrue(status, conditions.Ready))
+
+	conditions.Remove(status, conditions.Ready)
+	assert.Nil(t, conditions.Get(status, conditions.Ready))
+	assert.False(t, conditions.IsTrue(status, conditions.Ready))
+	assert.Len(t, status.Conditions, 1)
+}
+
+func TestMark(t *testing.T) {
+	status := &nais_io_v1.NaisjobStatus{}
+
+	conditions.MarkDegraded(status, 1, "ReplicaMissing", "one replica is unavailable")
+	assert.True(t, conditions.IsTrue(status, conditions.Degraded))
+
+	conditions.MarkReady(status, 3, "RolloutComplete", "")
+	assert.True(t, conditions.IsTrue(status, conditions.Ready))
+	assert.False(t, conditions.IsTrue(status, conditions.Degraded), "ready clears degraded")
+
+	assert.True(t, conditions.IsCurrent(status, conditions.Ready, 3))
+	assert.False(t, conditions.IsCurrent(status, conditions.Ready, 4), "newer generation not yet observed")
+
+	conditions.MarkNotReady(status, 4, "RolloutFailed", "image pull failed")
+	assert.False(t, conditions.IsCurrent(status, conditions.Ready, 4))
+
+	conditions.MarkSynchronizationFailed(status, 4, "Error", "")
+	assert.False(t, conditions.IsTrue(status, conditions.Synchronized))
+	conditions.MarkSynchronized(status, 4, "Synchronized", "")
+	assert.True(t, conditions.IsCurrent(status, conditions.Synchronized, 4))
+}
+
+func TestAivenApplicationStatus(t *testing.T) {
+	status := &aiven_nais_io_v1.AivenApplicationStatus{ObservedGeneration: 5}
+	status.AddCondition(aiven_nais_io_v1.AivenApplicationCondition{
+		Type:   aiven_nais_io_v1.AivenApplicationSucceeded,
+		Status: corev1.ConditionTrue,
+	})
+
+	conditions.MarkReady(status, 5, "Provisioned", "")
+
+	assert.True(t, conditions.IsTrue(status, conditions.ConditionType(aiven_nais_io_v1.AivenApplicationSucceeded)))
+	assert.True(t, conditions.IsCurrent(status, conditions.Ready, 5))
+	ready := status.GetConditionOfType(aiven_nais_io_v1.AivenApplicationConditionType(conditions.Ready))
+	require.NotNil(t, ready)
+	assert.Equal(t, "Provisioned", ready.Reason)
+	assert.False(t, ready.LastUpdateTime.IsZero())
+}
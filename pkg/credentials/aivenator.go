@@ -0,0 +1,30 @@
+package credentials
+
+import (
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+// KafkaCredentials are provisioned by Aivenator for an AivenApplication with Kafka access.
+// The keystore and truststore are stored as files, and are never prefixed.
+type KafkaCredentials struct {
+	Brokers                string `key:"BROKERS"`
+	Certificate            string `key:"CERTIFICATE"`
+	PrivateKey             string `key:"PRIVATE_KEY"`
+	CA                     string `key:"CA"`
+	CredstorePassword      string `key:"CREDSTORE_PASSWORD"`
+	SchemaRegistry         string `key:"SCHEMA_REGISTRY,optional"`
+	SchemaRegistryUser     string `key:"SCHEMA_REGISTRY_USER,optional"`
+	SchemaRegistryPassword string `key:"SCHEMA_REGISTRY_PASSWORD,optional"`
+	Keystore               []byte `key:"client.keystore.p12,noprefix"`
+	Truststore             []byte `key:"client.truststore.jks,noprefix"`
+}
+
+var _ Credentials = KafkaCredentials{}
+
+func (in KafkaCredentials) SecretType() string {
+	return kubernetes.AivenSecretType
+}
+
+func (in KafkaCredentials) DefaultPrefix() string {
+	return "KAFKA"
+}
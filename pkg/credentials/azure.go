@@ -0,0 +1,28 @@
+package credentials
+
+import (
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+// AzureCredentials are provisioned by Azurerator for an AzureAdApplication.
+type AzureCredentials struct {
+	ClientID          string `key:"APP_CLIENT_ID"`
+	ClientSecret      string `key:"APP_CLIENT_SECRET"`
+	JWK               string `key:"APP_JWK"`
+	PreAuthorizedApps string `key:"APP_PRE_AUTHORIZED_APPS"`
+	TenantID          string `key:"APP_TENANT_ID"`
+	WellKnownURL      string `key:"APP_WELL_KNOWN_URL"`
+	Issuer            string `key:"OPENID_CONFIG_ISSUER,optional"`
+	JwksURI           string `key:"OPENID_CONFIG_JWKS_URI,optional"`
+	TokenEndpoint     string `key:"OPENID_CONFIG_TOKEN_ENDPOINT,optional"`
+}
+
+var _ Credentials = AzureCredentials{}
+
+func (in AzureCredentials) SecretType() string {
+	return kubernetes.AzureSecretType
+}
+
+func (in AzureCredentials) DefaultPrefix() string {
+	return "AZURE"
+}
@@ -0,0 +1,229 @@
+// Package credentials defines the contract between NAIS provisioners and the applications consuming their secrets.
+//
+// Each provisioner has a credentials type whose fields map to secret keys through the `key` struct tag.
+// Keys are prefixed with the provisioner's default prefix, or a user-defined prefix, and separated by an underscore.
+// The tag options `noprefix` and `optional` denote keys that are never prefixed and keys that may be absent, respectively.
+// Fields of type []byte hold binary data, all other fields must be strings.
+//
+//	secret, err := credentials.Secret(azureCredentials, credentials.Metadata{Name: "azure-myapp-1a2b3c", ...}, "")
+//	err = credentials.Parse(*secret, &azureCredentials, "")
+package credentials
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+// SecretKeyPrefixAnnotation records the key prefix used when the secret was created.
+const SecretKeyPrefixAnnotation = "nais.io/secretKeyPrefix"
+
+// Credentials is implemented by the credentials of every provisioner.
+type Credentials interface {
+	// Value of the `type` label on secrets with these credentials.
+	SecretType() string
+	// Prefix applied to all keys, unless overridden.
+	DefaultPrefix() string
+}
+
+// Metadata describes the secret holding a set of credentials.
+type Metadata struct {
+	Name      string
+	Namespace string
+	// Name of the application consuming the credentials.
+	App string
+	// Resource that requested the credentials. The secret is garbage collected together with the owner.
+	Owner *metav1.OwnerReference
+	// Additional labels and annotations. Standard labels take precedence.
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+type key struct {
+	name     string
+	noPrefix bool
+	optional bool
+	binary   bool
+	field    int
+}
+
+// Secret builds a secret containing the credentials.
+// If prefix is empty, the default prefix of the credentials is used.
+// An error is returned if a required key has an empty value.
+func Secret(creds Credentials, meta Metadata, prefix string) (*corev1.Secret, error) {
+	keys, value, err := keysFor(creds)
+	if err != nil {
+		return nil, err
+	}
+	prefix = Prefix(creds, prefix)
+	if errs := ValidatePrefix(creds, prefix, field.NewPath("prefix")); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
+	data := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		field := value.Field(k.field)
+		var v []byte
+		if k.binary {
+			v = field.Bytes()
+		} else {
+			v = []byte(field.String())
+		}
+		name := k.key(prefix)
+		if len(v) == 0 {
+			if k.optional {
+				continue
+			}
+			return nil, fmt.Errorf("%T: required key %s is empty", creds, name)
+		}
+		data[name] = v
+	}
+
+	labels := make(map[string]string)
+	for k, v := range meta.Labels {
+		labels[k] = v
+	}
+	labels[kubernetes.SecretTypeLabelKey] = creds.SecretType()
+	labels[kubernetes.SecretAppLabelKey] = meta.App
+	labels[kubernetes.SecretTeamLabelKey] = meta.Namespace
+
+	annotations := make(map[string]string)
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	annotations[SecretKeyPrefixAnnotation] = prefix
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        meta.Name,
+			Namespace:   meta.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Data: data,
+		Type: corev1.SecretTypeOpaque,
+	}
+	if meta.Owner != nil {
+		secret.OwnerReferences = []metav1.OwnerReference{*meta.Owner}
+	}
+
+	return secret, nil
+}
+
+// Parse reads credentials from a secret into creds, which must be a pointer to a credentials struct.
+// If prefix is empty, the prefix recorded on the secret is used, falling back to the default prefix of the credentials.
+// An error is returned if a required key is missing.
+func Parse(secret corev1.Secret, creds Credentials, prefix string) error {
+	keys, value, err := keysFor(creds)
+	if err != nil {
+		return err
+	}
+	if !value.CanSet() {
+		return fmt.Errorf("%T: credentials must be passed by pointer", creds)
+	}
+	if len(prefix) == 0 {
+		prefix = secret.Annotations[SecretKeyPrefixAnnotation]
+	}
+	prefix = Prefix(creds, prefix)
+
+	for _, k := range keys {
+		name := k.key(prefix)
+		v, found := secret.Data[name]
+		if !found {
+			s, ok := secret.StringData[name]
+			v, found = []byte(s), ok
+		}
+		if !found || len(v) == 0 {
+			if k.optional {
+				continue
+			}
+			return fmt.Errorf("secret %s/%s: required key %s not found", secret.Namespace, secret.Name, name)
+		}
+		if k.binary {
+			value.Field(k.field).SetBytes(v)
+		} else {
+			value.Field(k.field).SetString(string(v))
+		}
+	}
+
+	return nil
+}
+
+// Keys returns the sorted secret keys of the given credentials type, with the given prefix applied.
+// If prefix is empty, the default prefix is used.
+func Keys(creds Credentials, prefix string) ([]string, error) {
+	keys, _, err := keysFor(creds)
+	if err != nil {
+		return nil, err
+	}
+	prefix = Prefix(creds, prefix)
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.key(prefix)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (k key) key(prefix string) string {
+	if k.noPrefix {
+		return k.name
+	}
+	return PrefixedKey(prefix, k.name)
+}
+
+func keysFor(creds Credentials) ([]key, reflect.Value, error) {
+	value := reflect.ValueOf(creds)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, value, fmt.Errorf("%T: credentials must be a struct", creds)
+	}
+
+	t := value.Type()
+	keys := make([]key, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("key")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		k := key{
+			name:  parts[0],
+			field: i,
+		}
+		for _, option := range parts[1:] {
+			switch option {
+			case "noprefix":
+				k.noPrefix = true
+			case "optional":
+				k.optional = true
+			default:
+				return nil, value, fmt.Errorf("%T: field %s: unknown key option %q", creds, field.Name, option)
+			}
+		}
+		switch {
+		case field.Type.Kind() == reflect.String:
+		case field.Type == reflect.TypeOf([]byte{}):
+			k.binary = true
+		default:
+			return nil, value, fmt.Errorf("%T: field %s must be string or []byte", creds, field.Name)
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, value, nil
+}
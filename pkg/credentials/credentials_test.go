@@ -0,0 +1,164 @@
+package credentials_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nais/liberator/pkg/credentials"
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+func metadata() credentials.Metadata {
+	return credentials.Metadata{
+		Name:      "azure-myapp-1a2b3c",
+		Namespace: "myteam",
+		App:       "myapp",
+		Owner: &metav1.OwnerReference{
+			APIVersion: "nais.io/v1",
+			Kind:       "AzureAdApplication",
+			Name:       "myapp",
+			UID:        "123",
+		},
+		Labels: map[string]string{
+			"foo":                         "bar",
+			kubernetes.SecretTypeLabelKey: "overridden",
+		},
+	}
+}
+
+func azureCredentials() credentials.AzureCredentials {
+	return credentials.AzureCredentials{
+		ClientID:          "client-id",
+		ClientSecret:      "client-secret",
+		JWK:               "{}",
+		PreAuthorizedApps: "[]",
+		TenantID:          "tenant",
+		WellKnownURL:      "https://login.microsoftonline.com/tenant/v2.0/.well-known/openid-configuration",
+	}
+}
+
+func TestSecret(t *testing.T) {
+	secret, err := credentials.Secret(azureCredentials(), metadata(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "azure-myapp-1a2b3c", secret.Name)
+	assert.Equal(t, "myteam", secret.Namespace)
+	assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+	assert.Equal(t, map[string]string{
+		"foo":                         "bar",
+		kubernetes.SecretTypeLabelKey: kubernetes.AzureSecretType,
+		kubernetes.SecretAppLabelKey:  "myapp",
+		kubernetes.SecretTeamLabelKey: "myteam",
+	}, secret.Labels)
+	assert.Equal(t, "AZURE", secret.Annotations[credentials.SecretKeyPrefixAnnotation])
+	assert.Equal(t, []metav1.OwnerReference{*metadata().Owner}, secret.OwnerReferences)
+
+	assert.Len(t, secret.Data, 6, "empty optional keys are left out")
+	assert.Equal(t, []byte("client-id"), secret.Data["AZURE_APP_CLIENT_ID"])
+	assert.Equal(t, []byte("tenant"), secret.Data["AZURE_APP_TENANT_ID"])
+
+	t.Run("custom prefix", func(t *testing.T) {
+		secret, err := credentials.Secret(azureCredentials(), metadata(), "MY_AZURE")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("client-id"), secret.Data["MY_AZURE_APP_CLIENT_ID"])
+		assert.Equal(t, "MY_AZURE", secret.Annotations[credentials.SecretKeyPrefixAnnotation])
+	})
+
+	t.Run("missing required key", func(t *testing.T) {
+		creds := azureCredentials()
+		creds.ClientSecret = ""
+		_, err := credentials.Secret(creds, metadata(), "")
+		assert.EqualError(t, err, "credentials.AzureCredentials: required key AZURE_APP_CLIENT_SECRET is empty")
+	})
+}
+
+func TestParse(t *testing.T) {
+	expected := azureCredentials()
+	secret, err := credentials.Secret(expected, metadata(), "MY_AZURE")
+	require.NoError(t, err)
+
+	actual := credentials.AzureCredentials{}
+	err = credentials.Parse(*secret, &actual, "")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual, "prefix is read from the secret annotation")
+
+	t.Run("explicit prefix", func(t *testing.T) {
+		actual := credentials.AzureCredentials{}
+		err := credentials.Parse(*secret, &actual, "AZURE")
+		assert.EqualError(t, err, "secret myteam/azure-myapp-1a2b3c: required key AZURE_APP_CLIENT_ID not found")
+	})
+
+	t.Run("string data and default prefix", func(t *testing.T) {
+		secret := corev1.Secret{StringData: map[string]string{
+			"MASKINPORTEN_CLIENT_ID":      "client-id",
+			"MASKINPORTEN_CLIENT_JWK":     "{}",
+			"MASKINPORTEN_SCOPES":         "nav:test/api",
+			"MASKINPORTEN_WELL_KNOWN_URL": "https://maskinporten.no/.well-known/oauth-authorization-server",
+		}}
+		actual := credentials.MaskinportenCredentials{}
+		err := credentials.Parse(secret, &actual, "")
+		require.NoError(t, err)
+		assert.Equal(t, "nav:test/api", actual.Scopes)
+	})
+
+	t.Run("credentials must be passed by pointer", func(t *testing.T) {
+		err := credentials.Parse(*secret, credentials.AzureCredentials{}, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestKafkaCredentials(t *testing.T) {
+	expected := credentials.KafkaCredentials{
+		Brokers:           "broker:1234",
+		Certificate:       "cert",
+		PrivateKey:        "key",
+		CA:                "ca",
+		CredstorePassword: "changeme",
+		Keystore:          []byte{0x00, 0x01},
+		Truststore:        []byte{0x02, 0x03},
+	}
+	secret, err := credentials.Secret(expected, metadata(), "")
+	require.NoError(t, err)
+	assert.Equal(t, kubernetes.AivenSecretType, secret.Labels[kubernetes.SecretTypeLabelKey])
+	assert.Equal(t, []byte{0x00, 0x01}, secret.Data["client.keystore.p12"])
+	assert.Equal(t, []byte("broker:1234"), secret.Data["KAFKA_BROKERS"])
+
+	actual := credentials.KafkaCredentials{}
+	require.NoError(t, credentials.Parse(*secret, &actual, ""))
+	assert.Equal(t, expected, actual)
+}
+
+// Credentials must be structs with tagged fields.
+type unsupportedCredentials string
+
+func (unsupportedCredentials) SecretType() string    { return "unsupported" }
+func (unsupportedCredentials) DefaultPrefix() string { return "UNSUPPORTED" }
+
+func TestKeys(t *testing.T) {
+	keys := func(creds credentials.Credentials, prefix string) []string {
+		keys, err := credentials.Keys(creds, prefix)
+		require.NoError(t, err)
+		return keys
+	}
+
+	assert.Equal(t, []string{
+		"TOKEN_X_CLIENT_ID",
+		"TOKEN_X_PRIVATE_JWK",
+		"TOKEN_X_WELL_KNOWN_URL",
+	}, keys(credentials.TokenXCredentials{}, ""))
+	assert.Equal(t, []string{
+		"IDPORTEN_CLIENT_ID",
+		"IDPORTEN_CLIENT_JWK",
+		"IDPORTEN_REDIRECT_URI",
+		"IDPORTEN_WELL_KNOWN_URL",
+	}, keys(&credentials.IDPortenCredentials{}, ""))
+	assert.Contains(t, keys(credentials.KafkaCredentials{}, "MY_KAFKA"), "client.truststore.jks")
+	assert.Contains(t, keys(credentials.KafkaCredentials{}, "MY_KAFKA"), "MY_KAFKA_CA")
+
+	_, err := credentials.Keys(unsupportedCredentials("token"), "")
+	assert.Error(t, err, "unknown credentials types are rejected")
+}
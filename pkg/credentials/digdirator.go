@@ -0,0 +1,40 @@
+package credentials
+
+import (
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+// MaskinportenCredentials are provisioned by Digdirator for a MaskinportenClient.
+type MaskinportenCredentials struct {
+	ClientID     string `key:"CLIENT_ID"`
+	JWK          string `key:"CLIENT_JWK"`
+	Scopes       string `key:"SCOPES"`
+	WellKnownURL string `key:"WELL_KNOWN_URL"`
+}
+
+// IDPortenCredentials are provisioned by Digdirator for an IDPortenClient.
+type IDPortenCredentials struct {
+	ClientID     string `key:"CLIENT_ID"`
+	JWK          string `key:"CLIENT_JWK"`
+	RedirectURI  string `key:"REDIRECT_URI"`
+	WellKnownURL string `key:"WELL_KNOWN_URL"`
+}
+
+var _ Credentials = MaskinportenCredentials{}
+var _ Credentials = IDPortenCredentials{}
+
+func (in MaskinportenCredentials) SecretType() string {
+	return kubernetes.DigdiratorSecretType
+}
+
+func (in MaskinportenCredentials) DefaultPrefix() string {
+	return "MASKINPORTEN"
+}
+
+func (in IDPortenCredentials) SecretType() string {
+	return kubernetes.DigdiratorSecretType
+}
+
+func (in IDPortenCredentials) DefaultPrefix() string {
+	return "IDPORTEN"
+}
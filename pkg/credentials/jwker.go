@@ -0,0 +1,22 @@
+package credentials
+
+import (
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+// TokenXCredentials are provisioned by Jwker for a Jwker resource.
+type TokenXCredentials struct {
+	ClientID     string `key:"CLIENT_ID"`
+	PrivateJWK   string `key:"PRIVATE_JWK"`
+	WellKnownURL string `key:"WELL_KNOWN_URL"`
+}
+
+var _ Credentials = TokenXCredentials{}
+
+func (in TokenXCredentials) SecretType() string {
+	return kubernetes.JwkerSecretType
+}
+
+func (in TokenXCredentials) DefaultPrefix() string {
+	return "TOKEN_X"
+}
@@ -0,0 +1,55 @@
+package credentials
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PrefixPattern matches exactly the prefixes accepted by ValidatePrefix, and is the OpenAPI pattern of the
+// secretKeyPrefix fields of credential CRDs: a valid environment variable name that does not end with `_`.
+const PrefixPattern = `^([A-Za-z]|[A-Za-z_][A-Za-z0-9_]*[A-Za-z0-9])$`
+
+// Prefix returns the key prefix to use for the given credentials:
+// the user-defined prefix if set, otherwise the default prefix.
+func Prefix(creds Credentials, prefix string) string {
+	if len(prefix) == 0 {
+		return creds.DefaultPrefix()
+	}
+	return prefix
+}
+
+// PrefixedKey joins a prefix and a key name with an underscore.
+func PrefixedKey(prefix, key string) string {
+	return prefix + "_" + key
+}
+
+// ValidatePrefix checks that every prefixed key of the given credentials is a valid environment variable name.
+// Keys that are never prefixed are not environment variables, and are not checked.
+func ValidatePrefix(creds Credentials, prefix string, path *field.Path) field.ErrorList {
+	if len(prefix) == 0 {
+		return nil
+	}
+	if strings.HasSuffix(prefix, "_") {
+		return field.ErrorList{field.Invalid(path, prefix, "must not end with `_`, as keys are separated from the prefix by an underscore")}
+	}
+
+	keys, _, err := keysFor(creds)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+
+	for _, k := range keys {
+		if k.noPrefix {
+			continue
+		}
+		name := k.key(prefix)
+		if msgs := validation.IsCIdentifier(name); len(msgs) > 0 {
+			return field.ErrorList{field.Invalid(path, prefix, fmt.Sprintf("resulting key %s is not a valid environment variable name: %s", name, strings.Join(msgs, "; ")))}
+		}
+	}
+
+	return nil
+}
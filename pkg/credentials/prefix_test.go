@@ -0,0 +1,46 @@
+package credentials_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/nais/liberator/pkg/credentials"
+)
+
+func TestPrefix(t *testing.T) {
+	assert.Equal(t, "AZURE", credentials.Prefix(credentials.AzureCredentials{}, ""))
+	assert.Equal(t, "MY_AZURE", credentials.Prefix(credentials.AzureCredentials{}, "MY_AZURE"))
+	assert.Equal(t, "MY_AZURE_APP_CLIENT_ID", credentials.PrefixedKey("MY_AZURE", "APP_CLIENT_ID"))
+}
+
+func TestValidatePrefix(t *testing.T) {
+	path := field.NewPath("spec", "secretKeyPrefix")
+
+	for _, valid := range []string{"", "KAFKA", "my_kafka", "_KAFKA"} {
+		assert.Empty(t, credentials.ValidatePrefix(credentials.KafkaCredentials{}, valid, path), valid)
+	}
+
+	for _, invalid := range []string{"MY-KAFKA", "MY.KAFKA", "9KAFKA", "KAFKA_", "MY KAFKA"} {
+		errs := credentials.ValidatePrefix(credentials.KafkaCredentials{}, invalid, path)
+		if assert.Len(t, errs, 1, invalid) {
+			assert.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+			assert.Equal(t, "spec.secretKeyPrefix", errs[0].Field)
+		}
+	}
+
+	t.Run("pattern accepts the same prefixes", func(t *testing.T) {
+		pattern := regexp.MustCompile(credentials.PrefixPattern)
+		for _, prefix := range []string{"A", "a", "_", "__", "_A", "_1", "A1", "1", "1A", "A_", "A_B", "A__B", "Æ", "A-B", "A B", "MY_KAFKA"} {
+			valid := len(credentials.ValidatePrefix(credentials.KafkaCredentials{}, prefix, path)) == 0
+			assert.Equal(t, valid, pattern.MatchString(prefix), prefix)
+		}
+	})
+
+	t.Run("invalid prefix is rejected when building secrets", func(t *testing.T) {
+		_, err := credentials.Secret(azureCredentials(), metadata(), "MY-AZURE")
+		assert.Error(t, err)
+	})
+}
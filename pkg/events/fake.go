@@ -0,0 +1,107 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// FakeRecorder keeps recorded events in memory, for use in tests.
+//
+// Events are aggregated the same way as with Recorder, so recording an identical event twice
+// increments the count of the first one.
+type FakeRecorder struct {
+	// Used to resolve references to objects not implementing ObjectReferencer.
+	// Recording an event panics if the reference cannot be resolved.
+	Scheme *runtime.Scheme
+
+	lock   sync.Mutex
+	events []corev1.Event
+}
+
+var _ record.EventRecorder = &FakeRecorder{}
+
+func NewFakeRecorder(scheme *runtime.Scheme) *FakeRecorder {
+	return &FakeRecorder{
+		Scheme: scheme,
+	}
+}
+
+func (r *FakeRecorder) Event(object runtime.Object, eventType, reason, message string) {
+	r.record(object, nil, eventType, reason, message)
+}
+
+func (r *FakeRecorder) Eventf(object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	r.record(object, nil, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *FakeRecorder) PastEventf(object runtime.Object, timestamp metav1.Time, eventType, reason, messageFmt string, args ...interface{}) {
+	r.record(object, nil, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *FakeRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventType, reason, messageFmt string, args ...interface{}) {
+	r.record(object, annotations, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// Events returns a copy of all recorded events, in the order they were first recorded.
+func (r *FakeRecorder) Events() []corev1.Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	events := make([]corev1.Event, len(r.events))
+	for i := range r.events {
+		r.events[i].DeepCopyInto(&events[i])
+	}
+	return events
+}
+
+// Reasons returns the reason of every recorded event, in the order they were first recorded.
+func (r *FakeRecorder) Reasons() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reasons := make([]string, len(r.events))
+	for i := range r.events {
+		reasons[i] = r.events[i].Reason
+	}
+	return reasons
+}
+
+func (r *FakeRecorder) record(object runtime.Object, annotations map[string]string, eventType, reason, message string) {
+	ref, err := ObjectReference(r.Scheme, object)
+	if err != nil {
+		panic(err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := metav1.Now()
+	ref = dedupReference(ref)
+	for i := range r.events {
+		event := &r.events[i]
+		if event.InvolvedObject == ref && event.Type == eventType && event.Reason == reason && event.Message == message {
+			event.Count++
+			event.LastTimestamp = now
+			return
+		}
+	}
+
+	r.events = append(r.events, corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   ref.Namespace,
+			Annotations: CorrelationAnnotations(object, annotations),
+		},
+		InvolvedObject: ref,
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Count:          1,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+	})
+}
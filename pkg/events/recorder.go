@@ -0,0 +1,274 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/reference"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// Identical events recorded within this duration of each other are aggregated into a single Event resource.
+const DefaultAggregationWindow = 10 * time.Minute
+
+// Maximum duration of the API calls made to record a single event.
+const DefaultTimeout = 10 * time.Second
+
+// ObjectReferencer is implemented by liberator resources that know how to reference themselves.
+type ObjectReferencer interface {
+	GetObjectReference() corev1.ObjectReference
+}
+
+// Recorder creates Kubernetes Event resources for any liberator object.
+//
+// Repeated events with the same object, type, reason and message are aggregated by incrementing
+// the count of the existing event instead of creating a new one. If the object carries a deployment
+// correlation ID annotation, it is copied to the event.
+//
+// Recorder implements record.EventRecorder, and can be used wherever a controller-runtime recorder is expected.
+type Recorder struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	// Reported as the source component and reporting controller of all events.
+	Component string
+	// Events are only aggregated if the previous occurrence is within this window.
+	// Defaults to DefaultAggregationWindow.
+	AggregationWindow time.Duration
+	// Maximum duration of the API calls made to record a single event.
+	// Defaults to DefaultTimeout.
+	Timeout time.Duration
+	Logger  log.FieldLogger
+
+	// Guards recent only; API calls are made without holding the lock.
+	lock   sync.Mutex
+	recent map[eventKey]*corev1.Event
+}
+
+type eventKey struct {
+	object    corev1.ObjectReference
+	eventType string
+	reason    string
+	message   string
+}
+
+var _ record.EventRecorder = &Recorder{}
+
+func NewRecorder(cli client.Client, scheme *runtime.Scheme, component string) *Recorder {
+	return &Recorder{
+		Client:    cli,
+		Scheme:    scheme,
+		Component: component,
+	}
+}
+
+func (r *Recorder) Event(object runtime.Object, eventType, reason, message string) {
+	r.record(object, nil, eventType, reason, message)
+}
+
+func (r *Recorder) Eventf(object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	r.record(object, nil, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// PastEventf records an event. The timestamp is ignored; the current time is used.
+func (r *Recorder) PastEventf(object runtime.Object, timestamp metav1.Time, eventType, reason, messageFmt string, args ...interface{}) {
+	r.record(object, nil, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventType, reason, messageFmt string, args ...interface{}) {
+	r.record(object, annotations, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) record(object runtime.Object, annotations map[string]string, eventType, reason, message string) {
+	logger := r.logger().WithFields(log.Fields{
+		"reason":  reason,
+		"message": message,
+	})
+
+	ref, err := ObjectReference(r.Scheme, object)
+	if err != nil {
+		logger.Errorf("Unable to record event: %s", err)
+		return
+	}
+
+	err = r.create(ref, CorrelationAnnotations(object, annotations), eventType, reason, message)
+	if err != nil {
+		logger.Errorf("Unable to record event for %s %s/%s: %s", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+}
+
+func (r *Recorder) create(ref corev1.ObjectReference, annotations map[string]string, eventType, reason, message string) error {
+	now := time.Now()
+	key := eventKey{
+		object:    dedupReference(ref),
+		eventType: eventType,
+		reason:    reason,
+		message:   message,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout())
+	defer cancel()
+
+	if previous := r.previous(key, now); previous != nil {
+		event := previous.DeepCopy()
+		event.Count++
+		event.LastTimestamp = metav1.NewTime(now)
+		event.Annotations = annotations
+		err := r.Client.Update(ctx, event)
+		if err == nil {
+			r.remember(key, event)
+			return nil
+		}
+		// The event was deleted, or updated concurrently. Record a new event instead of losing this one.
+		if !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			return err
+		}
+		r.forget(key, previous)
+	}
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s.%x", ref.Name, now.UnixNano()),
+			Namespace:   ref.Namespace,
+			Annotations: annotations,
+		},
+		InvolvedObject:      ref,
+		Reason:              reason,
+		Message:             message,
+		Type:                eventType,
+		Count:               1,
+		FirstTimestamp:      metav1.NewTime(now),
+		LastTimestamp:       metav1.NewTime(now),
+		Source:              corev1.EventSource{Component: r.Component},
+		ReportingController: r.Component,
+		ReportingInstance:   r.Component,
+		Action:              reason,
+	}
+	if len(event.Namespace) == 0 {
+		event.Namespace = metav1.NamespaceDefault
+	}
+
+	err := r.Client.Create(ctx, event)
+	if err != nil {
+		return err
+	}
+	r.remember(key, event)
+
+	return nil
+}
+
+// Returns the most recent occurrence of an event that can still be aggregated, or nil.
+func (r *Recorder) previous(key eventKey, now time.Time) *corev1.Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.expire(now)
+	return r.recent[key]
+}
+
+// Stores the event as the most recent occurrence, unless a later occurrence has been stored concurrently.
+func (r *Recorder) remember(key eventKey, event *corev1.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.recent == nil {
+		r.recent = make(map[eventKey]*corev1.Event)
+	}
+	if existing, ok := r.recent[key]; ok && existing.LastTimestamp.After(event.LastTimestamp.Time) {
+		return
+	}
+	r.recent[key] = event
+}
+
+// Forgets the given occurrence of an event, unless it has been replaced concurrently.
+func (r *Recorder) forget(key eventKey, event *corev1.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.recent[key] == event {
+		delete(r.recent, key)
+	}
+}
+
+// Forget events that can no longer be aggregated, so that the cache does not grow indefinitely.
+// Must be called with the lock held.
+func (r *Recorder) expire(now time.Time) {
+	window := r.AggregationWindow
+	if window == 0 {
+		window = DefaultAggregationWindow
+	}
+	for key, event := range r.recent {
+		if now.Sub(event.LastTimestamp.Time) > window {
+			delete(r.recent, key)
+		}
+	}
+}
+
+func (r *Recorder) timeout() time.Duration {
+	if r.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return r.Timeout
+}
+
+func (r *Recorder) logger() log.FieldLogger {
+	if r.Logger == nil {
+		return log.StandardLogger()
+	}
+	return r.Logger
+}
+
+// ObjectReference returns a reference to object, to be used as the involved object of an event.
+// Objects implementing ObjectReferencer reference themselves; for all others, the reference is
+// resolved using the scheme.
+func ObjectReference(scheme *runtime.Scheme, object runtime.Object) (corev1.ObjectReference, error) {
+	if referencer, ok := object.(ObjectReferencer); ok {
+		return referencer.GetObjectReference(), nil
+	}
+	if scheme == nil {
+		return corev1.ObjectReference{}, fmt.Errorf("no scheme to resolve reference to %T", object)
+	}
+	ref, err := reference.GetReference(scheme, object)
+	if err != nil {
+		return corev1.ObjectReference{}, fmt.Errorf("resolve reference: %w", err)
+	}
+	return *ref, nil
+}
+
+// CorrelationAnnotations returns the given annotations, with the deployment correlation ID
+// of the object added if present.
+func CorrelationAnnotations(object runtime.Object, annotations map[string]string) map[string]string {
+	result := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		result[k] = v
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err == nil {
+		correlationID := accessor.GetAnnotations()[nais_io_v1.DeploymentCorrelationIDAnnotation]
+		if len(correlationID) > 0 {
+			result[nais_io_v1.DeploymentCorrelationIDAnnotation] = correlationID
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// Events are aggregated across resource versions of the involved object.
+func dedupReference(ref corev1.ObjectReference) corev1.ObjectReference {
+	ref.ResourceVersion = ""
+	return ref
+}
@@ -0,0 +1,165 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/events"
+	"github.com/nais/liberator/pkg/scheme"
+)
+
+func application() *nais_io_v1alpha1.Application {
+	return &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "myapp",
+			Namespace:       "myteam",
+			UID:             "123",
+			ResourceVersion: "1",
+			Annotations: map[string]string{
+				nais_io_v1.DeploymentCorrelationIDAnnotation: "correlation-id",
+			},
+		},
+	}
+}
+
+func listEvents(t *testing.T, cli client.Client) []corev1.Event {
+	list := &corev1.EventList{}
+	require.NoError(t, cli.List(context.Background(), list))
+	return list.Items
+}
+
+func TestRecorder(t *testing.T) {
+	sch, err := scheme.All()
+	require.NoError(t, err)
+	cli := fake.NewFakeClientWithScheme(sch)
+	recorder := events.NewRecorder(cli, sch, "naiserator")
+
+	app := application()
+	recorder.Eventf(app, corev1.EventTypeWarning, events.FailedSynchronization, "failed: %s", "error")
+
+	list := listEvents(t, cli)
+	require.Len(t, list, 1)
+	event := list[0]
+	assert.Equal(t, "myteam", event.Namespace)
+	assert.Equal(t, app.GetObjectReference(), event.InvolvedObject)
+	assert.Equal(t, corev1.EventTypeWarning, event.Type)
+	assert.Equal(t, events.FailedSynchronization, event.Reason)
+	assert.Equal(t, "failed: error", event.Message)
+	assert.Equal(t, int32(1), event.Count)
+	assert.Equal(t, "naiserator", event.Source.Component)
+	assert.Equal(t, "correlation-id", event.Annotations[nais_io_v1.DeploymentCorrelationIDAnnotation])
+
+	t.Run("repeated events are aggregated", func(t *testing.T) {
+		app.ResourceVersion = "2"
+		recorder.Eventf(app, corev1.EventTypeWarning, events.FailedSynchronization, "failed: %s", "error")
+		list := listEvents(t, cli)
+		require.Len(t, list, 1)
+		assert.Equal(t, int32(2), list[0].Count)
+		assert.False(t, list[0].LastTimestamp.Before(&list[0].FirstTimestamp))
+	})
+
+	t.Run("different events are not aggregated", func(t *testing.T) {
+		recorder.Event(app, corev1.EventTypeNormal, events.Synchronized, "ok")
+		assert.Len(t, listEvents(t, cli), 2)
+	})
+
+	t.Run("events outside the aggregation window are not aggregated", func(t *testing.T) {
+		recorder.AggregationWindow = time.Nanosecond
+		time.Sleep(time.Millisecond)
+		recorder.Event(app, corev1.EventTypeNormal, events.Synchronized, "ok")
+		assert.Len(t, listEvents(t, cli), 3)
+	})
+
+	t.Run("native objects are referenced using the scheme", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mypod",
+				Namespace: "myteam",
+			},
+		}
+		recorder.AnnotatedEventf(pod, map[string]string{"foo": "bar"}, corev1.EventTypeNormal, events.RolloutComplete, "done")
+		list := listEvents(t, cli)
+		require.Len(t, list, 4)
+		var event corev1.Event
+		for _, e := range list {
+			if e.InvolvedObject.Name == "mypod" {
+				event = e
+			}
+		}
+		assert.Equal(t, "Pod", event.InvolvedObject.Kind)
+		assert.Equal(t, "v1", event.InvolvedObject.APIVersion)
+		assert.Equal(t, map[string]string{"foo": "bar"}, event.Annotations)
+	})
+}
+
+// Blocks event creation for the involved object named "slow" until released.
+type blockingClient struct {
+	client.Client
+	release   chan struct{}
+	deadlines chan bool
+}
+
+func (c *blockingClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	_, hasDeadline := ctx.Deadline()
+	c.deadlines <- hasDeadline
+	if obj.(*corev1.Event).InvolvedObject.Name == "slow" {
+		<-c.release
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func TestRecorder_Concurrency(t *testing.T) {
+	sch, err := scheme.All()
+	require.NoError(t, err)
+	cli := &blockingClient{
+		Client:    fake.NewFakeClientWithScheme(sch),
+		release:   make(chan struct{}),
+		deadlines: make(chan bool, 2),
+	}
+	recorder := events.NewRecorder(cli, sch, "naiserator")
+
+	slow := application()
+	slow.Name = "slow"
+	done := make(chan struct{})
+	go func() {
+		recorder.Event(slow, corev1.EventTypeNormal, events.Synchronized, "ok")
+		close(done)
+	}()
+	assert.True(t, <-cli.deadlines, "API calls are made with a timeout")
+
+	recorder.Event(application(), corev1.EventTypeNormal, events.Synchronized, "ok")
+	assert.True(t, <-cli.deadlines)
+	assert.Len(t, listEvents(t, cli), 1, "events are recorded while another API call is in progress")
+
+	close(cli.release)
+	<-done
+	assert.Len(t, listEvents(t, cli), 2)
+}
+
+func TestFakeRecorder(t *testing.T) {
+	recorder := events.NewFakeRecorder(nil)
+	app := application()
+
+	recorder.Event(app, corev1.EventTypeWarning, events.FailedPrepare, "prepare failed")
+	recorder.Event(app, corev1.EventTypeWarning, events.FailedPrepare, "prepare failed")
+	recorder.Eventf(app, corev1.EventTypeNormal, events.RolloutComplete, "rollout %s", "complete")
+
+	assert.Equal(t, []string{events.FailedPrepare, events.RolloutComplete}, recorder.Reasons())
+
+	recorded := recorder.Events()
+	require.Len(t, recorded, 2)
+	assert.Equal(t, int32(2), recorded[0].Count)
+	assert.Equal(t, "rollout complete", recorded[1].Message)
+	assert.Equal(t, "correlation-id", recorded[1].Annotations[nais_io_v1.DeploymentCorrelationIDAnnotation])
+}
@@ -0,0 +1,147 @@
+package finalizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nais/liberator/pkg/strings"
+)
+
+// Object is a Kubernetes resource that can carry finalizers.
+type Object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// Cleanup releases external resources held by a resource that is being deleted.
+type Cleanup func(ctx context.Context) error
+
+type finalizerPatch struct {
+	Metadata finalizerPatchMetadata `json:"metadata"`
+}
+
+type finalizerPatchMetadata struct {
+	Finalizers      []string `json:"finalizers"`
+	ResourceVersion string   `json:"resourceVersion"`
+}
+
+// Ensure adds the named finalizer to obj, unless already present.
+//
+// The finalizer list is updated using a merge patch that is conditional on the resource version of obj.
+// If the object was changed concurrently, it is reloaded and the patch is retried.
+// On success, obj reflects the state of the object in the cluster.
+func Ensure(ctx context.Context, cli client.Client, obj Object, name string) error {
+	return patchFinalizers(ctx, cli, obj, func(finalizers []string) ([]string, bool) {
+		if strings.ContainsString(finalizers, name) {
+			return finalizers, false
+		}
+		return append(finalizers, name), true
+	})
+}
+
+// Remove removes the named finalizer from obj, if present.
+// Conflicts are handled the same way as in Ensure.
+func Remove(ctx context.Context, cli client.Client, obj Object, name string) error {
+	return patchFinalizers(ctx, cli, obj, func(finalizers []string) ([]string, bool) {
+		if !strings.ContainsString(finalizers, name) {
+			return finalizers, false
+		}
+		return strings.RemoveString(finalizers, name), true
+	})
+}
+
+// Finalize runs cleanup for a resource that is being deleted, and removes the named finalizer once cleanup succeeds.
+// Cleanup is cancelled if it does not complete within the given timeout. Finalize returns once the timeout
+// expires, even if cleanup ignores the cancellation and keeps running in the background.
+// Only the error returned by cleanup counts as a failure, so cleanup that completes right at the deadline succeeds.
+//
+// Nothing is done if the resource is not being deleted, or if it does not carry the finalizer.
+// Returns true if the finalizer was removed.
+func Finalize(ctx context.Context, cli client.Client, obj Object, name string, timeout time.Duration, cleanup Cleanup) (bool, error) {
+	if !IsBeingDeleted(obj) || !HasFinalizer(obj, name) {
+		return false, nil
+	}
+
+	cleanupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- cleanup(cleanupCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-cleanupCtx.Done():
+		select {
+		case err = <-result:
+		default:
+			err = fmt.Errorf("did not complete within %s: %w", timeout, cleanupCtx.Err())
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("cleanup: %w", err)
+	}
+
+	err = Remove(ctx, cli, obj, name)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func patchFinalizers(ctx context.Context, cli client.Client, obj Object, mutate func([]string) ([]string, bool)) error {
+	key := types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+	attempt := 0
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		attempt++
+		if attempt > 1 {
+			err := cli.Get(ctx, key, obj)
+			if err != nil {
+				return err
+			}
+		}
+
+		finalizers, changed := mutate(append([]string{}, obj.GetFinalizers()...))
+		if !changed {
+			return nil
+		}
+
+		patch := finalizerPatch{
+			Metadata: finalizerPatchMetadata{
+				Finalizers:      finalizers,
+				ResourceVersion: obj.GetResourceVersion(),
+			},
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return err
+		}
+
+		return cli.Patch(ctx, obj, client.RawPatch(types.MergePatchType, data))
+	})
+
+	if err != nil {
+		if errors.IsConflict(err) {
+			return fmt.Errorf("update finalizers: giving up after %d attempts: %w", attempt, err)
+		}
+		return fmt.Errorf("update finalizers: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package finalizer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nais/liberator/pkg/finalizer"
+)
+
+const finalizerName = "example.nais.io"
+
+func configMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-configmap",
+			Namespace:  "test-namespace",
+			Finalizers: []string{"other"},
+		},
+	}
+}
+
+func newClient(t *testing.T, obj *corev1.ConfigMap) (client.Client, *corev1.ConfigMap) {
+	cli := fake.NewFakeClientWithScheme(scheme.Scheme, obj)
+	actual := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: obj.Namespace, Name: obj.Name}, actual))
+	return cli, actual
+}
+
+func get(t *testing.T, cli client.Client, obj *corev1.ConfigMap) *corev1.ConfigMap {
+	actual := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: obj.Namespace, Name: obj.Name}, actual))
+	return actual
+}
+
+func TestEnsure(t *testing.T) {
+	ctx := context.Background()
+	cli, obj := newClient(t, configMap())
+
+	err := finalizer.Ensure(ctx, cli, obj, finalizerName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other", finalizerName}, obj.Finalizers)
+	assert.Equal(t, []string{"other", finalizerName}, get(t, cli, obj).Finalizers)
+
+	t.Run("existing finalizer is not added twice", func(t *testing.T) {
+		resourceVersion := obj.ResourceVersion
+		err := finalizer.Ensure(ctx, cli, obj, finalizerName)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other", finalizerName}, get(t, cli, obj).Finalizers)
+		assert.Equal(t, resourceVersion, get(t, cli, obj).ResourceVersion)
+	})
+}
+
+func TestEnsureConflict(t *testing.T) {
+	ctx := context.Background()
+	cli, obj := newClient(t, configMap())
+	stale := obj.DeepCopy()
+
+	obj.Data = map[string]string{"foo": "bar"}
+	require.NoError(t, cli.Update(ctx, obj))
+
+	err := finalizer.Ensure(ctx, cli, stale, finalizerName)
+	require.NoError(t, err)
+
+	actual := get(t, cli, obj)
+	assert.Equal(t, []string{"other", finalizerName}, actual.Finalizers)
+	assert.Equal(t, "bar", actual.Data["foo"], "concurrent changes are preserved")
+	assert.Equal(t, actual.ResourceVersion, stale.ResourceVersion, "stale object is refreshed")
+}
+
+func TestRemove(t *testing.T) {
+	ctx := context.Background()
+	cm := configMap()
+	cm.Finalizers = []string{finalizerName, "other"}
+	cli, obj := newClient(t, cm)
+
+	err := finalizer.Remove(ctx, cli, obj, finalizerName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other"}, obj.Finalizers)
+	assert.Equal(t, []string{"other"}, get(t, cli, obj).Finalizers)
+
+	err = finalizer.Remove(ctx, cli, obj, "other")
+	require.NoError(t, err)
+	assert.Empty(t, get(t, cli, obj).Finalizers)
+}
+
+func TestFinalize(t *testing.T) {
+	ctx := context.Background()
+	deleted := func() *corev1.ConfigMap {
+		cm := configMap()
+		cm.Finalizers = []string{finalizerName}
+		cm.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return cm
+	}
+
+	t.Run("finalizer is removed after successful cleanup", func(t *testing.T) {
+		cli, obj := newClient(t, deleted())
+		called := false
+		removed, err := finalizer.Finalize(ctx, cli, obj, finalizerName, time.Second, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, called)
+		assert.True(t, removed)
+		assert.Empty(t, get(t, cli, obj).Finalizers)
+	})
+
+	t.Run("finalizer is kept when cleanup fails", func(t *testing.T) {
+		cli, obj := newClient(t, deleted())
+		removed, err := finalizer.Finalize(ctx, cli, obj, finalizerName, time.Second, func(ctx context.Context) error {
+			return fmt.Errorf("external system unavailable")
+		})
+		assert.EqualError(t, err, "cleanup: external system unavailable")
+		assert.False(t, removed)
+		assert.Equal(t, []string{finalizerName}, get(t, cli, obj).Finalizers)
+	})
+
+	t.Run("finalizer is kept when cleanup times out", func(t *testing.T) {
+		cli, obj := newClient(t, deleted())
+		removed, err := finalizer.Finalize(ctx, cli, obj, finalizerName, time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.False(t, removed)
+		assert.Equal(t, []string{finalizerName}, get(t, cli, obj).Finalizers)
+	})
+
+	t.Run("timeout is enforced when cleanup ignores cancellation", func(t *testing.T) {
+		cli, obj := newClient(t, deleted())
+		release := make(chan struct{})
+		defer close(release)
+		removed, err := finalizer.Finalize(ctx, cli, obj, finalizerName, time.Millisecond, func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+		assert.EqualError(t, err, "cleanup: did not complete within 1ms: context deadline exceeded")
+		assert.False(t, removed)
+		assert.Equal(t, []string{finalizerName}, get(t, cli, obj).Finalizers)
+	})
+
+	t.Run("cleanup is not run for resources that are not being deleted", func(t *testing.T) {
+		cm := configMap()
+		cm.Finalizers = []string{finalizerName}
+		cli, obj := newClient(t, cm)
+		removed, err := finalizer.Finalize(ctx, cli, obj, finalizerName, time.Second, func(ctx context.Context) error {
+			t.Fatal("cleanup called")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, removed)
+	})
+}
@@ -0,0 +1,280 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Algorithm identifies the hash function used to produce a digest.
+type Algorithm string
+
+const (
+	SHA256 Algorithm = "sha256"
+
+	// Algorithm used by Digest.
+	DefaultAlgorithm = SHA256
+
+	// Struct tag used to exclude a field from the digest, e.g. `hash:"-"`.
+	StructTag = "hash"
+)
+
+// LegacyFunc computes a hash using one of the formats predating Digest, such as Hash.
+type LegacyFunc func() (string, error)
+
+// Digest returns a digest of the canonical representation of input, prefixed with the algorithm, e.g. `sha256:8ad7...`.
+//
+// The canonical representation is the JSON encoding of input with map keys sorted and with all
+// zero-valued fields left out, so that adding a new optional field does not change existing digests.
+// Non-nil pointers are kept even if they point to a zero value, so that an explicit `false` or `0` differs from an unset field.
+// Struct fields are named after their JSON name. Fields tagged with `hash:"-"` or `json:"-"` are excluded,
+// as are unexported fields, including embedded structs of unexported types.
+func Digest(input interface{}) (string, error) {
+	canonical, err := Canonical(input)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return string(DefaultAlgorithm) + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+// Canonical returns the canonical representation of input that is hashed by Digest.
+func Canonical(input interface{}) ([]byte, error) {
+	value, err := canonicalize(reflect.ValueOf(input))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize: %w", err)
+	}
+	return json.Marshal(value)
+}
+
+// ParseDigest splits a digest into its algorithm and hex encoded sum.
+// An error is returned if the algorithm is unknown or the sum is malformed.
+// Hashes without an algorithm prefix are legacy hashes, see IsLegacy.
+func ParseDigest(digest string) (Algorithm, string, error) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("digest %q has no algorithm prefix", digest)
+	}
+	algorithm := Algorithm(parts[0])
+	if algorithm != SHA256 {
+		return "", "", fmt.Errorf("unsupported hash algorithm %q", algorithm)
+	}
+	sum, err := hex.DecodeString(parts[1])
+	if err != nil || len(sum) != sha256.Size {
+		return "", "", fmt.Errorf("malformed %s digest %q", algorithm, digest)
+	}
+	return algorithm, parts[1], nil
+}
+
+// IsLegacy returns true if hash was produced by one of the hash formats predating Digest.
+func IsLegacy(hash string) bool {
+	return len(hash) > 0 && !strings.Contains(hash, ":")
+}
+
+// Verify checks whether a stored hash matches the current input.
+//
+// Digests are compared against Digest(input). Legacy hashes are compared against the result of each
+// legacy function in turn, so that resources hashed before migrating to Digest are not considered changed.
+// Callers should store the output of Digest once a legacy hash has been verified.
+func Verify(stored string, input interface{}, legacy ...LegacyFunc) (bool, error) {
+	if len(stored) == 0 {
+		return false, nil
+	}
+
+	if IsLegacy(stored) {
+		for _, fn := range legacy {
+			h, err := fn()
+			if err != nil {
+				return false, fmt.Errorf("legacy hash: %w", err)
+			}
+			if h == stored {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	_, _, err := ParseDigest(stored)
+	if err != nil {
+		return false, err
+	}
+	digest, err := Digest(input)
+	if err != nil {
+		return false, err
+	}
+	return digest == stored, nil
+}
+
+var jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Convert a value into a tree of maps, slices and primitives that encodes canonically using encoding/json.
+// A nil return value means that the value is empty and should be left out.
+func canonicalize(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if v.Type().Implements(jsonMarshaler) && !(v.Kind() == reflect.Ptr && v.IsNil()) {
+		return canonicalizeMarshaler(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		value, err := canonicalize(v.Elem())
+		if value != nil || err != nil {
+			return value, err
+		}
+		return zeroValue(v.Elem()), nil
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return canonicalize(v.Elem())
+
+	case reflect.Struct:
+		if reflect.PtrTo(v.Type()).Implements(jsonMarshaler) {
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			return canonicalizeMarshaler(ptr)
+		}
+		fields := make(map[string]interface{})
+		err := canonicalizeStruct(v, fields)
+		if err != nil || len(fields) == 0 {
+			return nil, err
+		}
+		return fields, nil
+
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		entries := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := canonicalize(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				entries[fmt.Sprint(iter.Key().Interface())] = value
+			}
+		}
+		return entries, nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := canonicalize(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return nil, fmt.Errorf("cannot hash value of type %s", v.Type())
+	}
+
+	if v.IsZero() {
+		return nil, nil
+	}
+	return v.Interface(), nil
+}
+
+// Returns the canonical representation of a zero value pointed to by a non-nil pointer.
+func zeroValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		return map[string]interface{}{}
+	case reflect.Slice, reflect.Array:
+		return []interface{}{}
+	case reflect.Ptr, reflect.Interface:
+		return nil
+	}
+	return v.Interface()
+}
+
+func canonicalizeStruct(v reflect.Value, fields map[string]interface{}) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+		if field.Tag.Get(StructTag) == "-" {
+			continue
+		}
+
+		name, inline := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if inline {
+			for fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					break
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				err := canonicalizeStruct(fieldValue, fields)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			name = field.Name
+		}
+
+		value, err := canonicalize(fieldValue)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+		if value != nil {
+			fields[name] = value
+		}
+	}
+	return nil
+}
+
+func canonicalizeMarshaler(v reflect.Value) (interface{}, error) {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalize(reflect.ValueOf(value))
+}
+
+// Returns the JSON name of a struct field, and whether its fields should be inlined into the parent.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	name := strings.Split(tag, ",")[0]
+	if name == "-" && tag == "-" {
+		return name, false
+	}
+	if len(name) > 0 {
+		return name, false
+	}
+	if field.Anonymous || strings.Contains(tag, ",inline") {
+		return "", true
+	}
+	return field.Name, false
+}
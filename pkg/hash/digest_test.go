@@ -0,0 +1,164 @@
+package hash_test
+
+import (
+	"testing"
+
+	"github.com/nais/liberator/pkg/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Spec struct {
+	Name     string            `json:"name"`
+	Replicas *int              `json:"replicas,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Memory   resource.Quantity `json:"memory"`
+	Ignored  string            `json:"ignored" hash:"-"`
+	Omitted  string            `json:"-"`
+	Embedded
+}
+
+type Embedded struct {
+	Inline string `json:"inline"`
+}
+
+type digestStructV2 struct {
+	Spec
+	NewField *string `json:"newField,omitempty"`
+}
+
+func TestCanonical(t *testing.T) {
+	replicas := 2
+	input := Spec{
+		Name:     "foo",
+		Replicas: &replicas,
+		Labels:   map[string]string{"b": "2", "a": "1"},
+		Memory:   resource.MustParse("512Mi"),
+		Ignored:  "ignored",
+		Omitted:  "omitted",
+		Embedded: Embedded{Inline: "inline"},
+	}
+
+	canonical, err := hash.Canonical(input)
+	require.NoError(t, err)
+	assert.Equal(t, `{"inline":"inline","labels":{"a":"1","b":"2"},"memory":"512Mi","name":"foo","replicas":2}`, string(canonical))
+
+	zero := 0
+	canonical, err = hash.Canonical(Spec{Name: "foo", Replicas: &zero})
+	require.NoError(t, err)
+	assert.Equal(t, `{"memory":"0","name":"foo","replicas":0}`, string(canonical))
+}
+
+func TestDigest(t *testing.T) {
+	input := Spec{Name: "foo"}
+
+	digest, err := hash.Digest(input)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:409f6eee96db8c863dbbdd9e44b9d73179f31045255d17c60a817a024ba413f0", digest)
+
+	t.Run("excluded fields do not change the digest", func(t *testing.T) {
+		other := input
+		other.Ignored = "changed"
+		other.Omitted = "changed"
+		otherDigest, err := hash.Digest(other)
+		require.NoError(t, err)
+		assert.Equal(t, digest, otherDigest)
+	})
+
+	t.Run("new empty fields do not change the digest", func(t *testing.T) {
+		newDigest, err := hash.Digest(digestStructV2{Spec: input})
+		require.NoError(t, err)
+		assert.Equal(t, digest, newDigest)
+	})
+
+	t.Run("nil and empty values are equivalent", func(t *testing.T) {
+		other := input
+		other.Labels = map[string]string{}
+		otherDigest, err := hash.Digest(other)
+		require.NoError(t, err)
+		assert.Equal(t, digest, otherDigest)
+	})
+
+	t.Run("explicit zero values behind pointers change the digest", func(t *testing.T) {
+		zero := 0
+		other := input
+		other.Replicas = &zero
+		zeroDigest, err := hash.Digest(other)
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, zeroDigest)
+
+		disabled := false
+		flagDigest, err := hash.Digest(struct {
+			Spec
+			Enabled *bool `json:"enabled,omitempty"`
+		}{Spec: input, Enabled: &disabled})
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, flagDigest)
+		assert.NotEqual(t, zeroDigest, flagDigest)
+	})
+
+	t.Run("changed fields change the digest", func(t *testing.T) {
+		other := input
+		other.Name = "bar"
+		otherDigest, err := hash.Digest(other)
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, otherDigest)
+	})
+
+	t.Run("values implementing json.Marshaler are hashed by their JSON representation", func(t *testing.T) {
+		a, err := hash.Digest(metav1.Duration{})
+		require.NoError(t, err)
+		b, err := hash.Digest(&metav1.Duration{})
+		require.NoError(t, err)
+		assert.Equal(t, a, b)
+	})
+}
+
+func TestParseDigest(t *testing.T) {
+	algorithm, sum, err := hash.ParseDigest("sha256:409f6eee96db8c863dbbdd9e44b9d73179f31045255d17c60a817a024ba413f0")
+	require.NoError(t, err)
+	assert.Equal(t, hash.SHA256, algorithm)
+	assert.Equal(t, "409f6eee96db8c863dbbdd9e44b9d73179f31045255d17c60a817a024ba413f0", sum)
+
+	for _, invalid := range []string{"8a26a8b71bf71ecb", "md5:d41d8cd98f00b204e9800998ecf8427e", "sha256:abc", "sha256:xyz"} {
+		_, _, err := hash.ParseDigest(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	input := Spec{Name: "foo"}
+	digest, err := hash.Digest(input)
+	require.NoError(t, err)
+	legacy := func() (string, error) {
+		return hash.Hash(input)
+	}
+	legacyHash, err := legacy()
+	require.NoError(t, err)
+
+	assert.True(t, hash.IsLegacy(legacyHash))
+	assert.False(t, hash.IsLegacy(digest))
+
+	for _, tt := range []struct {
+		name     string
+		stored   string
+		expected bool
+	}{
+		{"digest", digest, true},
+		{"legacy hash", legacyHash, true},
+		{"other legacy hash", "8a26a8b71bf71ecb", false},
+		{"other digest", "sha256:bd32a8e5f3bc65d2f5b1a7b5fae3a0ddc1a1c6f35b27e3eb24a04fa1b07b5aaf", false},
+		{"empty", "", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := hash.Verify(tt.stored, input, legacy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+
+	_, err = hash.Verify("md5:d41d8cd98f00b204e9800998ecf8427e", input, legacy)
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Labels used by NAIS and Kubernetes to associate pods with the workload they belong to.
+const (
+	AppLabelKey     = "app"
+	AppNameLabelKey = "app.kubernetes.io/name"
+	JobNameLabelKey = "job-name"
+
+	// Number of objects requested per page when listing, unless configured otherwise.
+	DefaultPageSize = 500
+)
+
+// LabelScheme returns the labels carried by the pods of an owner object.
+type LabelScheme func(owner metav1.Object) map[string]string
+
+// NameLabel returns a label scheme where pods are labeled with the name of their owner under the given key.
+func NameLabel(key string) LabelScheme {
+	return func(owner metav1.Object) map[string]string {
+		return map[string]string{key: owner.GetName()}
+	}
+}
+
+var (
+	// Label schemes used by the pods of an Application.
+	ApplicationLabelSchemes = []LabelScheme{NameLabel(AppLabelKey), NameLabel(AppNameLabelKey)}
+	// Label schemes used by the pods of a Naisjob. Pods of jobs created by a cronjob are only found through owner references.
+	NaisjobLabelSchemes = []LabelScheme{NameLabel(AppLabelKey), NameLabel(JobNameLabelKey)}
+)
+
+// PodDiscovery finds the pods belonging to an owner object, such as an Application or a Naisjob.
+//
+// Pods are matched if they carry the labels of any of the label schemes, or, if OwnerReferences is set,
+// if they are owned by the owner object, either directly or through deployments, replicasets, cronjobs and jobs.
+// All objects are listed in pages of PageSize objects.
+type PodDiscovery struct {
+	Reader          client.Reader
+	LabelSchemes    []LabelScheme
+	OwnerReferences bool
+	// Defaults to DefaultPageSize.
+	PageSize int64
+}
+
+// Pods returns the pods belonging to owner, sorted by name.
+func (d *PodDiscovery) Pods(ctx context.Context, owner metav1.Object) ([]corev1.Pod, error) {
+	found := make(map[types.UID]corev1.Pod)
+	namespace := client.InNamespace(owner.GetNamespace())
+
+	for _, scheme := range d.LabelSchemes {
+		err := d.list(ctx, &corev1.PodList{}, func(list runtime.Object) {
+			for _, pod := range list.(*corev1.PodList).Items {
+				found[pod.UID] = pod
+			}
+		}, namespace, client.MatchingLabels(scheme(owner)))
+		if err != nil {
+			return nil, fmt.Errorf("listing pods: %w", err)
+		}
+	}
+
+	if d.OwnerReferences {
+		owned, err := d.ownedUIDs(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		err = d.list(ctx, &corev1.PodList{}, func(list runtime.Object) {
+			for _, pod := range list.(*corev1.PodList).Items {
+				if ownedBy(&pod, owned) {
+					found[pod.UID] = pod
+				}
+			}
+		}, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("listing pods: %w", err)
+		}
+	}
+
+	pods := make([]corev1.Pod, 0, len(found))
+	for _, pod := range found {
+		pods = append(pods, pod)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+// Returns the UIDs of owner and all workloads transitively owned by it.
+func (d *PodDiscovery) ownedUIDs(ctx context.Context, owner metav1.Object) (map[types.UID]bool, error) {
+	owned := map[types.UID]bool{owner.GetUID(): true}
+	namespace := client.InNamespace(owner.GetNamespace())
+
+	// Listed in ownership order, so that a single pass finds all transitively owned workloads.
+	lists := []runtime.Object{
+		&appsv1.DeploymentList{},
+		&appsv1.ReplicaSetList{},
+		&batchv1beta1.CronJobList{},
+		&batchv1.JobList{},
+	}
+	for _, list := range lists {
+		var inner error
+		err := d.list(ctx, list, func(page runtime.Object) {
+			inner = meta.EachListItem(page, func(obj runtime.Object) error {
+				accessor, err := meta.Accessor(obj)
+				if err != nil {
+					return err
+				}
+				if ownedBy(accessor, owned) {
+					owned[accessor.GetUID()] = true
+				}
+				return nil
+			})
+		}, namespace)
+		if err == nil {
+			err = inner
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing %T: %w", list, err)
+		}
+	}
+
+	return owned, nil
+}
+
+func ownedBy(obj metav1.Object, owners map[types.UID]bool) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if owners[ref.UID] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *PodDiscovery) list(ctx context.Context, list runtime.Object, fn func(page runtime.Object), opts ...client.ListOption) error {
+	pageSize := d.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return ListPages(ctx, d.Reader, list, pageSize, fn, opts...)
+}
+
+// ListPages lists objects in pages of at most pageSize objects, calling fn with each page.
+// The list object is reused between pages.
+func ListPages(ctx context.Context, reader client.Reader, list runtime.Object, pageSize int64, fn func(page runtime.Object), opts ...client.ListOption) error {
+	continueToken := ""
+	for {
+		pageOpts := append([]client.ListOption{client.Limit(pageSize), client.Continue(continueToken)}, opts...)
+		err := reader.List(ctx, list, pageOpts...)
+		if err != nil {
+			return err
+		}
+		fn(list)
+
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return err
+		}
+		continueToken = listMeta.GetContinue()
+		if len(continueToken) == 0 {
+			return nil
+		}
+	}
+}
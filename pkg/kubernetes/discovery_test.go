@@ -0,0 +1,152 @@
+package kubernetes_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+// pagingReader serves list requests in pages, as the fake client ignores the limit option.
+type pagingReader struct {
+	client.Reader
+	requests int
+}
+
+func (r *pagingReader) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	r.requests++
+	options := &client.ListOptions{}
+	options.ApplyOptions(opts)
+
+	err := r.Reader.List(ctx, list, opts...)
+	if err != nil || options.Limit == 0 {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	offset, _ := strconv.Atoi(options.Continue)
+	end := offset + int(options.Limit)
+	continueToken := strconv.Itoa(end)
+	if end >= len(items) {
+		end = len(items)
+		continueToken = ""
+	}
+	listMeta, _ := meta.ListAccessor(list)
+	listMeta.SetContinue(continueToken)
+	return meta.SetList(list, items[offset:end])
+}
+
+func ownerRef(kind, name string, uid types.UID) []metav1.OwnerReference {
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid}}
+}
+
+func pod(name string, labels map[string]string, owners []metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "myteam",
+			UID:             types.UID(name),
+			Labels:          labels,
+			OwnerReferences: owners,
+		},
+	}
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, len(pods))
+	for i := range pods {
+		names[i] = pods[i].Name
+	}
+	return names
+}
+
+func TestPodDiscovery(t *testing.T) {
+	owner := &metav1.ObjectMeta{Name: "myapp", Namespace: "myteam", UID: "owner"}
+
+	objects := []runtime.Object{
+		pod("app-label", map[string]string{"app": "myapp"}, nil),
+		pod("app-name-label", map[string]string{"app.kubernetes.io/name": "myapp"}, nil),
+		pod("other-app", map[string]string{"app": "otherapp"}, nil),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam", UID: "deployment", OwnerReferences: ownerRef("Application", "myapp", "owner")}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "myapp-abc", Namespace: "myteam", UID: "replicaset", OwnerReferences: ownerRef("Deployment", "myapp", "deployment")}},
+		pod("owned-by-replicaset", nil, ownerRef("ReplicaSet", "myapp-abc", "replicaset")),
+		&batchv1beta1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam", UID: "cronjob", OwnerReferences: ownerRef("Naisjob", "myapp", "owner")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "myapp-123", Namespace: "myteam", UID: "job", OwnerReferences: ownerRef("CronJob", "myapp", "cronjob")}},
+		pod("owned-by-job", map[string]string{"job-name": "myapp-123"}, ownerRef("Job", "myapp-123", "job")),
+		pod("direct-job", map[string]string{"job-name": "myapp"}, nil),
+	}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, pod("many-"+strconv.Itoa(i), map[string]string{"app": "myapp"}, nil))
+	}
+
+	reader := &pagingReader{Reader: fake.NewFakeClientWithScheme(scheme.Scheme, objects...)}
+
+	t.Run("application label schemes", func(t *testing.T) {
+		discovery := &kubernetes.PodDiscovery{
+			Reader:       reader,
+			LabelSchemes: kubernetes.ApplicationLabelSchemes,
+			PageSize:     2,
+		}
+		reader.requests = 0
+		pods, err := discovery.Pods(context.Background(), owner)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app-label", "app-name-label", "many-0", "many-1", "many-2", "many-3", "many-4"}, podNames(pods))
+		assert.Greater(t, reader.requests, len(kubernetes.ApplicationLabelSchemes), "pods are listed in pages")
+	})
+
+	t.Run("naisjob label schemes", func(t *testing.T) {
+		discovery := &kubernetes.PodDiscovery{
+			Reader:       reader,
+			LabelSchemes: []kubernetes.LabelScheme{kubernetes.NameLabel(kubernetes.JobNameLabelKey)},
+		}
+		pods, err := discovery.Pods(context.Background(), owner)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"direct-job"}, podNames(pods))
+	})
+
+	t.Run("owner references", func(t *testing.T) {
+		discovery := &kubernetes.PodDiscovery{
+			Reader:          reader,
+			OwnerReferences: true,
+			PageSize:        3,
+		}
+		pods, err := discovery.Pods(context.Background(), owner)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"owned-by-job", "owned-by-replicaset"}, podNames(pods))
+	})
+}
+
+func TestListNamespaces(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared1", Labels: map[string]string{kubernetes.SharedNamespaceLabelKey: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared2", Labels: map[string]string{kubernetes.SharedNamespaceLabelKey: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{"team": "team"}}},
+	}
+	reader := &pagingReader{Reader: fake.NewFakeClientWithScheme(scheme.Scheme, objects...)}
+
+	namespaces, err := kubernetes.ListSharedNamespaces(context.Background(), reader)
+	require.NoError(t, err)
+	assert.Len(t, namespaces.Items, 2)
+
+	namespaces, err = kubernetes.ListNamespaces(context.Background(), reader)
+	require.NoError(t, err)
+	assert.Len(t, namespaces.Items, 3)
+}
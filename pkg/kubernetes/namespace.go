@@ -3,21 +3,34 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Namespaces labeled with this key set to "true" are shared between teams.
+const SharedNamespaceLabelKey = "shared"
+
+// ListNamespaces lists all namespaces matching the options, in pages of DefaultPageSize namespaces.
 func ListNamespaces(ctx context.Context, reader client.Reader, opts ...client.ListOption) (corev1.NamespaceList, error) {
 	var namespaces corev1.NamespaceList
-	if err := reader.List(ctx, &namespaces, opts...); err != nil {
+	err := ListPages(ctx, reader, &corev1.NamespaceList{}, DefaultPageSize, func(page runtime.Object) {
+		namespaces.Items = append(namespaces.Items, page.(*corev1.NamespaceList).Items...)
+	}, opts...)
+	if err != nil {
 		return namespaces, fmt.Errorf("listing namespaces: %w", err)
 	}
 	return namespaces, nil
 }
 
+// ListNamespacesWithLabels lists all namespaces carrying the given labels.
+func ListNamespacesWithLabels(ctx context.Context, reader client.Reader, labels map[string]string) (corev1.NamespaceList, error) {
+	return ListNamespaces(ctx, reader, client.MatchingLabels(labels))
+}
+
 func ListSharedNamespaces(ctx context.Context, reader client.Reader) (corev1.NamespaceList, error) {
-	opts := client.MatchingLabels{
-		"shared": "true",
-	}
-	return ListNamespaces(ctx, reader, opts)
+	return ListNamespacesWithLabels(ctx, reader, map[string]string{
+		SharedNamespaceLabelKey: "true",
+	})
 }
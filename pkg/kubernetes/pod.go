@@ -16,9 +16,11 @@ func ListPods(ctx context.Context, reader client.Reader, opts ...client.ListOpti
 	return podList, nil
 }
 
+// ListPodsForApplication lists the pods labeled with the application name.
+// Use PodDiscovery to find pods using other label schemes or owner references.
 func ListPodsForApplication(ctx context.Context, reader client.Reader, name, namespace string) (corev1.PodList, error) {
 	matchingLabels := client.MatchingLabels{
-		"app": name,
+		AppLabelKey: name,
 	}
 	namespaceSelector := client.InNamespace(namespace)
 	return ListPods(ctx, reader, matchingLabels, namespaceSelector)
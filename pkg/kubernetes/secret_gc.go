@@ -0,0 +1,109 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Labels set by NAIS operators on the credential secrets they create.
+const (
+	SecretTypeLabelKey = "type"
+	SecretAppLabelKey  = "app"
+	SecretTeamLabelKey = "team"
+
+	AzureSecretType      = "azurerator.nais.io"
+	DigdiratorSecretType = "digdirator.nais.io"
+	AivenSecretType      = "aivenator.aiven.nais.io"
+	JwkerSecretType      = "jwker.nais.io"
+)
+
+// SecretGCPlan is the result of planning garbage collection of rotated credential secrets.
+type SecretGCPlan struct {
+	// Secrets that are in use, or recent enough to be retained.
+	Keep []corev1.Secret
+	// Secrets that are safe to delete.
+	Delete []corev1.Secret
+}
+
+// SecretTypeSelector selects the credential secrets created by one of the NAIS operators, e.g. AzureSecretType.
+func SecretTypeSelector(secretType string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{SecretTypeLabelKey: secretType})
+}
+
+// PlanSecretGarbageCollection lists the secrets in a namespace matching the selector, and all workloads
+// in the same namespace, and plans garbage collection of the secrets. See PlanSecretGC.
+func PlanSecretGarbageCollection(ctx context.Context, reader client.Reader, namespace string, selector labels.Selector, retention int) (SecretGCPlan, error) {
+	secrets := &corev1.SecretList{}
+	err := reader.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return SecretGCPlan{}, fmt.Errorf("listing secrets: %w", err)
+	}
+
+	workloads, err := ListWorkloads(ctx, reader, client.InNamespace(namespace))
+	if err != nil {
+		return SecretGCPlan{}, err
+	}
+
+	return PlanSecretGC(secrets.Items, workloads.SecretUsage(), retention)
+}
+
+// PlanSecretGC decides which of the given credential secrets are safe to delete.
+//
+// Secrets referenced by any workload are always kept. The remaining secrets are grouped by the
+// application they belong to, as given by the `app` label, and the newest `retention` secrets of
+// each application are kept, so that a rollback to the previous credentials remains possible.
+// All other secrets are planned for deletion.
+//
+// Secrets without an `app` label cannot be attributed to an application, and are always kept.
+// Retention must be at least 1, so that the newest credentials of an application are never deleted.
+func PlanSecretGC(secrets []corev1.Secret, usage SecretUsage, retention int) (SecretGCPlan, error) {
+	if retention < 1 {
+		return SecretGCPlan{}, fmt.Errorf("retention must be at least 1, got %d", retention)
+	}
+
+	plan := SecretGCPlan{
+		Keep:   make([]corev1.Secret, 0),
+		Delete: make([]corev1.Secret, 0),
+	}
+
+	unused := make(map[string][]corev1.Secret)
+	for _, secret := range secrets {
+		if usage.IsUsed(secret) || len(secret.Labels[SecretAppLabelKey]) == 0 {
+			plan.Keep = append(plan.Keep, secret)
+			continue
+		}
+		app := secret.Namespace + "/" + secret.Labels[SecretAppLabelKey]
+		unused[app] = append(unused[app], secret)
+	}
+
+	apps := make([]string, 0, len(unused))
+	for app := range unused {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	for _, app := range apps {
+		candidates := unused[app]
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i].CreationTimestamp, candidates[j].CreationTimestamp
+			if a.Equal(&b) {
+				return candidates[i].Name < candidates[j].Name
+			}
+			return b.Before(&a)
+		})
+		for i, secret := range candidates {
+			if i < retention {
+				plan.Keep = append(plan.Keep, secret)
+			} else {
+				plan.Delete = append(plan.Delete, secret)
+			}
+		}
+	}
+
+	return plan, nil
+}
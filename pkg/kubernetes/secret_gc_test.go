@@ -0,0 +1,123 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+var gcEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func credentialSecret(name, app string, age time.Duration) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "myteam",
+			Labels: map[string]string{
+				kubernetes.SecretTypeLabelKey: kubernetes.AzureSecretType,
+				kubernetes.SecretAppLabelKey:  app,
+			},
+			CreationTimestamp: metav1.NewTime(gcEpoch.Add(-age)),
+		},
+	}
+}
+
+func secretNames(secrets []corev1.Secret) []string {
+	names := make([]string, len(secrets))
+	for i := range secrets {
+		names[i] = secrets[i].Name
+	}
+	return names
+}
+
+func TestPlanSecretGC(t *testing.T) {
+	secrets := []corev1.Secret{
+		*credentialSecret("app1-old", "app1", 72*time.Hour),
+		*credentialSecret("app1-in-use", "app1", 96*time.Hour),
+		*credentialSecret("app1-new", "app1", 1*time.Hour),
+		*credentialSecret("app1-previous", "app1", 24*time.Hour),
+		*credentialSecret("app2-old", "app2", 48*time.Hour),
+		*credentialSecret("app2-new", "app2", 1*time.Hour),
+		*credentialSecret("unlabeled-old", "", 72*time.Hour),
+		*credentialSecret("unlabeled-new", "", 1*time.Hour),
+	}
+	workloads := kubernetes.Workloads{
+		Pods: []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Name: "app1-pod", Namespace: "myteam"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+				{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "app1-in-use"}}},
+			}},
+		}},
+	}
+
+	plan, err := kubernetes.PlanSecretGC(secrets, workloads.SecretUsage(), 2)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app1-in-use", "app1-new", "app1-previous", "app2-new", "app2-old", "unlabeled-old", "unlabeled-new"}, secretNames(plan.Keep))
+	assert.Equal(t, []string{"app1-old"}, secretNames(plan.Delete))
+
+	plan, err = kubernetes.PlanSecretGC(secrets, workloads.SecretUsage(), 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app1-in-use", "app1-new", "app2-new", "unlabeled-old", "unlabeled-new"}, secretNames(plan.Keep))
+	assert.ElementsMatch(t, []string{"app1-old", "app1-previous", "app2-old"}, secretNames(plan.Delete))
+
+	for _, retention := range []int{0, -1} {
+		_, err = kubernetes.PlanSecretGC(secrets, workloads.SecretUsage(), retention)
+		assert.Error(t, err, "retention %d would delete the newest credentials", retention)
+	}
+}
+
+func TestPlanSecretGarbageCollection(t *testing.T) {
+	otherType := credentialSecret("jwker-secret", "app1", 72*time.Hour)
+	otherType.Labels[kubernetes.SecretTypeLabelKey] = kubernetes.JwkerSecretType
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "app1-previous-revision", Namespace: "myteam"},
+		Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "azure-previous-revision"}}},
+			},
+		}}},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app1", Namespace: "myteam"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Env: []corev1.EnvVar{{
+					Name: "AZURE_APP_CLIENT_ID",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "azure-in-use"},
+						Key:                  "AZURE_APP_CLIENT_ID",
+					}},
+				}},
+			}},
+		}}},
+	}
+
+	objects := []runtime.Object{
+		credentialSecret("azure-in-use", "app1", 96*time.Hour),
+		credentialSecret("azure-old", "app1", 72*time.Hour),
+		credentialSecret("azure-previous-revision", "app1", 120*time.Hour),
+		credentialSecret("azure-new", "app1", time.Hour),
+		otherType,
+		deployment,
+		replicaSet,
+	}
+	cli := fake.NewFakeClientWithScheme(scheme.Scheme, objects...)
+
+	plan, err := kubernetes.PlanSecretGarbageCollection(context.Background(), cli, "myteam", kubernetes.SecretTypeSelector(kubernetes.AzureSecretType), 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"azure-in-use", "azure-previous-revision", "azure-new"}, secretNames(plan.Keep), "secrets of replicasets are in use")
+	assert.Equal(t, []string{"azure-old"}, secretNames(plan.Delete))
+}
@@ -0,0 +1,150 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Workloads are the resources that can refer to secrets through their pod templates.
+type Workloads struct {
+	Pods        []corev1.Pod
+	Deployments []appsv1.Deployment
+	ReplicaSets []appsv1.ReplicaSet
+	Jobs        []batchv1.Job
+	CronJobs    []batchv1beta1.CronJob
+}
+
+// SecretUsage maps each referenced secret to the workloads referring to it.
+type SecretUsage map[types.NamespacedName][]corev1.ObjectReference
+
+// ListWorkloads lists all pods, deployments, replicasets, jobs and cronjobs matching the given options.
+func ListWorkloads(ctx context.Context, reader client.Reader, opts ...client.ListOption) (Workloads, error) {
+	var workloads Workloads
+
+	pods := &corev1.PodList{}
+	if err := reader.List(ctx, pods, opts...); err != nil {
+		return workloads, fmt.Errorf("listing pods: %w", err)
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := reader.List(ctx, deployments, opts...); err != nil {
+		return workloads, fmt.Errorf("listing deployments: %w", err)
+	}
+	replicaSets := &appsv1.ReplicaSetList{}
+	if err := reader.List(ctx, replicaSets, opts...); err != nil {
+		return workloads, fmt.Errorf("listing replicasets: %w", err)
+	}
+	jobs := &batchv1.JobList{}
+	if err := reader.List(ctx, jobs, opts...); err != nil {
+		return workloads, fmt.Errorf("listing jobs: %w", err)
+	}
+	cronJobs := &batchv1beta1.CronJobList{}
+	if err := reader.List(ctx, cronJobs, opts...); err != nil {
+		return workloads, fmt.Errorf("listing cronjobs: %w", err)
+	}
+
+	workloads.Pods = pods.Items
+	workloads.Deployments = deployments.Items
+	workloads.ReplicaSets = replicaSets.Items
+	workloads.Jobs = jobs.Items
+	workloads.CronJobs = cronJobs.Items
+
+	return workloads, nil
+}
+
+// SecretUsage finds all secrets referenced by the workloads.
+func (in Workloads) SecretUsage() SecretUsage {
+	usage := make(SecretUsage)
+
+	add := func(kind, namespace, name string, spec corev1.PodSpec) {
+		ref := corev1.ObjectReference{
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+		}
+		for _, secret := range PodSpecSecretNames(spec) {
+			key := types.NamespacedName{Namespace: namespace, Name: secret}
+			usage[key] = append(usage[key], ref)
+		}
+	}
+
+	for _, pod := range in.Pods {
+		add("Pod", pod.Namespace, pod.Name, pod.Spec)
+	}
+	for _, deployment := range in.Deployments {
+		add("Deployment", deployment.Namespace, deployment.Name, deployment.Spec.Template.Spec)
+	}
+	// Old replicasets retain the secrets of previous revisions, which are needed to roll back a deployment.
+	for _, replicaSet := range in.ReplicaSets {
+		add("ReplicaSet", replicaSet.Namespace, replicaSet.Name, replicaSet.Spec.Template.Spec)
+	}
+	for _, job := range in.Jobs {
+		add("Job", job.Namespace, job.Name, job.Spec.Template.Spec)
+	}
+	for _, cronJob := range in.CronJobs {
+		add("CronJob", cronJob.Namespace, cronJob.Name, cronJob.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	return usage
+}
+
+// IsUsed returns true if any workload refers to the secret.
+func (in SecretUsage) IsUsed(secret corev1.Secret) bool {
+	return len(in[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}]) > 0
+}
+
+// PodSpecSecretNames returns the sorted names of all secrets referenced by a pod spec,
+// through volumes, projected volumes, environment variables and image pull secrets of all containers and init containers.
+func PodSpecSecretNames(spec corev1.PodSpec) []string {
+	names := make(map[string]bool)
+	add := func(name string) {
+		if len(name) > 0 {
+			names[name] = true
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			add(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(source.Secret.Name)
+				}
+			}
+		}
+	}
+
+	for _, pullSecret := range spec.ImagePullSecrets {
+		add(pullSecret.Name)
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				add(envFrom.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				add(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
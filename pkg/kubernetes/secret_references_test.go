@@ -0,0 +1,87 @@
+package kubernetes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+func podSpecWithAllReferences() corev1.PodSpec {
+	return corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "a", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "volume"}}},
+			{Name: "b", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected"}}},
+					{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "configmap"}}},
+				},
+			}}},
+		},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "pull"}},
+		InitContainers: []corev1.Container{
+			{EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "init-env-from"}}}}},
+		},
+		Containers: []corev1.Container{
+			{
+				Env: []corev1.EnvVar{
+					{Name: "FOO", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "env-value-from"}, Key: "foo"}}},
+					{Name: "BAR", Value: "bar"},
+				},
+				EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "volume"}}}},
+			},
+		},
+	}
+}
+
+func TestPodSpecSecretNames(t *testing.T) {
+	expected := []string{"env-value-from", "init-env-from", "projected", "pull", "volume"}
+	assert.Equal(t, expected, kubernetes.PodSpecSecretNames(podSpecWithAllReferences()))
+	assert.Empty(t, kubernetes.PodSpecSecretNames(corev1.PodSpec{}))
+}
+
+func TestWorkloads_SecretUsage(t *testing.T) {
+	spec := func(secret string) corev1.PodSpec {
+		return corev1.PodSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: secret}}}
+	}
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "ns"}
+	}
+	template := func(secret string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{Spec: spec(secret)}
+	}
+
+	workloads := kubernetes.Workloads{
+		Pods:        []corev1.Pod{{ObjectMeta: meta("pod"), Spec: spec("shared")}},
+		Deployments: []appsv1.Deployment{{ObjectMeta: meta("deployment"), Spec: appsv1.DeploymentSpec{Template: template("deployment-secret")}}},
+		ReplicaSets: []appsv1.ReplicaSet{{ObjectMeta: meta("replicaset"), Spec: appsv1.ReplicaSetSpec{Template: template("replicaset-secret")}}},
+		Jobs:        []batchv1.Job{{ObjectMeta: meta("job"), Spec: batchv1.JobSpec{Template: template("shared")}}},
+		CronJobs: []batchv1beta1.CronJob{{ObjectMeta: meta("cronjob"), Spec: batchv1beta1.CronJobSpec{
+			JobTemplate: batchv1beta1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template("cronjob-secret")}},
+		}}},
+	}
+
+	usage := workloads.SecretUsage()
+	assert.Len(t, usage, 4)
+	assert.Equal(t, []corev1.ObjectReference{
+		{Kind: "Pod", Namespace: "ns", Name: "pod"},
+		{Kind: "Job", Namespace: "ns", Name: "job"},
+	}, usage[types.NamespacedName{Namespace: "ns", Name: "shared"}])
+	assert.Equal(t, "Deployment", usage[types.NamespacedName{Namespace: "ns", Name: "deployment-secret"}][0].Kind)
+	assert.Equal(t, "ReplicaSet", usage[types.NamespacedName{Namespace: "ns", Name: "replicaset-secret"}][0].Kind)
+	assert.Equal(t, "CronJob", usage[types.NamespacedName{Namespace: "ns", Name: "cronjob-secret"}][0].Kind)
+
+	secret := func(namespace, name string) corev1.Secret {
+		return corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	assert.True(t, usage.IsUsed(secret("ns", "shared")))
+	assert.False(t, usage.IsUsed(secret("other", "shared")), "secrets are only used within their own namespace")
+	assert.False(t, usage.IsUsed(secret("ns", "unused")))
+}
@@ -0,0 +1,183 @@
+// Package promql parses and evaluates the subset of the Prometheus query language used in NAIS alert rules.
+//
+// The parser performs the same syntactic and type checks as Prometheus, so that an expression accepted here is
+// accepted by Prometheus. Experimental syntax, such as native histograms, is not supported.
+// The evaluator is intended for testing alert rules offline, and follows the semantics of the Prometheus engine.
+// TemplateFunctions provides the functions of Prometheus alert templates, for validating and expanding annotations.
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// ValueType is the type an expression evaluates to.
+type ValueType string
+
+const (
+	ValueTypeScalar ValueType = "scalar"
+	ValueTypeVector ValueType = "instant vector"
+	ValueTypeMatrix ValueType = "range vector"
+	ValueTypeString ValueType = "string"
+)
+
+// Expr is a node in the syntax tree of a parsed expression.
+type Expr interface {
+	Type() ValueType
+}
+
+type NumberLiteral struct {
+	Val float64
+}
+
+type StringLiteral struct {
+	Val string
+}
+
+type ParenExpr struct {
+	Expr Expr
+}
+
+// UnaryExpr negates a scalar or vector expression.
+type UnaryExpr struct {
+	Op   string
+	Expr Expr
+}
+
+type BinaryExpr struct {
+	Op       string
+	LHS, RHS Expr
+	// Comparisons return 0 or 1 instead of filtering the result.
+	ReturnBool bool
+	// Label matching between two vectors. Nil if either side is a scalar.
+	Matching *VectorMatching
+}
+
+// VectorMatching describes how samples of two vectors are matched in a binary operation.
+type VectorMatching struct {
+	Card Cardinality
+	// If true, only MatchingLabels are compared. Otherwise all labels except MatchingLabels are compared.
+	On             bool
+	MatchingLabels []string
+	// Labels copied from the "one" side of a many-to-one or one-to-many match.
+	Include []string
+}
+
+type Cardinality string
+
+const (
+	CardOneToOne   Cardinality = "one-to-one"
+	CardManyToOne  Cardinality = "many-to-one"
+	CardOneToMany  Cardinality = "one-to-many"
+	CardManyToMany Cardinality = "many-to-many"
+)
+
+type VectorSelector struct {
+	Name     string
+	Matchers []*LabelMatcher
+	Offset   time.Duration
+	// Set by the @ modifier. Nil if the selector is evaluated at the evaluation time of the expression.
+	At *AtModifier
+}
+
+type MatrixSelector struct {
+	Vector *VectorSelector
+	Range  time.Duration
+}
+
+type SubqueryExpr struct {
+	Expr   Expr
+	Range  time.Duration
+	Step   time.Duration
+	Offset time.Duration
+	At     *AtModifier
+}
+
+// AtModifier fixes the evaluation time of a selector or subquery, e.g. `x @ 1609746000` or `x @ end()`.
+type AtModifier struct {
+	// Evaluation time given as a Unix timestamp. Zero if StartOrEnd is set.
+	Timestamp time.Time
+	// "start" or "end" for `@ start()` and `@ end()`, which refer to the start and end of the query.
+	StartOrEnd string
+}
+
+type AggregateExpr struct {
+	Op string
+	// Parameter of topk, bottomk, quantile and count_values.
+	Param    Expr
+	Expr     Expr
+	Grouping []string
+	Without  bool
+}
+
+type Call struct {
+	Func *Function
+	Args []Expr
+}
+
+type MatchType string
+
+const (
+	MatchEqual     MatchType = "="
+	MatchNotEqual  MatchType = "!="
+	MatchRegexp    MatchType = "=~"
+	MatchNotRegexp MatchType = "!~"
+)
+
+// LabelMatcher matches the value of a single label. Regular expressions are fully anchored.
+type LabelMatcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+
+	re *regexp.Regexp
+}
+
+func NewLabelMatcher(t MatchType, name, value string) (*LabelMatcher, error) {
+	m := &LabelMatcher{
+		Type:  t,
+		Name:  name,
+		Value: value,
+	}
+	if t == MatchRegexp || t == MatchNotRegexp {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", value, err)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+// Matches returns true if the label value satisfies the matcher. Missing labels have the empty value.
+func (in *LabelMatcher) Matches(value string) bool {
+	switch in.Type {
+	case MatchEqual:
+		return value == in.Value
+	case MatchNotEqual:
+		return value != in.Value
+	case MatchRegexp:
+		return in.re.MatchString(value)
+	case MatchNotRegexp:
+		return !in.re.MatchString(value)
+	}
+	return false
+}
+
+func (in *NumberLiteral) Type() ValueType  { return ValueTypeScalar }
+func (in *StringLiteral) Type() ValueType  { return ValueTypeString }
+func (in *ParenExpr) Type() ValueType      { return in.Expr.Type() }
+func (in *UnaryExpr) Type() ValueType      { return in.Expr.Type() }
+func (in *VectorSelector) Type() ValueType { return ValueTypeVector }
+func (in *MatrixSelector) Type() ValueType { return ValueTypeMatrix }
+func (in *SubqueryExpr) Type() ValueType   { return ValueTypeMatrix }
+func (in *AggregateExpr) Type() ValueType  { return ValueTypeVector }
+func (in *Call) Type() ValueType           { return in.Func.ReturnType }
+
+func (in *BinaryExpr) Type() ValueType {
+	if in.LHS.Type() == ValueTypeScalar && in.RHS.Type() == ValueTypeScalar {
+		return ValueTypeScalar
+	}
+	return ValueTypeVector
+}
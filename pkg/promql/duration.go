@@ -0,0 +1,51 @@
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var durationRegex = regexp.MustCompile(`^(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?$`)
+
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+	time.Millisecond,
+}
+
+// ParseDuration parses a duration in the Prometheus format, such as `5m` or `1h30m`.
+// Units must be given from largest to smallest; a year is always 365 days.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	matches := durationRegex.FindStringSubmatch(s)
+	if len(s) == 0 || matches == nil {
+		return 0, fmt.Errorf("not a valid duration: %q", s)
+	}
+
+	var duration time.Duration
+	for i, unit := range durationUnits {
+		group := matches[2*i+2]
+		if len(group) == 0 {
+			continue
+		}
+		n, err := strconv.ParseInt(group, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("not a valid duration: %q: %w", s, err)
+		}
+		next := duration + time.Duration(n)*unit
+		if time.Duration(n) > (1<<63-1)/unit || next < duration {
+			return 0, fmt.Errorf("duration out of range: %q", s)
+		}
+		duration = next
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,689 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// Instant vector selectors return the latest point at most this old.
+	DefaultLookbackDelta = 5 * time.Minute
+	// Resolution of subqueries that do not specify a step.
+	DefaultSubqueryStep = time.Minute
+)
+
+// Sample is a single element of an instant vector.
+// Samples selected directly from storage carry the time of the underlying point; all other samples the evaluation time.
+type Sample struct {
+	Labels Labels
+	T      time.Time
+	V      float64
+}
+
+type Vector []Sample
+
+type scalarValue struct {
+	T time.Time
+	V float64
+}
+
+type stringValue string
+
+type matrixValue []Series
+
+// Eval evaluates an expression at the given time. Scalar results are returned as a vector with a single, unlabeled sample.
+func Eval(q Queryable, expr Expr, ts time.Time) (Vector, error) {
+	ev := &evaluator{
+		q:        q,
+		ts:       ts,
+		start:    ts,
+		lookback: DefaultLookbackDelta,
+	}
+
+	value, err := ev.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case Vector:
+		seen := make(map[string]bool, len(v))
+		for _, sample := range v {
+			key := sample.Labels.Key()
+			if seen[key] {
+				return nil, fmt.Errorf("vector cannot contain metrics with the same labelset")
+			}
+			seen[key] = true
+		}
+		return v, nil
+	case scalarValue:
+		return Vector{{Labels: Labels{}, T: ts, V: v.V}}, nil
+	}
+	return nil, fmt.Errorf("expression must evaluate to a scalar or instant vector, got %T", value)
+}
+
+type evaluator struct {
+	q  Queryable
+	ts time.Time
+	// Evaluation time of the query. Both start() and end() of the @ modifier refer to it, as queries are instant.
+	start    time.Time
+	lookback time.Duration
+}
+
+// Returns an evaluator for the same expression at another time.
+func (ev *evaluator) at(ts time.Time) *evaluator {
+	return &evaluator{q: ev.q, ts: ts, start: ev.start, lookback: ev.lookback}
+}
+
+// Returns the time a selector or subquery is evaluated at, before the offset is applied.
+func (ev *evaluator) evalTime(at *AtModifier) time.Time {
+	switch {
+	case at == nil:
+		return ev.ts
+	case len(at.StartOrEnd) > 0:
+		return ev.start
+	}
+	return at.Timestamp
+}
+
+func (ev *evaluator) eval(expr Expr) (interface{}, error) {
+	switch e := expr.(type) {
+	case *NumberLiteral:
+		return scalarValue{T: ev.ts, V: e.Val}, nil
+	case *StringLiteral:
+		return stringValue(e.Val), nil
+	case *ParenExpr:
+		return ev.eval(e.Expr)
+	case *UnaryExpr:
+		return ev.evalUnary(e)
+	case *VectorSelector:
+		return ev.evalVectorSelector(e), nil
+	case *MatrixSelector:
+		return ev.evalMatrixSelector(e), nil
+	case *SubqueryExpr:
+		return ev.evalSubquery(e)
+	case *BinaryExpr:
+		return ev.evalBinary(e)
+	case *AggregateExpr:
+		return ev.evalAggregation(e)
+	case *Call:
+		return ev.evalCall(e)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func (ev *evaluator) evalVector(expr Expr) (Vector, error) {
+	value, err := ev.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := value.(Vector)
+	if !ok {
+		return nil, fmt.Errorf("expected instant vector, got %T", value)
+	}
+	return v, nil
+}
+
+func (ev *evaluator) evalScalar(expr Expr) (float64, error) {
+	value, err := ev.eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	s, ok := value.(scalarValue)
+	if !ok {
+		return 0, fmt.Errorf("expected scalar, got %T", value)
+	}
+	return s.V, nil
+}
+
+func (ev *evaluator) evalMatrix(expr Expr) (matrixValue, error) {
+	value, err := ev.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := value.(matrixValue)
+	if !ok {
+		return nil, fmt.Errorf("expected range vector, got %T", value)
+	}
+	return m, nil
+}
+
+func (ev *evaluator) evalUnary(e *UnaryExpr) (interface{}, error) {
+	value, err := ev.eval(e.Expr)
+	if err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case scalarValue:
+		return scalarValue{T: v.T, V: -v.V}, nil
+	case Vector:
+		result := make(Vector, 0, len(v))
+		for _, sample := range v {
+			result = append(result, Sample{Labels: sample.Labels.WithoutName(), T: sample.T, V: -sample.V})
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unary expression only allowed on scalars and instant vectors")
+}
+
+func selectorMatchers(vs *VectorSelector) []*LabelMatcher {
+	matchers := vs.Matchers
+	if len(vs.Name) > 0 {
+		nameMatcher, _ := NewLabelMatcher(MatchEqual, MetricNameLabel, vs.Name)
+		matchers = append([]*LabelMatcher{nameMatcher}, matchers...)
+	}
+	return matchers
+}
+
+func (ev *evaluator) evalVectorSelector(vs *VectorSelector) Vector {
+	end := ev.evalTime(vs.At).Add(-vs.Offset)
+	series := ev.q.Select(selectorMatchers(vs), end.Add(-ev.lookback), end)
+
+	result := make(Vector, 0, len(series))
+	for _, s := range series {
+		last := s.Points[len(s.Points)-1]
+		if IsStaleNaN(last.V) {
+			continue
+		}
+		result = append(result, Sample{Labels: s.Labels, T: last.T, V: last.V})
+	}
+	return result
+}
+
+func (ev *evaluator) evalMatrixSelector(ms *MatrixSelector) matrixValue {
+	end := ev.evalTime(ms.Vector.At).Add(-ms.Vector.Offset)
+	series := ev.q.Select(selectorMatchers(ms.Vector), end.Add(-ms.Range), end)
+
+	result := make(matrixValue, 0, len(series))
+	for _, s := range series {
+		points := make([]Point, 0, len(s.Points))
+		for _, p := range s.Points {
+			if !IsStaleNaN(p.V) {
+				points = append(points, p)
+			}
+		}
+		if len(points) > 0 {
+			result = append(result, Series{Labels: s.Labels, Points: points})
+		}
+	}
+	return result
+}
+
+// Subqueries are evaluated at multiples of the step, which aligns them with other subqueries regardless of evaluation time.
+func (ev *evaluator) evalSubquery(sq *SubqueryExpr) (interface{}, error) {
+	step := sq.Step
+	if step == 0 {
+		step = DefaultSubqueryStep
+	}
+	end := ev.evalTime(sq.At).Add(-sq.Offset)
+	start := end.Add(-sq.Range)
+
+	t := start.Truncate(step)
+	if !t.After(start) {
+		t = t.Add(step)
+	}
+
+	series := make(map[string]*Series)
+	order := make([]string, 0)
+	for ; !t.After(end); t = t.Add(step) {
+		v, err := ev.at(t).evalVector(sq.Expr)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range v {
+			key := sample.Labels.Key()
+			s, ok := series[key]
+			if !ok {
+				s = &Series{Labels: sample.Labels}
+				series[key] = s
+				order = append(order, key)
+			}
+			s.Points = append(s.Points, Point{T: t, V: sample.V})
+		}
+	}
+
+	result := make(matrixValue, 0, len(order))
+	for _, key := range order {
+		result = append(result, *series[key])
+	}
+	return result, nil
+}
+
+func (ev *evaluator) evalBinary(e *BinaryExpr) (interface{}, error) {
+	lhs, err := ev.eval(e.LHS)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := ev.eval(e.RHS)
+	if err != nil {
+		return nil, err
+	}
+
+	ls, lIsScalar := lhs.(scalarValue)
+	rs, rIsScalar := rhs.(scalarValue)
+
+	switch {
+	case lIsScalar && rIsScalar:
+		v, _ := binaryOp(e.Op, ls.V, rs.V)
+		if comparisonOperators[e.Op] {
+			v = boolValue(v != 0)
+		}
+		return scalarValue{T: ev.ts, V: v}, nil
+	case lIsScalar:
+		return ev.vectorScalarBinary(e, rhs.(Vector), ls.V, true), nil
+	case rIsScalar:
+		return ev.vectorScalarBinary(e, lhs.(Vector), rs.V, false), nil
+	}
+
+	lv, rv := lhs.(Vector), rhs.(Vector)
+	switch e.Op {
+	case "and", "or", "unless":
+		return ev.setBinary(e, lv, rv), nil
+	}
+	return ev.vectorBinary(e, lv, rv)
+}
+
+func (ev *evaluator) vectorScalarBinary(e *BinaryExpr, v Vector, scalar float64, scalarIsLHS bool) Vector {
+	result := make(Vector, 0, len(v))
+	for _, sample := range v {
+		l, r := sample.V, scalar
+		if scalarIsLHS {
+			l, r = r, l
+		}
+		value, keep := binaryOp(e.Op, l, r)
+		if comparisonOperators[e.Op] {
+			value = sample.V
+		}
+
+		labels := sample.Labels
+		switch {
+		case e.ReturnBool:
+			value = boolValue(keep)
+			labels = labels.WithoutName()
+		case comparisonOperators[e.Op]:
+			if !keep {
+				continue
+			}
+		default:
+			labels = labels.WithoutName()
+		}
+		result = append(result, Sample{Labels: labels, T: ev.ts, V: value})
+	}
+	return result
+}
+
+// Returns the labels used to match samples of two vectors.
+func matchingSignature(labels Labels, matching *VectorMatching) string {
+	if matching.On {
+		subset := make(Labels, len(matching.MatchingLabels))
+		for _, name := range matching.MatchingLabels {
+			if value, ok := labels[name]; ok {
+				subset[name] = value
+			}
+		}
+		return subset.Key()
+	}
+	subset := labels.WithoutName()
+	for _, name := range matching.MatchingLabels {
+		delete(subset, name)
+	}
+	return subset.Key()
+}
+
+func (ev *evaluator) setBinary(e *BinaryExpr, lhs, rhs Vector) Vector {
+	rightSignatures := make(map[string]bool, len(rhs))
+	for _, sample := range rhs {
+		rightSignatures[matchingSignature(sample.Labels, e.Matching)] = true
+	}
+
+	result := make(Vector, 0, len(lhs))
+	switch e.Op {
+	case "and":
+		for _, sample := range lhs {
+			if rightSignatures[matchingSignature(sample.Labels, e.Matching)] {
+				result = append(result, sample)
+			}
+		}
+	case "unless":
+		for _, sample := range lhs {
+			if !rightSignatures[matchingSignature(sample.Labels, e.Matching)] {
+				result = append(result, sample)
+			}
+		}
+	case "or":
+		leftSignatures := make(map[string]bool, len(lhs))
+		for _, sample := range lhs {
+			leftSignatures[matchingSignature(sample.Labels, e.Matching)] = true
+			result = append(result, sample)
+		}
+		for _, sample := range rhs {
+			if !leftSignatures[matchingSignature(sample.Labels, e.Matching)] {
+				result = append(result, sample)
+			}
+		}
+	}
+	return result
+}
+
+func (ev *evaluator) vectorBinary(e *BinaryExpr, lhs, rhs Vector) (Vector, error) {
+	matching := e.Matching
+	if matching.Card == CardOneToMany {
+		lhs, rhs = rhs, lhs
+	}
+
+	// The "one" side of the match must be unique for every signature.
+	one := make(map[string]Sample, len(rhs))
+	for _, sample := range rhs {
+		signature := matchingSignature(sample.Labels, matching)
+		if _, exists := one[signature]; exists {
+			side := "right"
+			if matching.Card == CardOneToMany {
+				side = "left"
+			}
+			return nil, fmt.Errorf("found duplicate series for the match group on the %s hand-side of the operation; many-to-many matching not allowed: matching labels must be unique on one side", side)
+		}
+		one[signature] = sample
+	}
+
+	matched := make(map[string]bool)
+	result := make(Vector, 0, len(lhs))
+	for _, ls := range lhs {
+		signature := matchingSignature(ls.Labels, matching)
+		rs, ok := one[signature]
+		if !ok {
+			continue
+		}
+
+		l, r := ls.V, rs.V
+		if matching.Card == CardOneToMany {
+			l, r = r, l
+		}
+		value, keep := binaryOp(e.Op, l, r)
+		if e.ReturnBool {
+			value = boolValue(keep)
+		} else if comparisonOperators[e.Op] && !keep {
+			continue
+		}
+
+		labels := resultLabels(ls.Labels, rs.Labels, e)
+		if matching.Card == CardOneToOne {
+			if matched[signature] {
+				return nil, fmt.Errorf("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+			}
+			matched[signature] = true
+		}
+		result = append(result, Sample{Labels: labels, T: ev.ts, V: value})
+	}
+	return result, nil
+}
+
+func resultLabels(many, one Labels, e *BinaryExpr) Labels {
+	matching := e.Matching
+	labels := many.Copy()
+	if !comparisonOperators[e.Op] || e.ReturnBool {
+		delete(labels, MetricNameLabel)
+	}
+
+	if matching.Card == CardOneToOne {
+		if matching.On {
+			subset := make(Labels, len(matching.MatchingLabels))
+			for _, name := range matching.MatchingLabels {
+				if value, ok := labels[name]; ok {
+					subset[name] = value
+				}
+			}
+			return subset
+		}
+		for _, name := range matching.MatchingLabels {
+			delete(labels, name)
+		}
+		return labels
+	}
+
+	for _, name := range matching.Include {
+		if value, ok := one[name]; ok && len(value) > 0 {
+			labels[name] = value
+		} else {
+			delete(labels, name)
+		}
+	}
+	return labels
+}
+
+// Returns the result of an arithmetic operation, or the left value and whether a comparison holds.
+func binaryOp(op string, l, r float64) (float64, bool) {
+	switch op {
+	case "+":
+		return l + r, true
+	case "-":
+		return l - r, true
+	case "*":
+		return l * r, true
+	case "/":
+		return l / r, true
+	case "%":
+		return math.Mod(l, r), true
+	case "^":
+		return math.Pow(l, r), true
+	case "atan2":
+		return math.Atan2(l, r), true
+	case "==":
+		return l, l == r
+	case "!=":
+		return l, l != r
+	case ">":
+		return l, l > r
+	case "<":
+		return l, l < r
+	case ">=":
+		return l, l >= r
+	case "<=":
+		return l, l <= r
+	}
+	panic(fmt.Sprintf("unknown binary operator %q", op))
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (ev *evaluator) evalAggregation(e *AggregateExpr) (interface{}, error) {
+	v, err := ev.evalVector(e.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var param float64
+	var label string
+	switch e.Op {
+	case "topk", "bottomk", "quantile":
+		param, err = ev.evalScalar(e.Param)
+	case "count_values":
+		var value interface{}
+		value, err = ev.eval(e.Param)
+		if err == nil {
+			label = string(value.(stringValue))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*aggregationGroup)
+	order := make([]string, 0)
+	for _, sample := range v {
+		labels := groupingLabels(sample.Labels, e)
+		key := labels.Key()
+		group, ok := groups[key]
+		if !ok {
+			group = &aggregationGroup{labels: labels}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.samples = append(group.samples, sample)
+	}
+
+	result := make(Vector, 0, len(order))
+	for _, key := range order {
+		group := groups[key]
+		samples, err := aggregate(e.Op, group, param, label)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range samples {
+			sample.T = ev.ts
+			result = append(result, sample)
+		}
+	}
+	return result, nil
+}
+
+type aggregationGroup struct {
+	labels  Labels
+	samples []Sample
+}
+
+func groupingLabels(labels Labels, e *AggregateExpr) Labels {
+	if e.Without {
+		result := labels.WithoutName()
+		for _, name := range e.Grouping {
+			delete(result, name)
+		}
+		return result
+	}
+	result := make(Labels, len(e.Grouping))
+	for _, name := range e.Grouping {
+		if value, ok := labels[name]; ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func aggregate(op string, group *aggregationGroup, param float64, label string) ([]Sample, error) {
+	values := make([]float64, 0, len(group.samples))
+	for _, sample := range group.samples {
+		values = append(values, sample.V)
+	}
+	single := func(v float64) []Sample {
+		return []Sample{{Labels: group.labels, V: v}}
+	}
+
+	switch op {
+	case "sum":
+		return single(sum(values)), nil
+	case "avg":
+		return single(sum(values) / float64(len(values))), nil
+	case "count":
+		return single(float64(len(values))), nil
+	case "group":
+		return single(1), nil
+	case "min":
+		return single(minimum(values)), nil
+	case "max":
+		return single(maximum(values)), nil
+	case "stddev":
+		return single(math.Sqrt(variance(values))), nil
+	case "stdvar":
+		return single(variance(values)), nil
+	case "quantile":
+		return single(quantile(param, values)), nil
+	case "topk", "bottomk":
+		samples := append([]Sample(nil), group.samples...)
+		sort.SliceStable(samples, func(i, j int) bool {
+			if op == "topk" {
+				return samples[i].V > samples[j].V
+			}
+			return samples[i].V < samples[j].V
+		})
+		k := int(param)
+		if k < 0 {
+			k = 0
+		}
+		if k < len(samples) {
+			samples = samples[:k]
+		}
+		return samples, nil
+	case "count_values":
+		counts := make(map[float64]int)
+		keys := make([]float64, 0)
+		for _, v := range values {
+			if _, ok := counts[v]; !ok {
+				keys = append(keys, v)
+			}
+			counts[v]++
+		}
+		samples := make([]Sample, 0, len(keys))
+		for _, v := range keys {
+			labels := group.labels.Copy()
+			labels[label] = strconv.FormatFloat(v, 'f', -1, 64)
+			samples = append(samples, Sample{Labels: labels, V: float64(counts[v])})
+		}
+		return samples, nil
+	}
+	return nil, fmt.Errorf("unsupported aggregation %q", op)
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func minimum(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range values {
+		if v < result || math.IsNaN(result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func maximum(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range values {
+		if v > result || math.IsNaN(result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func variance(values []float64) float64 {
+	mean := sum(values) / float64(len(values))
+	total := 0.0
+	for _, v := range values {
+		total += (v - mean) * (v - mean)
+	}
+	return total / float64(len(values))
+}
+
+// Returns the q-quantile of the values, interpolating linearly between the two nearest values.
+func quantile(q float64, values []float64) float64 {
+	if len(values) == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(1)
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := q * float64(len(sorted)-1)
+	lower := math.Max(0, math.Floor(rank))
+	upper := math.Min(float64(len(sorted)-1), lower+1)
+	weight := rank - math.Floor(rank)
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
@@ -0,0 +1,612 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Functions that keep the metric name of their input.
+var keepsMetricName = map[string]bool{
+	"label_join":     true,
+	"label_replace":  true,
+	"last_over_time": true,
+	"sort":           true,
+	"sort_desc":      true,
+}
+
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+func (ev *evaluator) evalCall(call *Call) (interface{}, error) {
+	name := call.Func.Name
+
+	switch name {
+	case "time":
+		return scalarValue{T: ev.ts, V: seconds(ev.ts)}, nil
+	case "pi":
+		return scalarValue{T: ev.ts, V: math.Pi}, nil
+	case "clamp":
+		return ev.clamp(call)
+	case "vector":
+		v, err := ev.evalScalar(call.Args[0])
+		return Vector{{Labels: Labels{}, T: ev.ts, V: v}}, err
+	case "scalar":
+		v, err := ev.evalVector(call.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(v) != 1 {
+			return scalarValue{T: ev.ts, V: math.NaN()}, nil
+		}
+		return scalarValue{T: ev.ts, V: v[0].V}, nil
+	case "absent":
+		v, err := ev.evalVector(call.Args[0])
+		if err != nil || len(v) > 0 {
+			return Vector{}, err
+		}
+		return Vector{{Labels: absentLabels(call.Args[0]), T: ev.ts, V: 1}}, nil
+	case "absent_over_time":
+		m, err := ev.evalMatrix(call.Args[0])
+		if err != nil || len(m) > 0 {
+			return Vector{}, err
+		}
+		return Vector{{Labels: absentLabels(call.Args[0]), T: ev.ts, V: 1}}, nil
+	case "histogram_quantile":
+		return ev.histogramQuantile(call)
+	case "label_replace":
+		return ev.labelReplace(call)
+	case "label_join":
+		return ev.labelJoin(call)
+	case "timestamp":
+		v, err := ev.evalVector(call.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		result := make(Vector, 0, len(v))
+		for _, sample := range v {
+			result = append(result, Sample{Labels: sample.Labels.WithoutName(), T: ev.ts, V: seconds(sample.T)})
+		}
+		return result, nil
+	case "sort", "sort_desc":
+		v, err := ev.evalVector(call.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		sorted := append(Vector(nil), v...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if name == "sort" {
+				return sorted[i].V < sorted[j].V
+			}
+			return sorted[i].V > sorted[j].V
+		})
+		return sorted, nil
+	}
+
+	if fn, ok := rangeFunctions[name]; ok {
+		return ev.evalRangeFunction(call, fn)
+	}
+	if fn, ok := instantFunctions[name]; ok {
+		return ev.evalInstantFunction(call, fn)
+	}
+	return nil, fmt.Errorf("function %s is not supported by the evaluator", name)
+}
+
+// Labels of the sample returned by absent, taken from the equality matchers of a vector selector.
+func absentLabels(arg Expr) Labels {
+	labels := Labels{}
+	var vs *VectorSelector
+	switch e := arg.(type) {
+	case *VectorSelector:
+		vs = e
+	case *MatrixSelector:
+		vs = e.Vector
+	default:
+		return labels
+	}
+	duplicates := make(map[string]bool)
+	for _, matcher := range vs.Matchers {
+		if matcher.Type != MatchEqual || matcher.Name == MetricNameLabel {
+			continue
+		}
+		if _, exists := labels[matcher.Name]; exists {
+			duplicates[matcher.Name] = true
+		}
+		labels[matcher.Name] = matcher.Value
+	}
+	for name := range duplicates {
+		delete(labels, name)
+	}
+	return labels
+}
+
+// Range functions are called with the points of a series within the range ending at end, and the scalar arguments of the call.
+type rangeFunction func(points []Point, end time.Time, r time.Duration, args []float64) (float64, bool)
+
+var rangeFunctions = map[string]rangeFunction{
+	"rate": func(points []Point, end time.Time, r time.Duration, _ []float64) (float64, bool) {
+		return extrapolatedRate(points, end, r, true, true)
+	},
+	"increase": func(points []Point, end time.Time, r time.Duration, _ []float64) (float64, bool) {
+		return extrapolatedRate(points, end, r, true, false)
+	},
+	"delta": func(points []Point, end time.Time, r time.Duration, _ []float64) (float64, bool) {
+		return extrapolatedRate(points, end, r, false, false)
+	},
+	"irate": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return instantDelta(points, true)
+	},
+	"idelta": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return instantDelta(points, false)
+	},
+	"changes": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		changes := 0
+		for i := 1; i < len(points); i++ {
+			if points[i].V != points[i-1].V && !(math.IsNaN(points[i].V) && math.IsNaN(points[i-1].V)) {
+				changes++
+			}
+		}
+		return float64(changes), true
+	},
+	"resets": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		resets := 0
+		for i := 1; i < len(points); i++ {
+			if points[i].V < points[i-1].V {
+				resets++
+			}
+		}
+		return float64(resets), true
+	},
+	"deriv": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		if len(points) < 2 {
+			return 0, false
+		}
+		slope, _ := linearRegression(points, points[0].T)
+		return slope, true
+	},
+	"predict_linear": func(points []Point, end time.Time, _ time.Duration, args []float64) (float64, bool) {
+		if len(points) < 2 {
+			return 0, false
+		}
+		slope, intercept := linearRegression(points, end)
+		return slope*args[0] + intercept, true
+	},
+	"avg_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return sum(pointValues(points)) / float64(len(points)), true
+	},
+	"count_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return float64(len(points)), true
+	},
+	"last_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return points[len(points)-1].V, true
+	},
+	"max_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return maximum(pointValues(points)), true
+	},
+	"min_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return minimum(pointValues(points)), true
+	},
+	"present_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return 1, true
+	},
+	"quantile_over_time": func(points []Point, _ time.Time, _ time.Duration, args []float64) (float64, bool) {
+		return quantile(args[0], pointValues(points)), true
+	},
+	"stddev_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return math.Sqrt(variance(pointValues(points))), true
+	},
+	"stdvar_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return variance(pointValues(points)), true
+	},
+	"sum_over_time": func(points []Point, _ time.Time, _ time.Duration, _ []float64) (float64, bool) {
+		return sum(pointValues(points)), true
+	},
+}
+
+func (ev *evaluator) evalRangeFunction(call *Call, fn rangeFunction) (interface{}, error) {
+	var matrix matrixValue
+	args := make([]float64, 0)
+	for _, arg := range call.Args {
+		if arg.Type() == ValueTypeMatrix {
+			m, err := ev.evalMatrix(arg)
+			if err != nil {
+				return nil, err
+			}
+			matrix = m
+			continue
+		}
+		v, err := ev.evalScalar(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	end, r := ev.rangeOf(call)
+	result := make(Vector, 0, len(matrix))
+	for _, series := range matrix {
+		v, ok := fn(series.Points, end, r, args)
+		if !ok {
+			continue
+		}
+		labels := series.Labels
+		if !keepsMetricName[call.Func.Name] {
+			labels = labels.WithoutName()
+		}
+		result = append(result, Sample{Labels: labels, T: ev.ts, V: v})
+	}
+	return result, nil
+}
+
+// Returns the end and length of the range of the range vector argument of a function, after the @ and offset modifiers.
+func (ev *evaluator) rangeOf(call *Call) (time.Time, time.Duration) {
+	for _, arg := range call.Args {
+		switch e := arg.(type) {
+		case *MatrixSelector:
+			return ev.evalTime(e.Vector.At).Add(-e.Vector.Offset), e.Range
+		case *SubqueryExpr:
+			return ev.evalTime(e.At).Add(-e.Offset), e.Range
+		}
+	}
+	return ev.ts, 0
+}
+
+// Computes rate, increase and delta the way Prometheus does: the difference between the first and last point is
+// extrapolated to the edges of the range, unless the series appears to start or end within the range.
+func extrapolatedRate(points []Point, rangeEnd time.Time, r time.Duration, isCounter, isRate bool) (float64, bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+	rangeStart := rangeEnd.Add(-r)
+	first, last := points[0], points[len(points)-1]
+
+	result := last.V - first.V
+	if isCounter {
+		for i := 1; i < len(points); i++ {
+			if points[i].V < points[i-1].V {
+				result += points[i-1].V
+			}
+		}
+	}
+
+	durationToStart := first.T.Sub(rangeStart).Seconds()
+	durationToEnd := rangeEnd.Sub(last.T).Seconds()
+	sampledInterval := last.T.Sub(first.T).Seconds()
+	averageDurationBetweenSamples := sampledInterval / float64(len(points)-1)
+
+	if isCounter && result > 0 && first.V >= 0 {
+		durationToZero := sampledInterval * (first.V / result)
+		if durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	threshold := averageDurationBetweenSamples * 1.1
+	extrapolateToInterval := sampledInterval
+	if durationToStart < threshold {
+		extrapolateToInterval += durationToStart
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	if durationToEnd < threshold {
+		extrapolateToInterval += durationToEnd
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+
+	result = result * (extrapolateToInterval / sampledInterval)
+	if isRate {
+		result = result / r.Seconds()
+	}
+	return result, true
+}
+
+func instantDelta(points []Point, isRate bool) (float64, bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+	last, previous := points[len(points)-1], points[len(points)-2]
+
+	result := last.V - previous.V
+	if isRate && last.V < previous.V {
+		result = last.V
+	}
+	if isRate {
+		interval := last.T.Sub(previous.T).Seconds()
+		if interval == 0 {
+			return 0, false
+		}
+		result = result / interval
+	}
+	return result, true
+}
+
+// Returns the slope per second and the value at interceptTime of the least squares fit through the points.
+func linearRegression(points []Point, interceptTime time.Time) (float64, float64) {
+	var n, sumX, sumY, sumXY, sumX2 float64
+	for _, p := range points {
+		x := p.T.Sub(interceptTime).Seconds()
+		n++
+		sumX += x
+		sumY += p.V
+		sumXY += x * p.V
+		sumX2 += x * x
+	}
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+	slope := covXY / varX
+	intercept := sumY/n - slope*sumX/n
+	return slope, intercept
+}
+
+func pointValues(points []Point) []float64 {
+	values := make([]float64, 0, len(points))
+	for _, p := range points {
+		values = append(values, p.V)
+	}
+	return values
+}
+
+type instantFunction func(v float64, args []float64) float64
+
+func dateFunction(fn func(t time.Time) int) instantFunction {
+	return func(v float64, _ []float64) float64 {
+		sec, frac := math.Modf(v)
+		return float64(fn(time.Unix(int64(sec), int64(frac*1e9)).UTC()))
+	}
+}
+
+var instantFunctions = map[string]instantFunction{
+	"abs":   func(v float64, _ []float64) float64 { return math.Abs(v) },
+	"ceil":  func(v float64, _ []float64) float64 { return math.Ceil(v) },
+	"exp":   func(v float64, _ []float64) float64 { return math.Exp(v) },
+	"floor": func(v float64, _ []float64) float64 { return math.Floor(v) },
+	"ln":    func(v float64, _ []float64) float64 { return math.Log(v) },
+	"log2":  func(v float64, _ []float64) float64 { return math.Log2(v) },
+	"log10": func(v float64, _ []float64) float64 { return math.Log10(v) },
+	"sqrt":  func(v float64, _ []float64) float64 { return math.Sqrt(v) },
+	"sgn": func(v float64, _ []float64) float64 {
+		switch {
+		case v < 0:
+			return -1
+		case v > 0:
+			return 1
+		}
+		return v
+	},
+	"acos":  func(v float64, _ []float64) float64 { return math.Acos(v) },
+	"acosh": func(v float64, _ []float64) float64 { return math.Acosh(v) },
+	"asin":  func(v float64, _ []float64) float64 { return math.Asin(v) },
+	"asinh": func(v float64, _ []float64) float64 { return math.Asinh(v) },
+	"atan":  func(v float64, _ []float64) float64 { return math.Atan(v) },
+	"atanh": func(v float64, _ []float64) float64 { return math.Atanh(v) },
+	"cos":   func(v float64, _ []float64) float64 { return math.Cos(v) },
+	"cosh":  func(v float64, _ []float64) float64 { return math.Cosh(v) },
+	"sin":   func(v float64, _ []float64) float64 { return math.Sin(v) },
+	"sinh":  func(v float64, _ []float64) float64 { return math.Sinh(v) },
+	"tan":   func(v float64, _ []float64) float64 { return math.Tan(v) },
+	"tanh":  func(v float64, _ []float64) float64 { return math.Tanh(v) },
+	"deg":   func(v float64, _ []float64) float64 { return v * 180 / math.Pi },
+	"rad":   func(v float64, _ []float64) float64 { return v * math.Pi / 180 },
+	"clamp_max": func(v float64, args []float64) float64 {
+		return math.Min(v, args[0])
+	},
+	"clamp_min": func(v float64, args []float64) float64 {
+		return math.Max(v, args[0])
+	},
+	"round": func(v float64, args []float64) float64 {
+		toNearest := 1.0
+		if len(args) > 0 {
+			toNearest = args[0]
+		}
+		inverse := 1.0 / toNearest
+		return math.Floor(v*inverse+0.5) / inverse
+	},
+	"day_of_month": dateFunction(func(t time.Time) int { return t.Day() }),
+	"day_of_week":  dateFunction(func(t time.Time) int { return int(t.Weekday()) }),
+	"days_in_month": dateFunction(func(t time.Time) int {
+		return 32 - time.Date(t.Year(), t.Month(), 32, 0, 0, 0, 0, time.UTC).Day()
+	}),
+	"hour":   dateFunction(func(t time.Time) int { return t.Hour() }),
+	"minute": dateFunction(func(t time.Time) int { return t.Minute() }),
+	"month":  dateFunction(func(t time.Time) int { return int(t.Month()) }),
+	"year":   dateFunction(func(t time.Time) int { return t.Year() }),
+}
+
+// Unlike clamp_min and clamp_max, clamp returns an empty vector if the minimum is larger than the maximum.
+func (ev *evaluator) clamp(call *Call) (interface{}, error) {
+	min, err := ev.evalScalar(call.Args[1])
+	if err != nil {
+		return nil, err
+	}
+	max, err := ev.evalScalar(call.Args[2])
+	if err != nil {
+		return nil, err
+	}
+	if max < min {
+		return Vector{}, nil
+	}
+	return ev.evalInstantFunction(call, func(v float64, _ []float64) float64 {
+		return math.Max(min, math.Min(max, v))
+	})
+}
+
+func (ev *evaluator) evalInstantFunction(call *Call, fn instantFunction) (interface{}, error) {
+	var v Vector
+	var err error
+	if len(call.Args) > 0 {
+		v, err = ev.evalVector(call.Args[0])
+	} else {
+		// Date functions default to the evaluation time.
+		v = Vector{{Labels: Labels{}, T: ev.ts, V: seconds(ev.ts)}}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]float64, 0)
+	for i := 1; i < len(call.Args); i++ {
+		value, err := ev.evalScalar(call.Args[i])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+	}
+
+	result := make(Vector, 0, len(v))
+	for _, sample := range v {
+		result = append(result, Sample{Labels: sample.Labels.WithoutName(), T: ev.ts, V: fn(sample.V, args)})
+	}
+	return result, nil
+}
+
+func (ev *evaluator) histogramQuantile(call *Call) (interface{}, error) {
+	q, err := ev.evalScalar(call.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	v, err := ev.evalVector(call.Args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	type bucket struct {
+		upperBound float64
+		count      float64
+	}
+	groups := make(map[string][]bucket)
+	labels := make(map[string]Labels)
+	order := make([]string, 0)
+	for _, sample := range v {
+		var upperBound float64
+		_, err := fmt.Sscan(sample.Labels["le"], &upperBound)
+		if err != nil {
+			continue
+		}
+		l := sample.Labels.WithoutName()
+		delete(l, "le")
+		key := l.Key()
+		if _, ok := groups[key]; !ok {
+			labels[key] = l
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], bucket{upperBound: upperBound, count: sample.V})
+	}
+
+	result := make(Vector, 0, len(order))
+	for _, key := range order {
+		buckets := groups[key]
+		sort.Slice(buckets, func(i, j int) bool {
+			return buckets[i].upperBound < buckets[j].upperBound
+		})
+		value := math.NaN()
+		switch {
+		case q < 0:
+			value = math.Inf(-1)
+		case q > 1:
+			value = math.Inf(1)
+		case len(buckets) >= 2 && math.IsInf(buckets[len(buckets)-1].upperBound, 1):
+			// Bucket counts are cumulative; the rank of the quantile is located in the first bucket reaching it.
+			for i := 1; i < len(buckets); i++ {
+				if buckets[i].count < buckets[i-1].count {
+					buckets[i].count = buckets[i-1].count
+				}
+			}
+			observations := buckets[len(buckets)-1].count
+			if observations == 0 {
+				break
+			}
+			rank := q * observations
+			b := sort.Search(len(buckets)-1, func(i int) bool { return buckets[i].count >= rank })
+			switch {
+			case b == len(buckets)-1:
+				value = buckets[len(buckets)-2].upperBound
+			case b == 0 && buckets[0].upperBound <= 0:
+				value = buckets[0].upperBound
+			default:
+				bucketStart := 0.0
+				bucketEnd := buckets[b].upperBound
+				count := buckets[b].count
+				if b > 0 {
+					bucketStart = buckets[b-1].upperBound
+					count -= buckets[b-1].count
+					rank -= buckets[b-1].count
+				}
+				value = bucketStart + (bucketEnd-bucketStart)*(rank/count)
+			}
+		}
+		result = append(result, Sample{Labels: labels[key], T: ev.ts, V: value})
+	}
+	return result, nil
+}
+
+func (ev *evaluator) labelReplace(call *Call) (interface{}, error) {
+	v, err := ev.evalVector(call.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	dst := call.Args[1].(*StringLiteral).Val
+	replacement := call.Args[2].(*StringLiteral).Val
+	src := call.Args[3].(*StringLiteral).Val
+	pattern := call.Args[4].(*StringLiteral).Val
+
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression in label_replace(): %s", pattern)
+	}
+	if !labelNameRegex.MatchString(dst) {
+		return nil, fmt.Errorf("invalid destination label name in label_replace(): %s", dst)
+	}
+
+	result := make(Vector, 0, len(v))
+	for _, sample := range v {
+		labels := sample.Labels
+		value := labels[src]
+		if indexes := re.FindStringSubmatchIndex(value); indexes != nil {
+			replaced := string(re.ExpandString(nil, replacement, value, indexes))
+			labels = labels.Copy()
+			if len(replaced) > 0 {
+				labels[dst] = replaced
+			} else {
+				delete(labels, dst)
+			}
+		}
+		result = append(result, Sample{Labels: labels, T: sample.T, V: sample.V})
+	}
+	return result, nil
+}
+
+func (ev *evaluator) labelJoin(call *Call) (interface{}, error) {
+	v, err := ev.evalVector(call.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	dst := call.Args[1].(*StringLiteral).Val
+	separator := call.Args[2].(*StringLiteral).Val
+	if !labelNameRegex.MatchString(dst) {
+		return nil, fmt.Errorf("invalid destination label name in label_join(): %s", dst)
+	}
+
+	result := make(Vector, 0, len(v))
+	for _, sample := range v {
+		values := make([]string, 0, len(call.Args)-3)
+		for _, arg := range call.Args[3:] {
+			values = append(values, sample.Labels[arg.(*StringLiteral).Val])
+		}
+		labels := sample.Labels.Copy()
+		joined := strings.Join(values, separator)
+		if len(joined) > 0 {
+			labels[dst] = joined
+		} else {
+			delete(labels, dst)
+		}
+		result = append(result, Sample{Labels: labels, T: sample.T, V: sample.V})
+	}
+	return result, nil
+}
+
+func seconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
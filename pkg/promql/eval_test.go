@@ -0,0 +1,216 @@
+package promql_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nais/liberator/pkg/promql"
+)
+
+var epoch = time.Unix(0, 0).UTC()
+
+// Stores a series with one point per minute, starting at the epoch.
+func appendSeries(storage *promql.Storage, labels promql.Labels, values ...float64) {
+	for i, v := range values {
+		storage.Append(labels, epoch.Add(time.Duration(i)*time.Minute), v)
+	}
+}
+
+func testStorage() *promql.Storage {
+	storage := promql.NewStorage()
+	appendSeries(storage, promql.Labels{"__name__": "http_requests_total", "app": "a", "code": "200"}, 0, 60, 120, 180, 240, 300)
+	appendSeries(storage, promql.Labels{"__name__": "http_requests_total", "app": "a", "code": "500"}, 0, 6, 12, 18, 24, 30)
+	appendSeries(storage, promql.Labels{"__name__": "http_requests_total", "app": "b", "code": "200"}, 0, 30, 60, 10, 40, 70)
+	appendSeries(storage, promql.Labels{"__name__": "app_info", "app": "a", "team": "aura"}, 1, 1, 1, 1, 1, 1)
+	appendSeries(storage, promql.Labels{"__name__": "app_info", "app": "b", "team": "nais"}, 1, 1, 1, 1, 1, 1)
+	appendSeries(storage, promql.Labels{"__name__": "up", "app": "a"}, 1, 1, 1, 1, 0, promql.StaleNaN)
+	for i, le := range []string{"0.1", "0.5", "1", "+Inf"} {
+		counts := []float64{10, 50, 90, 100}
+		appendSeries(storage, promql.Labels{"__name__": "latency_bucket", "app": "a", "le": le}, counts[i])
+	}
+	return storage
+}
+
+func evaluate(t *testing.T, input string, at time.Duration) promql.Vector {
+	expr, err := promql.ParseExpr(input)
+	require.NoError(t, err)
+	v, err := promql.Eval(testStorage(), expr, epoch.Add(at))
+	require.NoError(t, err)
+	return v
+}
+
+func values(v promql.Vector) map[string]float64 {
+	result := make(map[string]float64)
+	for _, sample := range v {
+		result[sample.Labels["app"]+"/"+sample.Labels["code"]] = sample.V
+	}
+	return result
+}
+
+func TestEval(t *testing.T) {
+	t.Run("instant selector with lookback", func(t *testing.T) {
+		v := evaluate(t, `http_requests_total{app="a"}`, 4*time.Minute+30*time.Second)
+		assert.Equal(t, map[string]float64{"a/200": 240, "a/500": 24}, values(v))
+		assert.Equal(t, "http_requests_total", v[0].Labels["__name__"])
+
+		assert.Empty(t, evaluate(t, `http_requests_total`, 11*time.Minute), "points older than the lookback delta are ignored")
+	})
+
+	t.Run("stale series", func(t *testing.T) {
+		assert.Len(t, evaluate(t, `up`, 4*time.Minute), 1)
+		assert.Empty(t, evaluate(t, `up`, 5*time.Minute))
+	})
+
+	t.Run("rate handles counter resets", func(t *testing.T) {
+		v := evaluate(t, `rate(http_requests_total[5m])`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 1, "a/500": 0.1, "b/200": math.Round(125.0/300*1e9) / 1e9}, roundValues(values(v)))
+		assert.NotContains(t, v[0].Labels, "__name__")
+	})
+
+	t.Run("aggregation and arithmetic", func(t *testing.T) {
+		v := evaluate(t, `sum by (app) (rate(http_requests_total{code=~"5.."}[5m])) / sum by (app) (rate(http_requests_total[5m]))`, 5*time.Minute)
+		require.Len(t, v, 1)
+		assert.InDelta(t, 0.1/1.1, v[0].V, 1e-9)
+		assert.Equal(t, promql.Labels{"app": "a"}, v[0].Labels)
+	})
+
+	t.Run("comparison filters", func(t *testing.T) {
+		v := evaluate(t, `http_requests_total > 100`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 300}, values(v))
+
+		v = evaluate(t, `http_requests_total > bool 100`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 1, "a/500": 0, "b/200": 0}, values(v))
+	})
+
+	t.Run("group left", func(t *testing.T) {
+		v := evaluate(t, `http_requests_total * on (app) group_left (team) app_info`, 5*time.Minute)
+		require.Len(t, v, 3)
+		for _, sample := range v {
+			assert.Contains(t, []string{"aura", "nais"}, sample.Labels["team"])
+		}
+	})
+
+	t.Run("one-to-one matching requires unique matches", func(t *testing.T) {
+		expr, err := promql.ParseExpr(`http_requests_total * on (app) app_info`)
+		require.NoError(t, err)
+		_, err = promql.Eval(testStorage(), expr, epoch.Add(5*time.Minute))
+		assert.Error(t, err)
+	})
+
+	t.Run("set operators", func(t *testing.T) {
+		v := evaluate(t, `http_requests_total unless on (app) up`, 4*time.Minute)
+		assert.Equal(t, map[string]float64{"b/200": 40}, values(v))
+	})
+
+	t.Run("topk", func(t *testing.T) {
+		v := evaluate(t, `topk(1, http_requests_total)`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 300}, values(v))
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		v := evaluate(t, `absent(nonexistent{job="myjob", instance=~".*"})`, 0)
+		require.Len(t, v, 1)
+		assert.Equal(t, promql.Labels{"job": "myjob"}, v[0].Labels)
+		assert.Empty(t, evaluate(t, `absent(up)`, 0))
+	})
+
+	t.Run("histogram quantile", func(t *testing.T) {
+		v := evaluate(t, `histogram_quantile(0.9, latency_bucket)`, 0)
+		require.Len(t, v, 1)
+		assert.InDelta(t, 1.0, v[0].V, 1e-9)
+
+		v = evaluate(t, `histogram_quantile(0.3, latency_bucket)`, 0)
+		assert.InDelta(t, 0.3, v[0].V, 1e-9)
+	})
+
+	t.Run("over time and subqueries", func(t *testing.T) {
+		v := evaluate(t, `max_over_time(http_requests_total{app="b"}[5m])`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"b/200": 70}, values(v))
+
+		v = evaluate(t, `max_over_time(rate(http_requests_total{app="b"}[2m])[4m:1m])`, 5*time.Minute)
+		require.Len(t, v, 1)
+		assert.InDelta(t, 0.5, v[0].V, 1e-9)
+	})
+
+	t.Run("scalar results", func(t *testing.T) {
+		v := evaluate(t, `time() - 60`, 5*time.Minute)
+		assert.Equal(t, promql.Vector{{Labels: promql.Labels{}, T: epoch.Add(5 * time.Minute), V: 240}}, v)
+	})
+
+	t.Run("label replace", func(t *testing.T) {
+		v := evaluate(t, `label_replace(up, "application", "app-$1", "app", "(.*)")`, 0)
+		require.Len(t, v, 1)
+		assert.Equal(t, "app-a", v[0].Labels["application"])
+	})
+
+	t.Run("@ modifier", func(t *testing.T) {
+		v := evaluate(t, `http_requests_total{app="a",code="200"} @ 120`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 120}, values(v))
+
+		v = evaluate(t, `http_requests_total{app="a",code="200"} @ 180 offset 1m`, 0)
+		assert.Equal(t, map[string]float64{"a/200": 120}, values(v), "offset is relative to the @ time")
+
+		v = evaluate(t, `rate(http_requests_total{app="a",code="200"}[2m] @ 180)`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 1}, roundValues(values(v)))
+
+		v = evaluate(t, `max_over_time(http_requests_total{app="b"}[1m:1m] @ 120)`, 5*time.Minute)
+		assert.Equal(t, map[string]float64{"b/200": 60}, values(v))
+
+		v = evaluate(t, `min_over_time((http_requests_total{app="a",code="200"} @ end())[2m:1m])`, 3*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 180}, values(v), "end() is the evaluation time of the query, also within subqueries")
+
+		v = evaluate(t, `http_requests_total{app="a",code="200"} @ start()`, 3*time.Minute)
+		assert.Equal(t, map[string]float64{"a/200": 180}, values(v), "start() equals end() for instant queries")
+	})
+}
+
+func TestEval_Functions(t *testing.T) {
+	functions := map[string]float64{
+		`sgn(vector(-3))`:          -1,
+		`sgn(vector(0))`:           0,
+		`sgn(vector(2.5))`:         1,
+		`sin(vector(pi() / 2))`:    1,
+		`cos(vector(pi()))`:        -1,
+		`tan(vector(pi() / 4))`:    1,
+		`asin(vector(1))`:          math.Pi / 2,
+		`acos(vector(-1))`:         math.Pi,
+		`atan(vector(1))`:          math.Pi / 4,
+		`sinh(vector(1))`:          math.Sinh(1),
+		`cosh(vector(1))`:          math.Cosh(1),
+		`tanh(vector(1))`:          math.Tanh(1),
+		`asinh(vector(1))`:         math.Asinh(1),
+		`acosh(vector(2))`:         math.Acosh(2),
+		`atanh(vector(0.5))`:       math.Atanh(0.5),
+		`deg(vector(pi()))`:        180,
+		`rad(vector(180))`:         math.Pi,
+		`pi()`:                     math.Pi,
+		`clamp(vector(5), 0, 1)`:   1,
+		`clamp(vector(-5), 0, 1)`:  0,
+		`clamp(vector(0.5), 0, 1)`: 0.5,
+	}
+
+	for input, expected := range functions {
+		t.Run(input, func(t *testing.T) {
+			v := evaluate(t, input, 0)
+			require.Len(t, v, 1)
+			assert.InDelta(t, expected, v[0].V, 1e-9)
+		})
+	}
+
+	v := evaluate(t, `clamp(http_requests_total{app="a"}, 10, 100)`, 5*time.Minute)
+	assert.Equal(t, map[string]float64{"a/200": 100, "a/500": 30}, values(v))
+	assert.NotContains(t, v[0].Labels, "__name__")
+
+	assert.Empty(t, evaluate(t, `clamp(vector(5), 1, 0)`, 0), "clamp returns nothing if min is larger than max")
+}
+
+func roundValues(m map[string]float64) map[string]float64 {
+	for k, v := range m {
+		m[k] = math.Round(v*1e9) / 1e9
+	}
+	return m
+}
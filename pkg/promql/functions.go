@@ -0,0 +1,79 @@
+package promql
+
+// Function describes the signature of a PromQL function.
+type Function struct {
+	Name     string
+	ArgTypes []ValueType
+	// Number of trailing arguments that may be omitted. If negative, the last argument may be repeated any number of times.
+	Variadic   int
+	ReturnType ValueType
+}
+
+var (
+	scalar = ValueTypeScalar
+	vector = ValueTypeVector
+	matrix = ValueTypeMatrix
+	str    = ValueTypeString
+)
+
+// Functions contains every function known to the parser, keyed by name.
+var Functions = map[string]*Function{}
+
+func init() {
+	register := func(name string, args []ValueType, variadic int, returns ValueType) {
+		Functions[name] = &Function{
+			Name:       name,
+			ArgTypes:   args,
+			Variadic:   variadic,
+			ReturnType: returns,
+		}
+	}
+
+	for _, name := range []string{
+		"abs", "absent", "ceil", "exp", "floor", "ln", "log2", "log10", "sgn", "sort", "sort_desc", "sqrt", "timestamp",
+		"acos", "acosh", "asin", "asinh", "atan", "atanh", "cos", "cosh", "deg", "rad", "sin", "sinh", "tan", "tanh",
+	} {
+		register(name, []ValueType{vector}, 0, vector)
+	}
+	for _, name := range []string{"day_of_month", "day_of_week", "days_in_month", "hour", "minute", "month", "year"} {
+		register(name, []ValueType{vector}, 1, vector)
+	}
+	for _, name := range []string{
+		"absent_over_time", "avg_over_time", "changes", "count_over_time", "delta", "deriv", "idelta", "increase", "irate",
+		"last_over_time", "max_over_time", "min_over_time", "present_over_time", "rate", "resets", "stddev_over_time",
+		"stdvar_over_time", "sum_over_time",
+	} {
+		register(name, []ValueType{matrix}, 0, vector)
+	}
+
+	register("clamp", []ValueType{vector, scalar, scalar}, 0, vector)
+	register("clamp_max", []ValueType{vector, scalar}, 0, vector)
+	register("clamp_min", []ValueType{vector, scalar}, 0, vector)
+	register("histogram_quantile", []ValueType{scalar, vector}, 0, vector)
+	register("holt_winters", []ValueType{matrix, scalar, scalar}, 0, vector)
+	register("label_join", []ValueType{vector, str, str, str}, -1, vector)
+	register("label_replace", []ValueType{vector, str, str, str, str}, 0, vector)
+	register("pi", []ValueType{}, 0, scalar)
+	register("predict_linear", []ValueType{matrix, scalar}, 0, vector)
+	register("quantile_over_time", []ValueType{scalar, matrix}, 0, vector)
+	register("round", []ValueType{vector, scalar}, 1, vector)
+	register("scalar", []ValueType{vector}, 0, scalar)
+	register("time", []ValueType{}, 0, scalar)
+	register("vector", []ValueType{scalar}, 0, vector)
+}
+
+// Aggregation operators. Those mapping to true take a parameter before the aggregated expression.
+var aggregations = map[string]bool{
+	"avg":          false,
+	"bottomk":      true,
+	"count":        false,
+	"count_values": true,
+	"group":        false,
+	"max":          false,
+	"min":          false,
+	"quantile":     true,
+	"stddev":       false,
+	"stdvar":       false,
+	"sum":          false,
+	"topk":         true,
+}
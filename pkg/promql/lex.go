@@ -0,0 +1,221 @@
+package promql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdentifier
+	tokenNumber
+	tokenDuration
+	tokenString
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBrace
+	tokenRightBrace
+	tokenLeftBracket
+	tokenRightBracket
+	tokenComma
+	tokenColon
+	tokenAt
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.val)
+}
+
+var punctuation = map[byte]tokenType{
+	'(': tokenLeftParen,
+	')': tokenRightParen,
+	'{': tokenLeftBrace,
+	'}': tokenRightBrace,
+	'[': tokenLeftBracket,
+	']': tokenRightBracket,
+	',': tokenComma,
+	':': tokenColon,
+	'@': tokenAt,
+}
+
+// Operators ordered so that longer operators are matched first.
+var operators = []string{"==", "!=", ">=", "<=", "=~", "!~", "+", "-", "*", "/", "%", "^", ">", "<", "="}
+
+func lex(input string) ([]token, error) {
+	tokens := make([]token, 0)
+	pos := 0
+
+	for pos < len(input) {
+		c := input[pos]
+		start := pos
+
+		switch {
+		case c == '#':
+			for pos < len(input) && input[pos] != '\n' {
+				pos++
+			}
+			continue
+		case unicode.IsSpace(rune(c)):
+			pos++
+			continue
+		case isIdentifierStart(c):
+			for pos < len(input) && isIdentifierChar(input[pos]) {
+				pos++
+			}
+			tokens = append(tokens, token{typ: tokenIdentifier, val: input[start:pos], pos: start})
+			continue
+		case isDigit(c) || (c == '.' && pos+1 < len(input) && isDigit(input[pos+1])):
+			tok, end, err := lexNumber(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos = end
+			continue
+		case c == '"' || c == '\'' || c == '`':
+			tok, end, err := lexString(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos = end
+			continue
+		}
+
+		if typ, ok := punctuation[c]; ok {
+			tokens = append(tokens, token{typ: typ, val: string(c), pos: start})
+			pos++
+			continue
+		}
+
+		matched := false
+		for _, op := range operators {
+			if strings.HasPrefix(input[pos:], op) {
+				tokens = append(tokens, token{typ: tokenOperator, val: op, pos: start})
+				pos += len(op)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+		}
+	}
+
+	return append(tokens, token{typ: tokenEOF, pos: pos}), nil
+}
+
+// Numbers directly followed by a duration unit are lexed as durations.
+func lexNumber(input string, pos int) (token, int, error) {
+	start := pos
+	if strings.HasPrefix(input[pos:], "0x") || strings.HasPrefix(input[pos:], "0X") {
+		pos += 2
+		for pos < len(input) && isHexDigit(input[pos]) {
+			pos++
+		}
+		if pos == start+2 || (pos < len(input) && isIdentifierChar(input[pos])) {
+			return token{}, 0, fmt.Errorf("bad number syntax at position %d", start)
+		}
+		return token{typ: tokenNumber, val: input[start:pos], pos: start}, pos, nil
+	}
+
+	for pos < len(input) && isDigit(input[pos]) {
+		pos++
+	}
+
+	if pos < len(input) && strings.IndexByte("smhdwy", input[pos]) >= 0 {
+		for pos < len(input) && (isDigit(input[pos]) || strings.IndexByte("smhdwy", input[pos]) >= 0) {
+			pos++
+		}
+		if pos < len(input) && (isIdentifierStart(input[pos]) || isDigit(input[pos])) {
+			return token{}, 0, fmt.Errorf("bad duration syntax at position %d", start)
+		}
+		return token{typ: tokenDuration, val: input[start:pos], pos: start}, pos, nil
+	}
+
+	for pos < len(input) && (isDigit(input[pos]) || input[pos] == '.') {
+		pos++
+	}
+	if pos < len(input) && (input[pos] == 'e' || input[pos] == 'E') {
+		pos++
+		if pos < len(input) && (input[pos] == '+' || input[pos] == '-') {
+			pos++
+		}
+		for pos < len(input) && isDigit(input[pos]) {
+			pos++
+		}
+	}
+	if pos < len(input) && (isIdentifierStart(input[pos]) || isDigit(input[pos])) {
+		return token{}, 0, fmt.Errorf("bad number syntax at position %d", start)
+	}
+	if _, err := strconv.ParseFloat(input[start:pos], 64); err != nil {
+		return token{}, 0, fmt.Errorf("bad number %q at position %d", input[start:pos], start)
+	}
+	return token{typ: tokenNumber, val: input[start:pos], pos: start}, pos, nil
+}
+
+// Strings are returned unquoted. Raw strings in backticks are not unescaped.
+func lexString(input string, pos int) (token, int, error) {
+	start := pos
+	quote := input[pos]
+	pos++
+	for pos < len(input) && input[pos] != quote {
+		if input[pos] == '\\' && quote != '`' && pos+1 < len(input) {
+			pos++
+		} else if input[pos] == '\n' && quote != '`' {
+			break
+		}
+		pos++
+	}
+	if pos >= len(input) || input[pos] != quote {
+		return token{}, 0, fmt.Errorf("unterminated string at position %d", start)
+	}
+	pos++
+
+	raw := input[start:pos]
+	if quote == '`' {
+		return token{typ: tokenString, val: raw[1 : len(raw)-1], pos: start}, pos, nil
+	}
+	if quote == '\'' {
+		// strconv only unquotes single runes in single quotes.
+		body := raw[1 : len(raw)-1]
+		body = strings.ReplaceAll(body, `\'`, `'`)
+		body = strings.ReplaceAll(body, `"`, `\"`)
+		raw = `"` + body + `"`
+	}
+	val, err := strconv.Unquote(raw)
+	if err != nil {
+		return token{}, 0, fmt.Errorf("invalid string at position %d: %w", start, err)
+	}
+	return token{typ: tokenString, val: val, pos: start}, pos, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == ':' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,702 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Binary operators by precedence, lowest first. The power operator is right associative and handled separately.
+var precedence = [][]string{
+	{"or"},
+	{"and", "unless"},
+	{"==", "!=", "<=", "<", ">=", ">"},
+	{"+", "-"},
+	{"*", "/", "%", "atan2"},
+}
+
+var comparisonOperators = map[string]bool{"==": true, "!=": true, "<=": true, "<": true, ">=": true, ">": true}
+
+var setOperators = map[string]bool{"and": true, "or": true, "unless": true}
+
+// Words that cannot be used as metric names, as they would make expressions ambiguous.
+var keywords = map[string]bool{
+	"and": true, "or": true, "unless": true, "atan2": true, "by": true, "without": true, "on": true, "ignoring": true,
+	"group_left": true, "group_right": true, "offset": true, "bool": true,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// ParseExpr parses and type checks a PromQL expression.
+func ParseExpr(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.typ != tokenEOF {
+		return nil, p.unexpected(tok, "end of input")
+	}
+	if t := expr.Type(); t != ValueTypeScalar && t != ValueTypeVector {
+		return nil, fmt.Errorf("expression must evaluate to a scalar or instant vector, got %s", t)
+	}
+	return expr, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.typ != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(typ tokenType, description string) (token, error) {
+	tok := p.next()
+	if tok.typ != typ {
+		return tok, p.unexpected(tok, description)
+	}
+	return tok, nil
+}
+
+func (p *parser) unexpected(tok token, expected string) error {
+	return fmt.Errorf("unexpected %s at position %d, expected %s", tok, tok.pos, expected)
+}
+
+// Returns the binary operator at the current position if it has the given precedence.
+func (p *parser) binaryOperator(level int) (string, bool) {
+	tok := p.peek()
+	if tok.typ != tokenOperator && tok.typ != tokenIdentifier {
+		return "", false
+	}
+	op := strings.ToLower(tok.val)
+	if tok.typ == tokenOperator {
+		op = tok.val
+	}
+	for _, candidate := range precedence[level] {
+		if op == candidate {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseBinary(level int) (Expr, error) {
+	if level == len(precedence) {
+		return p.parseUnary()
+	}
+
+	lhs, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.binaryOperator(level)
+		if !ok {
+			return lhs, nil
+		}
+		p.next()
+
+		expr := &BinaryExpr{Op: op, LHS: lhs}
+		err = p.parseModifiers(expr)
+		if err != nil {
+			return nil, err
+		}
+		expr.RHS, err = p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		err = checkBinary(expr)
+		if err != nil {
+			return nil, err
+		}
+		lhs = expr
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	if tok.typ == tokenOperator && (tok.val == "-" || tok.val == "+") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if t := expr.Type(); t != ValueTypeScalar && t != ValueTypeVector {
+			return nil, fmt.Errorf("unary expression only allowed on scalars and instant vectors, got %s", t)
+		}
+		if tok.val == "+" {
+			return expr, nil
+		}
+		if number, ok := expr.(*NumberLiteral); ok {
+			return &NumberLiteral{Val: -number.Val}, nil
+		}
+		return &UnaryExpr{Op: tok.val, Expr: expr}, nil
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() (Expr, error) {
+	lhs, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.typ != tokenOperator || tok.val != "^" {
+		return lhs, nil
+	}
+	p.next()
+
+	expr := &BinaryExpr{Op: "^", LHS: lhs}
+	err = p.parseModifiers(expr)
+	if err != nil {
+		return nil, err
+	}
+	expr.RHS, err = p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return expr, checkBinary(expr)
+}
+
+// Parses the bool, on, ignoring, group_left and group_right modifiers following a binary operator.
+func (p *parser) parseModifiers(expr *BinaryExpr) error {
+	if p.acceptKeyword("bool") {
+		expr.ReturnBool = true
+	}
+
+	matching := &VectorMatching{Card: CardOneToOne}
+	if setOperators[expr.Op] {
+		matching.Card = CardManyToMany
+	}
+	expr.Matching = matching
+
+	switch {
+	case p.acceptKeyword("on"):
+		matching.On = true
+		fallthrough
+	case p.acceptKeyword("ignoring"):
+		labels, err := p.parseLabelList()
+		if err != nil {
+			return err
+		}
+		matching.MatchingLabels = labels
+	}
+
+	group := ""
+	switch {
+	case p.acceptKeyword("group_left"):
+		group = "group_left"
+		matching.Card = CardManyToOne
+	case p.acceptKeyword("group_right"):
+		group = "group_right"
+		matching.Card = CardOneToMany
+	}
+	if len(group) > 0 {
+		if setOperators[expr.Op] {
+			return fmt.Errorf("no grouping allowed for %q operation", expr.Op)
+		}
+		if p.peek().typ == tokenLeftParen {
+			labels, err := p.parseLabelList()
+			if err != nil {
+				return err
+			}
+			matching.Include = labels
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	tok := p.peek()
+	if tok.typ == tokenIdentifier && strings.ToLower(tok.val) == keyword {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseLabelList() ([]string, error) {
+	_, err := p.expect(tokenLeftParen, `"("`)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0)
+	for p.peek().typ != tokenRightParen {
+		tok, err := p.expect(tokenIdentifier, "label name")
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(tok.val, ":") {
+			return nil, fmt.Errorf("invalid label name %q at position %d", tok.val, tok.pos)
+		}
+		labels = append(labels, tok.val)
+		if p.peek().typ != tokenComma {
+			break
+		}
+		p.next()
+	}
+
+	_, err = p.expect(tokenRightParen, `"," or ")"`)
+	return labels, err
+}
+
+// Parses a primary expression followed by an optional range or subquery, and the offset and @ modifiers in any order.
+func (p *parser) parsePostfix() (Expr, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().typ == tokenLeftBracket {
+		expr, err = p.parseRange(expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	offset, at := false, false
+	for {
+		tok := p.peek()
+		switch {
+		case !offset && p.acceptKeyword("offset"):
+			offset = true
+			err = p.parseOffset(expr, tok)
+		case !at && tok.typ == tokenAt:
+			p.next()
+			at = true
+			err = p.parseAt(expr, tok)
+		case tok.typ == tokenAt:
+			return nil, fmt.Errorf("@ modifier may not be set multiple times, at position %d", tok.pos)
+		default:
+			return expr, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseOffset(expr Expr, start token) error {
+	tok, err := p.expect(tokenDuration, "duration")
+	if err != nil {
+		return err
+	}
+	offset, err := ParseDuration(tok.val)
+	if err != nil {
+		return err
+	}
+	switch e := expr.(type) {
+	case *VectorSelector:
+		e.Offset = offset
+	case *MatrixSelector:
+		e.Vector.Offset = offset
+	case *SubqueryExpr:
+		e.Offset = offset
+	default:
+		return fmt.Errorf("offset modifier must be preceded by a selector or subquery, at position %d", start.pos)
+	}
+	return nil
+}
+
+// Parses the timestamp, start() or end() following the @ modifier.
+func (p *parser) parseAt(expr Expr, start token) error {
+	at := &AtModifier{}
+
+	tok := p.next()
+	switch {
+	case tok.typ == tokenIdentifier && (tok.val == "start" || tok.val == "end"):
+		at.StartOrEnd = tok.val
+		if _, err := p.expect(tokenLeftParen, `"("`); err != nil {
+			return err
+		}
+		if _, err := p.expect(tokenRightParen, `")"`); err != nil {
+			return err
+		}
+	default:
+		sign := 1.0
+		if tok.typ == tokenOperator && (tok.val == "-" || tok.val == "+") {
+			if tok.val == "-" {
+				sign = -1
+			}
+			tok = p.next()
+		}
+		if tok.typ != tokenNumber {
+			return p.unexpected(tok, "timestamp, start() or end()")
+		}
+		val, err := parseNumber(tok)
+		if err != nil {
+			return err
+		}
+		val *= sign
+		// Prometheus stores the timestamp in milliseconds.
+		if math.IsNaN(val) || math.IsInf(val, 0) || math.Abs(val*1000) > math.MaxInt64 {
+			return fmt.Errorf("timestamp out of bounds for @ modifier: %g, at position %d", val, tok.pos)
+		}
+		sec, frac := math.Modf(val)
+		at.Timestamp = time.Unix(int64(sec), int64(math.Round(frac*1e3))*int64(time.Millisecond)).UTC()
+	}
+
+	switch e := expr.(type) {
+	case *VectorSelector:
+		e.At = at
+	case *MatrixSelector:
+		e.Vector.At = at
+	case *SubqueryExpr:
+		e.At = at
+	default:
+		return fmt.Errorf("@ modifier must be preceded by a selector or subquery, at position %d", start.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseRange(expr Expr) (Expr, error) {
+	open := p.next()
+
+	rangeDuration, err := p.parseDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().typ == tokenRightBracket {
+		p.next()
+		vs, ok := expr.(*VectorSelector)
+		if !ok {
+			return nil, fmt.Errorf("ranges only allowed for vector selectors, at position %d", open.pos)
+		}
+		if vs.Offset != 0 {
+			return nil, fmt.Errorf("offset modifier must follow the range, at position %d", open.pos)
+		}
+		return &MatrixSelector{Vector: vs, Range: rangeDuration}, nil
+	}
+
+	_, err = p.expect(tokenColon, `":" or "]"`)
+	if err != nil {
+		return nil, err
+	}
+	if expr.Type() != ValueTypeVector {
+		return nil, fmt.Errorf("subquery is only allowed on instant vectors, got %s", expr.Type())
+	}
+
+	subquery := &SubqueryExpr{Expr: expr, Range: rangeDuration}
+	if p.peek().typ == tokenDuration {
+		subquery.Step, err = p.parseDuration()
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = p.expect(tokenRightBracket, `"]"`)
+	return subquery, err
+}
+
+func (p *parser) parseDuration() (time.Duration, error) {
+	tok, err := p.expect(tokenDuration, "duration")
+	if err != nil {
+		return 0, err
+	}
+	duration, err := ParseDuration(tok.val)
+	if err != nil {
+		return 0, err
+	}
+	if duration == 0 {
+		return 0, fmt.Errorf("duration must be greater than 0, at position %d", tok.pos)
+	}
+	return duration, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+
+	switch tok.typ {
+	case tokenNumber:
+		val, err := parseNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		return &NumberLiteral{Val: val}, nil
+
+	case tokenString:
+		return &StringLiteral{Val: tok.val}, nil
+
+	case tokenLeftParen:
+		expr, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokenRightParen, `")"`)
+		return &ParenExpr{Expr: expr}, err
+
+	case tokenLeftBrace:
+		return p.parseVectorSelector("", tok)
+
+	case tokenIdentifier:
+		return p.parseIdentifier(tok)
+	}
+
+	return nil, p.unexpected(tok, "expression")
+}
+
+// Integers are parsed like Prometheus does, with a base prefix, so that `0x1F` is 31 and `010` is 8.
+func parseNumber(tok token) (float64, error) {
+	if i, err := strconv.ParseInt(tok.val, 0, 64); err == nil {
+		return float64(i), nil
+	}
+	val, err := strconv.ParseFloat(tok.val, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %q at position %d", tok.val, tok.pos)
+	}
+	return val, nil
+}
+
+func (p *parser) parseIdentifier(tok token) (Expr, error) {
+	lower := strings.ToLower(tok.val)
+	next := p.peek()
+
+	if hasParam, ok := aggregations[lower]; ok && (next.typ == tokenLeftParen || isGroupingKeyword(next)) {
+		return p.parseAggregation(lower, hasParam)
+	}
+
+	if next.typ == tokenLeftParen {
+		fn, ok := Functions[tok.val]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %q at position %d", tok.val, tok.pos)
+		}
+		return p.parseCall(fn, tok)
+	}
+
+	switch lower {
+	case "inf":
+		return &NumberLiteral{Val: math.Inf(1)}, nil
+	case "nan":
+		return &NumberLiteral{Val: math.NaN()}, nil
+	}
+
+	if keywords[lower] {
+		return nil, p.unexpected(tok, "expression")
+	}
+
+	if next.typ == tokenLeftBrace {
+		p.next()
+		return p.parseVectorSelector(tok.val, tok)
+	}
+	return &VectorSelector{Name: tok.val}, nil
+}
+
+func isGroupingKeyword(tok token) bool {
+	if tok.typ != tokenIdentifier {
+		return false
+	}
+	lower := strings.ToLower(tok.val)
+	return lower == "by" || lower == "without"
+}
+
+func (p *parser) parseAggregation(op string, hasParam bool) (Expr, error) {
+	expr := &AggregateExpr{Op: op}
+
+	parseGrouping := func() error {
+		if !isGroupingKeyword(p.peek()) {
+			return nil
+		}
+		expr.Without = strings.ToLower(p.next().val) == "without"
+		labels, err := p.parseLabelList()
+		expr.Grouping = labels
+		return err
+	}
+
+	err := parseGrouping()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expect(tokenLeftParen, `"("`)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasParam {
+		expr.Param, err = p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokenComma, `","`)
+		if err != nil {
+			return nil, err
+		}
+		expected := ValueTypeScalar
+		if op == "count_values" {
+			expected = ValueTypeString
+		}
+		if t := expr.Param.Type(); t != expected {
+			return nil, fmt.Errorf("expected type %s in aggregation parameter of %s, got %s", expected, op, t)
+		}
+	}
+
+	expr.Expr, err = p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := expr.Expr.Type(); t != ValueTypeVector {
+		return nil, fmt.Errorf("expected type %s in aggregation %s, got %s", ValueTypeVector, op, t)
+	}
+	_, err = p.expect(tokenRightParen, `")"`)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(expr.Grouping) == 0 && !expr.Without {
+		err = parseGrouping()
+	}
+	return expr, err
+}
+
+func (p *parser) parseCall(fn *Function, tok token) (Expr, error) {
+	p.next()
+	call := &Call{Func: fn, Args: make([]Expr, 0)}
+
+	for p.peek().typ != tokenRightParen {
+		arg, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+		if p.peek().typ != tokenComma {
+			break
+		}
+		p.next()
+	}
+	_, err := p.expect(tokenRightParen, `"," or ")"`)
+	if err != nil {
+		return nil, err
+	}
+
+	required := len(fn.ArgTypes)
+	maximum := len(fn.ArgTypes)
+	switch {
+	case fn.Variadic < 0:
+		maximum = math.MaxInt32
+	case fn.Variadic > 0:
+		required -= fn.Variadic
+	}
+	if len(call.Args) < required || len(call.Args) > maximum {
+		return nil, fmt.Errorf("wrong number of arguments for function %s at position %d: got %d, expected %d", fn.Name, tok.pos, len(call.Args), required)
+	}
+
+	for i, arg := range call.Args {
+		expected := fn.ArgTypes[len(fn.ArgTypes)-1]
+		if i < len(fn.ArgTypes) {
+			expected = fn.ArgTypes[i]
+		}
+		if t := arg.Type(); t != expected {
+			return nil, fmt.Errorf("expected type %s in argument %d of function %s, got %s", expected, i+1, fn.Name, t)
+		}
+	}
+
+	return call, nil
+}
+
+// Parses label matchers after the opening brace.
+func (p *parser) parseVectorSelector(name string, start token) (Expr, error) {
+	selector := &VectorSelector{Name: name, Matchers: make([]*LabelMatcher, 0)}
+
+	for p.peek().typ != tokenRightBrace {
+		label, err := p.expect(tokenIdentifier, "label name")
+		if err != nil {
+			return nil, err
+		}
+		op, err := p.expect(tokenOperator, "label matching operator")
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.expect(tokenString, "label value")
+		if err != nil {
+			return nil, err
+		}
+
+		matchType := MatchType(op.val)
+		switch matchType {
+		case MatchEqual, MatchNotEqual, MatchRegexp, MatchNotRegexp:
+		default:
+			return nil, p.unexpected(op, "label matching operator")
+		}
+		matcher, err := NewLabelMatcher(matchType, label.val, value.val)
+		if err != nil {
+			return nil, err
+		}
+
+		if label.val == "__name__" {
+			if len(name) > 0 {
+				return nil, fmt.Errorf("metric name must not be set twice, at position %d", label.pos)
+			}
+			if matchType == MatchEqual {
+				selector.Name = value.val
+			}
+		}
+		selector.Matchers = append(selector.Matchers, matcher)
+
+		if p.peek().typ != tokenComma {
+			break
+		}
+		p.next()
+	}
+
+	_, err := p.expect(tokenRightBrace, `"," or "}"`)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selector.Name) > 0 {
+		return selector, nil
+	}
+	for _, matcher := range selector.Matchers {
+		if !matcher.Matches("") {
+			return selector, nil
+		}
+	}
+	return nil, fmt.Errorf("vector selector must contain at least one non-empty matcher, at position %d", start.pos)
+}
+
+func checkBinary(expr *BinaryExpr) error {
+	lt, rt := expr.LHS.Type(), expr.RHS.Type()
+	for _, t := range []ValueType{lt, rt} {
+		if t != ValueTypeScalar && t != ValueTypeVector {
+			return fmt.Errorf("binary expression must contain only scalar and instant vector types, got %s", t)
+		}
+	}
+
+	if expr.ReturnBool && !comparisonOperators[expr.Op] {
+		return fmt.Errorf("bool modifier can only be used on comparison operators")
+	}
+	if comparisonOperators[expr.Op] && !expr.ReturnBool && lt == ValueTypeScalar && rt == ValueTypeScalar {
+		return fmt.Errorf("comparisons between scalars must use the bool modifier")
+	}
+	if setOperators[expr.Op] && (lt != ValueTypeVector || rt != ValueTypeVector) {
+		return fmt.Errorf("set operator %q not allowed in binary scalar expression", expr.Op)
+	}
+
+	if lt != ValueTypeVector || rt != ValueTypeVector {
+		matching := expr.Matching
+		if matching.On || len(matching.MatchingLabels) > 0 || matching.Card != CardOneToOne {
+			return fmt.Errorf("vector matching only allowed between instant vectors")
+		}
+		expr.Matching = nil
+	}
+
+	return nil
+}
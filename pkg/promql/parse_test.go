@@ -0,0 +1,217 @@
+package promql_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nais/liberator/pkg/promql"
+)
+
+func TestParseExpr(t *testing.T) {
+	valid := []string{
+		`up`,
+		`up == 0`,
+		`kube_deployment_status_replicas_available{deployment="myapp"} > 0`,
+		`sum by (app) (rate(http_requests_total{code=~"5..", namespace!="kube-system"}[5m])) / sum by (app) (rate(http_requests_total[5m])) > 0.05`,
+		`sum(rate(x[1m])) without (instance)`,
+		`histogram_quantile(0.99, sum by (le) (rate(latency_bucket[5m]))) > 1.5`,
+		`absent(up{job="myapp"})`,
+		`max_over_time(deriv(x[5m])[1h:1m]) > 0`,
+		`x offset 1h - x`,
+		`rate(x[5m] offset 1d)`,
+		`a * on (instance) group_left (team) b`,
+		`a and ignoring (code) b or c unless d`,
+		`topk(3, x)`,
+		`count_values("version", build_info)`,
+		`-x ^ 2`,
+		`1 + 2 == bool 3`,
+		`{__name__=~"job:.*"}`,
+		`time() - process_start_time_seconds > 60`,
+		`label_replace(up, "dst", "$1", "src", "(.*)")`,
+		`round(x, 0.5)`,
+		"x{a='single', b=`raw`} # comment",
+		`job:request_latency_seconds:mean5m > Inf`,
+		`clamp(x, 0, 1)`,
+		`sgn(x) * pi()`,
+		`deg(atan(x)) + rad(x)`,
+		`x > 0x1F`,
+		`x @ 1609746000`,
+		`x offset 1h @ -10.5`,
+		`rate(x[5m] @ end() offset 1m)`,
+		`x @ start() - x`,
+		`max_over_time(rate(x[5m])[1h:1m] @ 100)`,
+	}
+
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			_, err := promql.ParseExpr(input)
+			assert.NoError(t, err)
+		})
+	}
+
+	invalid := map[string]string{
+		``:                          "unexpected end of input",
+		`up ==`:                     "unexpected end of input",
+		`sum(up`:                    `expected ")"`,
+		`rate(x)`:                   "expected type range vector",
+		`rate(x[5m], 1)`:            "wrong number of arguments",
+		`foo_bar(x)`:                `unknown function "foo_bar"`,
+		`x[5m]`:                     "must evaluate to a scalar or instant vector",
+		`1 > 2`:                     "bool modifier",
+		`x + bool y`:                "bool modifier can only be used on comparison operators",
+		`1 and x`:                   `set operator "and"`,
+		`x and on (a) group_left y`: "no grouping allowed",
+		`{a=""}`:                    "at least one non-empty matcher",
+		`x{a=~"("}`:                 "invalid regular expression",
+		`x{a>"b"}`:                  "label matching operator",
+		`topk(x)`:                   `expected ","`,
+		`sum(x[5m])`:                "expected type instant vector in aggregation",
+		`(x + y)[5m]`:               "ranges only allowed for vector selectors",
+		`x[0s]`:                     "duration must be greater than 0",
+		`x[5]`:                      "expected duration",
+		`x{a="b"`:                   `expected "," or "}"`,
+		`"unterminated`:             "unterminated string",
+		`x @ foo()`:                 "expected timestamp, start() or end()",
+		`x @ 1 @ 2`:                 "may not be set multiple times",
+		`x offset 1m offset 2m`:     "expected end of input",
+		`x @ 1e20`:                  "timestamp out of bounds",
+		`x @ end`:                   `expected "("`,
+		`(1 + 2) @ 10`:              "@ modifier must be preceded by a selector",
+		`x @ 10[5m]`:                "expected end of input",
+		`0x`:                        "bad number syntax",
+		`0x1g`:                      "bad number syntax",
+		`sin(x, 1)`:                 "wrong number of arguments",
+		`clamp(x, 1)`:               "wrong number of arguments",
+		`x{__name__="y"}`:           "metric name must not be set twice",
+		`(1 + 2) offset 5m`:         "offset modifier must be preceded by a selector",
+		`count_values(1, x)`:        "expected type string",
+		`5x`:                        "bad number syntax",
+		`x * on (a) 1`:              "vector matching only allowed between instant vectors",
+	}
+
+	for input, message := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, err := promql.ParseExpr(input)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), message)
+			}
+		})
+	}
+}
+
+func TestParseExpr_Tree(t *testing.T) {
+	expr, err := promql.ParseExpr(`sum by (app) (rate(x{code=~"5.."}[5m] offset 1m)) > 2 * 3`)
+	require.NoError(t, err)
+
+	comparison, ok := expr.(*promql.BinaryExpr)
+	require.True(t, ok)
+	assert.Equal(t, ">", comparison.Op)
+	assert.Nil(t, comparison.Matching, "no vector matching against scalars")
+	assert.Equal(t, promql.ValueTypeScalar, comparison.RHS.Type())
+
+	aggregation, ok := comparison.LHS.(*promql.AggregateExpr)
+	require.True(t, ok)
+	assert.Equal(t, "sum", aggregation.Op)
+	assert.Equal(t, []string{"app"}, aggregation.Grouping)
+
+	call, ok := aggregation.Expr.(*promql.Call)
+	require.True(t, ok)
+	assert.Equal(t, "rate", call.Func.Name)
+
+	matrix, ok := call.Args[0].(*promql.MatrixSelector)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Minute, matrix.Range)
+	assert.Equal(t, time.Minute, matrix.Vector.Offset)
+	assert.Equal(t, "x", matrix.Vector.Name)
+	require.Len(t, matrix.Vector.Matchers, 1)
+	assert.True(t, matrix.Vector.Matchers[0].Matches("503"))
+	assert.False(t, matrix.Vector.Matchers[0].Matches("1503"), "regular expressions are anchored")
+
+	expr, err = promql.ParseExpr(`-2 ^ 2`)
+	require.NoError(t, err)
+	assert.IsType(t, &promql.UnaryExpr{}, expr, "power binds tighter than unary minus")
+
+	expr, err = promql.ParseExpr(`a - b - c`)
+	require.NoError(t, err)
+	assert.IsType(t, &promql.BinaryExpr{}, expr.(*promql.BinaryExpr).LHS, "operators are left associative")
+
+	expr, err = promql.ParseExpr(`a * on (x) group_right (y) b`)
+	require.NoError(t, err)
+	assert.Equal(t, &promql.VectorMatching{
+		Card:           promql.CardOneToMany,
+		On:             true,
+		MatchingLabels: []string{"x"},
+		Include:        []string{"y"},
+	}, expr.(*promql.BinaryExpr).Matching)
+}
+
+func TestParseExpr_Numbers(t *testing.T) {
+	numbers := map[string]float64{
+		`1`:    1,
+		`1.5`:  1.5,
+		`.5`:   0.5,
+		`1e3`:  1000,
+		`1E-3`: 0.001,
+		`0x1F`: 31,
+		`0X1f`: 31,
+		`010`:  8,
+		`Inf`:  math.Inf(1),
+		`-Inf`: math.Inf(-1),
+	}
+
+	for input, expected := range numbers {
+		t.Run(input, func(t *testing.T) {
+			expr, err := promql.ParseExpr(input)
+			require.NoError(t, err)
+			assert.Equal(t, &promql.NumberLiteral{Val: expected}, expr)
+		})
+	}
+
+	expr, err := promql.ParseExpr(`NaN`)
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(expr.(*promql.NumberLiteral).Val))
+}
+
+func TestParseExpr_At(t *testing.T) {
+	expr, err := promql.ParseExpr(`x @ 100.5 offset 1m`)
+	require.NoError(t, err)
+	selector := expr.(*promql.VectorSelector)
+	assert.Equal(t, time.Minute, selector.Offset)
+	assert.Equal(t, &promql.AtModifier{Timestamp: time.Unix(100, 5e8).UTC()}, selector.At)
+
+	expr, err = promql.ParseExpr(`rate(x[5m] @ -10)`)
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(-10, 0).UTC(), expr.(*promql.Call).Args[0].(*promql.MatrixSelector).Vector.At.Timestamp)
+
+	expr, err = promql.ParseExpr(`max_over_time(x[1h:] @ end())`)
+	require.NoError(t, err)
+	assert.Equal(t, &promql.AtModifier{StartOrEnd: "end"}, expr.(*promql.Call).Args[0].(*promql.SubqueryExpr).At)
+}
+
+func TestParseDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"0":       0,
+		"30s":     30 * time.Second,
+		"5m":      5 * time.Minute,
+		"1h30m":   90 * time.Minute,
+		"2d":      48 * time.Hour,
+		"1w":      7 * 24 * time.Hour,
+		"1y":      365 * 24 * time.Hour,
+		"100ms":   100 * time.Millisecond,
+		"1m500ms": time.Minute + 500*time.Millisecond,
+	}
+	for input, expected := range valid {
+		duration, err := promql.ParseDuration(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, duration, input)
+	}
+
+	for _, input := range []string{"", "5", "m", "1.5h", "30m1h", "-5m", "5 m", "99999999999y"} {
+		_, err := promql.ParseDuration(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,139 @@
+package promql
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MetricNameLabel holds the metric name of a series.
+const MetricNameLabel = "__name__"
+
+// StaleNaN marks a series as stale from the point it is written, as Prometheus does when a target disappears.
+// Use IsStaleNaN to detect it, as NaN never compares equal.
+var StaleNaN = math.Float64frombits(0x7ff0000000000002)
+
+func IsStaleNaN(v float64) bool {
+	return math.Float64bits(v) == 0x7ff0000000000002
+}
+
+// Labels identifies a series. The metric name is stored under MetricNameLabel.
+type Labels map[string]string
+
+// Key returns a string uniquely identifying the label set.
+func (in Labels) Key() string {
+	names := make([]string, 0, len(in))
+	for name := range in {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0xfe)
+		b.WriteString(in[name])
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+func (in Labels) Copy() Labels {
+	labels := make(Labels, len(in))
+	for k, v := range in {
+		labels[k] = v
+	}
+	return labels
+}
+
+// WithoutName returns a copy of the label set without the metric name.
+func (in Labels) WithoutName() Labels {
+	labels := in.Copy()
+	delete(labels, MetricNameLabel)
+	return labels
+}
+
+type Point struct {
+	T time.Time
+	V float64
+}
+
+// Series is a list of points ordered by time.
+type Series struct {
+	Labels Labels
+	Points []Point
+}
+
+// Queryable is the source of series used when evaluating an expression.
+type Queryable interface {
+	// Select returns the points of all series satisfying every matcher, within the half-open interval (start, end].
+	Select(matchers []*LabelMatcher, start, end time.Time) []Series
+}
+
+// Storage is an in-memory Queryable, intended for tests.
+type Storage struct {
+	series map[string]*Series
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		series: make(map[string]*Series),
+	}
+}
+
+// Append adds a point to a series, creating the series if it does not exist.
+func (in *Storage) Append(labels Labels, t time.Time, v float64) {
+	key := labels.Key()
+	series, ok := in.series[key]
+	if !ok {
+		series = &Series{Labels: labels.Copy()}
+		in.series[key] = series
+	}
+
+	i := sort.Search(len(series.Points), func(i int) bool {
+		return !series.Points[i].T.Before(t)
+	})
+	if i < len(series.Points) && series.Points[i].T.Equal(t) {
+		series.Points[i].V = v
+		return
+	}
+	series.Points = append(series.Points, Point{})
+	copy(series.Points[i+1:], series.Points[i:])
+	series.Points[i] = Point{T: t, V: v}
+}
+
+func (in *Storage) Select(matchers []*LabelMatcher, start, end time.Time) []Series {
+	keys := make([]string, 0, len(in.series))
+	for key := range in.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]Series, 0)
+	for _, key := range keys {
+		series := in.series[key]
+		if !matchesAll(matchers, series.Labels) {
+			continue
+		}
+		points := make([]Point, 0)
+		for _, point := range series.Points {
+			if point.T.After(start) && !point.T.After(end) {
+				points = append(points, point)
+			}
+		}
+		if len(points) > 0 {
+			result = append(result, Series{Labels: series.Labels, Points: points})
+		}
+	}
+	return result
+}
+
+func matchesAll(matchers []*LabelMatcher, labels Labels) bool {
+	for _, matcher := range matchers {
+		if !matcher.Matches(labels[matcher.Name]) {
+			return false
+		}
+	}
+	return true
+}
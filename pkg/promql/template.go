@@ -0,0 +1,241 @@
+package promql
+
+import (
+	"fmt"
+	html "html/template"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateVariables are defined by Prometheus before expanding the annotations of an alert.
+const TemplateVariables = `{{ $labels := .Labels }}{{ $externalLabels := .ExternalLabels }}{{ $externalURL := .ExternalURL }}{{ $value := .Value }}`
+
+// TemplateData is the data Prometheus expands the annotations of an alert with.
+type TemplateData struct {
+	Labels         map[string]string
+	ExternalLabels map[string]string
+	ExternalURL    string
+	Value          float64
+}
+
+// ParseTemplate parses an alert annotation the way Prometheus does, with TemplateVariables and TemplateFunctions defined.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateFunctions).Option("missingkey=zero").Parse(TemplateVariables + text)
+}
+
+// TemplateFunctions are the functions available in Prometheus alert templates, formatting values the way Prometheus does.
+// Functions operating on query results fail when executed, as there is no Prometheus server to query.
+// No external URL is configured, so externalURL and pathPrefix return the empty string.
+var TemplateFunctions = template.FuncMap{
+	"query":       unsupportedTemplateFunction("query"),
+	"first":       unsupportedTemplateFunction("first"),
+	"label":       unsupportedTemplateFunction("label"),
+	"value":       unsupportedTemplateFunction("value"),
+	"strvalue":    unsupportedTemplateFunction("strvalue"),
+	"sortByLabel": unsupportedTemplateFunction("sortByLabel"),
+	"toUpper":     strings.ToUpper,
+	"toLower":     strings.ToLower,
+	"title":       strings.Title,
+	"match":       regexp.MatchString,
+	"reReplaceAll": func(pattern, replacement, text string) (string, error) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", err
+		}
+		return re.ReplaceAllString(text, replacement), nil
+	},
+	"safeHtml": func(text string) html.HTML {
+		return html.HTML(text)
+	},
+	"args": func(args ...interface{}) map[string]interface{} {
+		result := make(map[string]interface{}, len(args))
+		for i, arg := range args {
+			result[fmt.Sprintf("arg%d", i)] = arg
+		}
+		return result
+	},
+	"graphLink": func(expr string) string {
+		return fmt.Sprintf("/graph?g0.expr=%s&g0.tab=0", url.QueryEscape(expr))
+	},
+	"tableLink": func(expr string) string {
+		return fmt.Sprintf("/graph?g0.expr=%s&g0.tab=1", url.QueryEscape(expr))
+	},
+	"externalURL": func() string { return "" },
+	"pathPrefix":  func() string { return "" },
+	"parseDuration": func(s string) (float64, error) {
+		d, err := ParseDuration(s)
+		if err != nil {
+			return 0, err
+		}
+		return d.Seconds(), nil
+	},
+	"humanize":           humanize,
+	"humanize1024":       humanize1024,
+	"humanizeDuration":   humanizeDuration,
+	"humanizePercentage": humanizePercentage,
+	"humanizeTimestamp":  humanizeTimestamp,
+	"toTime":             toTime,
+}
+
+func unsupportedTemplateFunction(name string) func(...interface{}) (interface{}, error) {
+	return func(...interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("template function %s requires a Prometheus server", name)
+	}
+}
+
+// Template functions accept numbers of any type, as well as strings and durations.
+func templateFloat(i interface{}) (float64, error) {
+	switch v := i.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case int:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case time.Duration:
+		return v.Seconds(), nil
+	}
+	return 0, fmt.Errorf("can't convert %T to float", i)
+}
+
+// Formats a number with SI prefixes, e.g. 1.5k or 250m.
+func humanize(i interface{}) (string, error) {
+	v, err := templateFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	prefix := ""
+	if math.Abs(v) >= 1 {
+		for _, p := range []string{"k", "M", "G", "T", "P", "E", "Z", "Y"} {
+			if math.Abs(v) < 1000 {
+				break
+			}
+			prefix = p
+			v /= 1000
+		}
+		return fmt.Sprintf("%.4g%s", v, prefix), nil
+	}
+	for _, p := range []string{"m", "u", "n", "p", "f", "a", "z", "y"} {
+		if math.Abs(v) >= 1 {
+			break
+		}
+		prefix = p
+		v *= 1000
+	}
+	return fmt.Sprintf("%.4g%s", v, prefix), nil
+}
+
+// Formats a number with binary prefixes, e.g. 1ki.
+func humanize1024(i interface{}) (string, error) {
+	v, err := templateFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if math.Abs(v) <= 1 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	prefix := ""
+	for _, p := range []string{"ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi"} {
+		if math.Abs(v) < 1024 {
+			break
+		}
+		prefix = p
+		v /= 1024
+	}
+	return fmt.Sprintf("%.4g%s", v, prefix), nil
+}
+
+// Formats seconds as days, hours, minutes and seconds, e.g. 1m 30s. Durations below a minute keep four significant digits.
+func humanizeDuration(i interface{}) (string, error) {
+	v, err := templateFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	if v == 0 {
+		return fmt.Sprintf("%.4gs", v), nil
+	}
+	if math.Abs(v) >= 1 {
+		sign := ""
+		if v < 0 {
+			sign = "-"
+			v = -v
+		}
+		duration := int64(v)
+		seconds := duration % 60
+		minutes := (duration / 60) % 60
+		hours := (duration / 60 / 60) % 24
+		days := duration / 60 / 60 / 24
+		switch {
+		case days != 0:
+			return fmt.Sprintf("%s%dd %dh %dm %ds", sign, days, hours, minutes, seconds), nil
+		case hours != 0:
+			return fmt.Sprintf("%s%dh %dm %ds", sign, hours, minutes, seconds), nil
+		case minutes != 0:
+			return fmt.Sprintf("%s%dm %ds", sign, minutes, seconds), nil
+		}
+		return fmt.Sprintf("%s%.4gs", sign, v), nil
+	}
+	prefix := ""
+	for _, p := range []string{"m", "u", "n", "p", "f", "a", "z", "y"} {
+		if math.Abs(v) >= 1 {
+			break
+		}
+		prefix = p
+		v *= 1000
+	}
+	return fmt.Sprintf("%.4g%ss", v, prefix), nil
+}
+
+func humanizePercentage(i interface{}) (string, error) {
+	v, err := templateFloat(i)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%.4g%%", v*100), nil
+}
+
+// Formats a Unix timestamp in seconds as a UTC time.
+func humanizeTimestamp(i interface{}) (string, error) {
+	v, err := templateFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	return fmt.Sprint(unixTime(v)), nil
+}
+
+func toTime(i interface{}) (*time.Time, error) {
+	v, err := templateFloat(i)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("cannot convert %v to time.Time", v)
+	}
+	t := unixTime(v)
+	return &t, nil
+}
+
+// Prometheus keeps timestamps with millisecond precision.
+func unixTime(v float64) time.Time {
+	return time.Unix(0, int64(v*1e3)*int64(time.Millisecond)).UTC()
+}
@@ -0,0 +1,72 @@
+package promql_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nais/liberator/pkg/promql"
+)
+
+func expandTemplate(t *testing.T, text string, value interface{}) (string, error) {
+	tmpl, err := template.New("test").Funcs(promql.TemplateFunctions).Parse(promql.TemplateVariables + text)
+	require.NoError(t, err)
+	var b strings.Builder
+	err = tmpl.Execute(&b, map[string]interface{}{"Value": value})
+	return b.String(), err
+}
+
+func TestTemplateFunctions(t *testing.T) {
+	tests := []struct {
+		text     string
+		value    interface{}
+		expected string
+	}{
+		{`{{ $value | humanize }}`, 0.0, "0"},
+		{`{{ $value | humanize }}`, 1234567.0, "1.235M"},
+		{`{{ $value | humanize }}`, 0.0025, "2.5m"},
+		{`{{ $value | humanize }}`, "1500", "1.5k"},
+		{`{{ $value | humanize1024 }}`, 1.0, "1"},
+		{`{{ $value | humanize1024 }}`, 2048.0, "2ki"},
+		{`{{ $value | humanize1024 }}`, 1073741824.0, "1Gi"},
+		{`{{ $value | humanizeDuration }}`, 0.0, "0s"},
+		{`{{ $value | humanizeDuration }}`, 0.25, "250ms"},
+		{`{{ $value | humanizeDuration }}`, 12.5, "12.5s"},
+		{`{{ $value | humanizeDuration }}`, 90.0, "1m 30s"},
+		{`{{ $value | humanizeDuration }}`, 3600.0, "1h 0m 0s"},
+		{`{{ $value | humanizeDuration }}`, 90061.0, "1d 1h 1m 1s"},
+		{`{{ $value | humanizeDuration }}`, -90.0, "-1m 30s"},
+		{`{{ $value | humanizeDuration }}`, 90 * time.Second, "1m 30s"},
+		{`{{ $value | humanizeDuration }}`, math.Inf(1), "+Inf"},
+		{`{{ $value | humanizePercentage }}`, 0.1234, "12.34%"},
+		{`{{ $value | humanizeTimestamp }}`, 1435065584.128, "2015-06-23 13:19:44.128 +0000 UTC"},
+		{`{{ ($value | toTime).Year }}`, 1435065584.0, "2015"},
+		{`{{ "1h30m" | parseDuration }}`, nil, "5400"},
+		{`{{ "myapp" | toUpper }} {{ "MyApp" | toLower }} {{ "my app" | title }}`, nil, "MYAPP myapp My App"},
+		{`{{ match "^my" "myapp" }}`, nil, "true"},
+		{`{{ reReplaceAll "-(.*)" "/$1" "app-prod" }}`, nil, "app/prod"},
+		{`{{ with args 1 "a" }}{{ .arg0 }} {{ .arg1 }}{{ end }}`, nil, "1 a"},
+		{`{{ graphLink "up == 0" }}`, nil, "/graph?g0.expr=up+%3D%3D+0&g0.tab=0"},
+		{`{{ tableLink "up" }}`, nil, "/graph?g0.expr=up&g0.tab=1"},
+		{`{{ externalURL }}{{ pathPrefix }}`, nil, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			result, err := expandTemplate(t, test.text, test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+
+	_, err := expandTemplate(t, `{{ query "up" | first | value }}`, nil)
+	assert.Error(t, err, "queries require a Prometheus server")
+
+	_, err = expandTemplate(t, `{{ $value | humanize }}`, "high")
+	assert.Error(t, err)
+}
@@ -0,0 +1,291 @@
+// Package reconciler implements the reconcile loop shared by NAIS operators.
+//
+// Operators provide the resource specific synchronization and cleanup as Hooks,
+// while the Reconciler takes care of finalizers, change detection, events, status updates and retries.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/nais/liberator/pkg/conditions"
+	"github.com/nais/liberator/pkg/events"
+	"github.com/nais/liberator/pkg/finalizer"
+	liberator_hash "github.com/nais/liberator/pkg/hash"
+	"github.com/nais/liberator/pkg/resync"
+)
+
+const (
+	DefaultBaseDelay = 5 * time.Second
+	DefaultMaxDelay  = 15 * time.Minute
+	DefaultTimeout   = 5 * time.Minute
+	// Leaves time to remove the finalizer once cleanup completes, within DefaultTimeout.
+	DefaultCleanupTimeout = time.Minute
+)
+
+// SynchronizableResource is a custom resource that can be reconciled by the Reconciler.
+type SynchronizableResource interface {
+	runtime.Object
+	metav1.Object
+
+	// Hash of the desired state. The resource is only synchronized when this value changes.
+	Hash() (string, error)
+	// Hash of the most recently synchronized desired state, as stored in the status subresource.
+	GetSynchronizationHash() string
+	SetSynchronizationHash(hash string)
+	// Set the synchronization state, and the time it was set, in the status subresource.
+	SetSynchronizationState(state string)
+	// Name of the finalizer ensuring that cleanup runs before the resource is deleted.
+	FinalizerName() string
+}
+
+// ConditionedResource is implemented by resources with status conditions.
+// The reconciler keeps the Synchronized and Ready conditions of these resources up to date.
+type ConditionedResource interface {
+	StatusConditions() conditions.Status
+}
+
+// VersionedResource is implemented by resources that record which liberator version synchronized them.
+// The reconciler re-synchronizes these resources when required by a liberator upgrade, see package resync.
+type VersionedResource interface {
+	VersionedStatus() resync.Versioned
+}
+
+// HashVerifier is implemented by resources that can verify a stored hash produced by an earlier hash format,
+// such as a legacy hash stored before the resource was migrated to hash.Digest.
+// The reconciler uses it to decide whether a stored hash that differs from Hash() still matches the desired state.
+type HashVerifier interface {
+	VerifyHash(stored string) (bool, error)
+}
+
+// Hooks contain the resource specific parts of the reconcile loop.
+type Hooks interface {
+	// Synchronize brings the world in line with the desired state of the resource.
+	Synchronize(ctx context.Context, resource SynchronizableResource) error
+	// Delete cleans up everything created by Synchronize. The finalizer is removed once it succeeds.
+	Delete(ctx context.Context, resource SynchronizableResource) error
+}
+
+// Reconciler runs the shared reconcile loop. Create it with NewReconciler, and set optional fields before it is started.
+// The Reconciler is safe for use by concurrent workers, as long as it is not modified once started.
+type Reconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Hooks    Hooks
+	// NewResource returns an empty instance of the reconciled resource type.
+	NewResource func() SynchronizableResource
+
+	// Determines the requeue delay after failures. Defaults to exponential backoff
+	// between DefaultBaseDelay and DefaultMaxDelay.
+	RateLimiter workqueue.RateLimiter
+	// Spreads re-synchronization driven by liberator, rather than by changes to the resource, over time.
+	// This covers re-synchronization required by liberator upgrades, and changes of the hash algorithm,
+	// both of which affect every resource at once. Resources that are new or have been edited since
+	// they were last synchronized are never delayed. If nil, resources are synchronized immediately.
+	Resync *resync.Plan
+	// Maximum duration of a single reconciliation, including all API calls and hook invocations. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Maximum duration of Hooks.Delete. Must be shorter than Timeout, so that the finalizer can be removed
+	// once cleanup completes. Defaults to DefaultCleanupTimeout.
+	CleanupTimeout time.Duration
+	Logger         log.FieldLogger
+	// Whether the CRD of the reconciled resource has a status subresource.
+	// If not, the API server rejects status updates, and the status is written with a regular update instead.
+	StatusSubresource bool
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// NewReconciler returns a Reconciler with default rate limiting, timeout and logging.
+func NewReconciler(cli client.Client, recorder record.EventRecorder, hooks Hooks, newResource func() SynchronizableResource) *Reconciler {
+	return &Reconciler{
+		Client:         cli,
+		Recorder:       recorder,
+		Hooks:          hooks,
+		NewResource:    newResource,
+		RateLimiter:    workqueue.NewItemExponentialFailureRateLimiter(DefaultBaseDelay, DefaultMaxDelay),
+		Timeout:        DefaultTimeout,
+		CleanupTimeout: DefaultCleanupTimeout,
+		Logger:         log.StandardLogger(),
+	}
+}
+
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+
+	logger := r.Logger.WithFields(log.Fields{
+		"namespace": req.Namespace,
+		"name":      req.Name,
+	})
+
+	resource := r.NewResource()
+	err := r.Client.Get(ctx, req.NamespacedName, resource)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.RateLimiter.Forget(req)
+			r.forgetResync(req)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("get resource: %w", err)
+	}
+
+	if finalizer.IsBeingDeleted(resource) {
+		return r.delete(ctx, req, resource, logger)
+	}
+
+	err = finalizer.Ensure(ctx, r.Client, resource, resource.FinalizerName())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("add finalizer: %w", err)
+	}
+
+	hash, err := resource.Hash()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("calculate hash: %w", err)
+	}
+
+	upgrade := false
+	if v, ok := resource.(VersionedResource); ok {
+		upgrade = resync.NeedsResync(v.VersionedStatus().GetLiberatorVersion())
+	}
+
+	stored := resource.GetSynchronizationHash()
+	changed := hash != stored
+	if changed {
+		if v, ok := resource.(HashVerifier); ok {
+			match, err := v.VerifyHash(stored)
+			if err != nil {
+				logger.Warnf("Verify stored hash: %s", err)
+			}
+			changed = err != nil || !match
+		}
+	}
+	if !changed && !upgrade {
+		logger.Debugf("Hash unchanged; skipping synchronization")
+		r.RateLimiter.Forget(req)
+		return reconcile.Result{}, nil
+	}
+
+	if r.Resync != nil && !edited(resource, stored) && (upgrade || algorithmChanged(stored, hash)) {
+		delay := r.Resync.Delay(req.NamespacedName)
+		if delay > 0 {
+			logger.Debugf("Synchronization scheduled in %s", delay)
+			return reconcile.Result{RequeueAfter: delay}, nil
+		}
+	}
+	if !changed {
+		logger.Infof("Re-synchronizing as required by liberator upgrade")
+	}
+
+	err = r.Hooks.Synchronize(ctx, resource)
+	if err != nil {
+		logger.Errorf("Synchronization failed: %s", err)
+		r.Recorder.Eventf(resource, corev1.EventTypeWarning, events.FailedSynchronization, "Synchronization failed: %s", err)
+		resource.SetSynchronizationState(events.FailedSynchronization)
+		if c, ok := resource.(ConditionedResource); ok {
+			conditions.MarkSynchronizationFailed(c.StatusConditions(), resource.GetGeneration(), events.FailedSynchronization, err.Error())
+			conditions.MarkNotReady(c.StatusConditions(), resource.GetGeneration(), events.FailedSynchronization, err.Error())
+		}
+		r.updateStatus(ctx, resource, logger)
+		return r.retry(req, logger), nil
+	}
+
+	resource.SetSynchronizationHash(hash)
+	resource.SetSynchronizationState(events.Synchronized)
+	if v, ok := resource.(VersionedResource); ok {
+		v.VersionedStatus().SetLiberatorVersion(resync.CurrentVersion)
+	}
+	if c, ok := resource.(ConditionedResource); ok {
+		conditions.MarkSynchronized(c.StatusConditions(), resource.GetGeneration(), events.Synchronized, "")
+		conditions.MarkReady(c.StatusConditions(), resource.GetGeneration(), events.Synchronized, "")
+	}
+	r.Recorder.Event(resource, corev1.EventTypeNormal, events.Synchronized, "Successfully synchronized")
+
+	if !r.updateStatus(ctx, resource, logger) {
+		return r.retry(req, logger), nil
+	}
+
+	r.RateLimiter.Forget(req)
+	r.forgetResync(req)
+	logger.Infof("Successfully synchronized")
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) delete(ctx context.Context, req reconcile.Request, resource SynchronizableResource, logger log.FieldLogger) (reconcile.Result, error) {
+	cleanup := func(ctx context.Context) error {
+		return r.Hooks.Delete(ctx, resource)
+	}
+
+	removed, err := finalizer.Finalize(ctx, r.Client, resource, resource.FinalizerName(), r.CleanupTimeout, cleanup)
+	if err != nil {
+		logger.Errorf("Finalization failed: %s", err)
+		r.Recorder.Eventf(resource, corev1.EventTypeWarning, events.FailedSynchronization, "Finalization failed: %s", err)
+		return r.retry(req, logger), nil
+	}
+
+	r.RateLimiter.Forget(req)
+	r.forgetResync(req)
+	if removed {
+		logger.Infof("Cleanup complete; removed finalizer %s", resource.FinalizerName())
+	}
+	return reconcile.Result{}, nil
+}
+
+// Returns true if the resource is new, or its spec has been edited since it was last synchronized.
+// Generations are only known for resources with status conditions; other resources are considered unedited.
+func edited(resource SynchronizableResource, stored string) bool {
+	if len(stored) == 0 {
+		return true
+	}
+	c, ok := resource.(ConditionedResource)
+	if !ok {
+		return false
+	}
+	synchronized := conditions.Get(c.StatusConditions(), conditions.Synchronized)
+	return synchronized != nil && synchronized.ObservedGeneration < resource.GetGeneration()
+}
+
+// Returns true if the stored and current hash were produced by different hash formats.
+func algorithmChanged(stored, current string) bool {
+	return liberator_hash.IsLegacy(stored) != liberator_hash.IsLegacy(current)
+}
+
+// Releases the slot of a resource in the resync plan, once it is synchronized or gone.
+func (r *Reconciler) forgetResync(req reconcile.Request) {
+	if r.Resync != nil {
+		r.Resync.Done(req.NamespacedName)
+	}
+}
+
+// Returns true if the status was updated.
+func (r *Reconciler) updateStatus(ctx context.Context, resource SynchronizableResource, logger log.FieldLogger) bool {
+	var err error
+	if r.StatusSubresource {
+		err = r.Client.Status().Update(ctx, resource)
+	} else {
+		err = r.Client.Update(ctx, resource)
+	}
+	if err != nil {
+		logger.Errorf("Update status: %s", err)
+		r.Recorder.Eventf(resource, corev1.EventTypeWarning, events.FailedStatusUpdate, "Failed to update status: %s", err)
+		return false
+	}
+	return true
+}
+
+func (r *Reconciler) retry(req reconcile.Request, logger log.FieldLogger) reconcile.Result {
+	delay := r.RateLimiter.When(req)
+	logger.Infof("Retrying in %s", delay)
+	return reconcile.Result{RequeueAfter: delay}
+}
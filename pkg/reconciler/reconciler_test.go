@@ -0,0 +1,423 @@
+package reconciler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kafka_nais_io_v1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	"github.com/nais/liberator/pkg/conditions"
+	"github.com/nais/liberator/pkg/events"
+	"github.com/nais/liberator/pkg/reconciler"
+	"github.com/nais/liberator/pkg/resync"
+	"github.com/nais/liberator/pkg/testutil"
+)
+
+type hooks struct {
+	synchronized int
+	deleted      int
+	err          error
+}
+
+func (h *hooks) Synchronize(ctx context.Context, resource reconciler.SynchronizableResource) error {
+	h.synchronized++
+	return h.err
+}
+
+func (h *hooks) Delete(ctx context.Context, resource reconciler.SynchronizableResource) error {
+	h.deleted++
+	return h.err
+}
+
+func topic() *kafka_nais_io_v1.Topic {
+	return &kafka_nais_io_v1.Topic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "mytopic",
+			Namespace:  "myteam",
+			Generation: 2,
+		},
+		Spec: kafka_nais_io_v1.TopicSpec{
+			Pool: "mypool",
+		},
+	}
+}
+
+func setup(t *testing.T, h *hooks, objects ...*kafka_nais_io_v1.Topic) (*reconciler.Reconciler, *testutil.Cluster, *record.FakeRecorder) {
+	cluster := testutil.NewFakeCluster(t)
+	for _, obj := range objects {
+		require.NoError(t, cluster.Client.Create(context.Background(), obj))
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := reconciler.NewReconciler(cluster.Client, recorder, h, func() reconciler.SynchronizableResource {
+		return &kafka_nais_io_v1.Topic{}
+	})
+	return r, cluster, recorder
+}
+
+var request = reconcile.Request{
+	NamespacedName: types.NamespacedName{Namespace: "myteam", Name: "mytopic"},
+}
+
+func TestReconcile(t *testing.T) {
+	h := &hooks{}
+	r, cluster, recorder := setup(t, h, topic())
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 1, h.synchronized)
+	assert.Equal(t, "Normal Synchronized Successfully synchronized", <-recorder.Events)
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	expectedHash, err := topic().Hash()
+	require.NoError(t, err)
+	assert.Contains(t, actual.Finalizers, kafka_nais_io_v1.Finalizer)
+	assert.Equal(t, expectedHash, actual.Status.SynchronizationHash)
+	assert.Equal(t, events.Synchronized, actual.Status.SynchronizationState)
+	assert.NotEmpty(t, actual.Status.SynchronizationTime)
+	assert.True(t, conditions.IsCurrent(actual.Status, conditions.Ready, 2))
+	assert.True(t, conditions.IsTrue(actual.Status, conditions.Synchronized))
+	assert.Equal(t, resync.CurrentVersion, actual.Status.LiberatorVersion)
+
+	t.Run("unchanged hash skips synchronization", func(t *testing.T) {
+		result, err := r.Reconcile(request)
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.Equal(t, 1, h.synchronized)
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+func TestReconcileFailure(t *testing.T) {
+	h := &hooks{err: fmt.Errorf("kafka is down")}
+	r, cluster, recorder := setup(t, h, topic())
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconciler.DefaultBaseDelay, result.RequeueAfter)
+	assert.Equal(t, "Warning FailedSynchronization Synchronization failed: kafka is down", <-recorder.Events)
+
+	result, err = r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, 2*reconciler.DefaultBaseDelay, result.RequeueAfter, "backoff increases with repeated failures")
+	<-recorder.Events
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	assert.Empty(t, actual.Status.SynchronizationHash)
+	assert.Equal(t, events.FailedSynchronization, actual.Status.SynchronizationState)
+	assert.False(t, conditions.IsTrue(actual.Status, conditions.Ready))
+	assert.Equal(t, "kafka is down", conditions.Get(actual.Status, conditions.Synchronized).Message)
+
+	h.err = nil
+	result, err = r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+
+	h.err = fmt.Errorf("kafka is down again")
+	cluster.Get(t, actual)
+	actual.Spec.Pool = "otherpool"
+	require.NoError(t, cluster.Client.Update(context.Background(), actual))
+	result, err = r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconciler.DefaultBaseDelay, result.RequeueAfter, "backoff is reset after success")
+}
+
+func TestReconcileUpgrade(t *testing.T) {
+	originalHistory, originalVersion := resync.History, resync.CurrentVersion
+	defer func() {
+		resync.History, resync.CurrentVersion = originalHistory, originalVersion
+	}()
+
+	h := &hooks{}
+	first, second := topic(), topic()
+	second.Name = "othertopic"
+	r, cluster, _ := setup(t, h, first, second)
+	secondRequest := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "myteam", Name: "othertopic"}}
+
+	for _, req := range []reconcile.Request{request, secondRequest} {
+		_, err := r.Reconcile(req)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, h.synchronized)
+	r.Resync = resync.NewPlan(time.Hour, 1)
+
+	resync.History = append(resync.History, resync.Change{Version: resync.CurrentVersion + 1, Resync: true})
+	resync.CurrentVersion++
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 3, h.synchronized, "first resource is re-synchronized immediately")
+
+	result, err = r.Reconcile(secondRequest)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, result.RequeueAfter, float64(time.Minute), "second resource is scheduled for later")
+	assert.Equal(t, 3, h.synchronized)
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	assert.Equal(t, resync.CurrentVersion, actual.Status.LiberatorVersion)
+
+	result, err = r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, 3, h.synchronized, "upgraded resources are not re-synchronized again")
+}
+
+func TestReconcileChangedHashes(t *testing.T) {
+	h := &hooks{}
+	first, second := topic(), topic()
+	second.Name = "othertopic"
+	r, cluster, _ := setup(t, h, first, second)
+	secondRequest := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "myteam", Name: "othertopic"}}
+	requests := []reconcile.Request{request, secondRequest}
+
+	for _, req := range requests {
+		_, err := r.Reconcile(req)
+		require.NoError(t, err)
+	}
+	r.Resync = resync.NewPlan(time.Hour, 1)
+
+	setStoredHash := func(hash string) {
+		for _, req := range requests {
+			actual := &kafka_nais_io_v1.Topic{ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name}}
+			cluster.Get(t, actual)
+			actual.Status.SynchronizationHash = hash
+			require.NoError(t, cluster.Client.Update(context.Background(), actual))
+		}
+	}
+
+	t.Run("changed hashes are synchronized immediately", func(t *testing.T) {
+		setStoredHash("stale")
+		for _, req := range requests {
+			result, err := r.Reconcile(req)
+			require.NoError(t, err)
+			assert.Equal(t, reconcile.Result{}, result)
+		}
+		assert.Equal(t, 4, h.synchronized)
+	})
+
+	t.Run("changed hash algorithms are synchronized at the planned rate", func(t *testing.T) {
+		setStoredHash("sha256:0000000000000000000000000000000000000000000000000000000000000000")
+
+		result, err := r.Reconcile(request)
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.Equal(t, 5, h.synchronized)
+
+		result, err = r.Reconcile(secondRequest)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Hour, result.RequeueAfter, float64(time.Minute))
+		assert.Equal(t, 5, h.synchronized)
+
+		actual := &kafka_nais_io_v1.Topic{ObjectMeta: metav1.ObjectMeta{Namespace: "myteam", Name: "othertopic"}}
+		cluster.Get(t, actual)
+		actual.Generation++
+		actual.Spec.Pool = "otherpool"
+		require.NoError(t, cluster.Client.Update(context.Background(), actual))
+
+		result, err = r.Reconcile(secondRequest)
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result, "edited resources are not delayed")
+		assert.Equal(t, 6, h.synchronized)
+	})
+}
+
+// verifiedTopic is a Topic whose stored hash is checked by a custom verifier.
+type verifiedTopic struct {
+	*kafka_nais_io_v1.Topic
+	verify func(stored string) (bool, error)
+}
+
+func (in *verifiedTopic) VerifyHash(stored string) (bool, error) {
+	return in.verify(stored)
+}
+
+// Reads and writes the Topic wrapped by verifiedTopic, as the wrapper is not known to the scheme.
+type verifiedTopicClient struct {
+	client.Client
+}
+
+func (c verifiedTopicClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	return c.Client.Get(ctx, key, obj.(*verifiedTopic).Topic)
+}
+
+func (c verifiedTopicClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(ctx, obj.(*verifiedTopic).Topic, opts...)
+}
+
+func TestReconcileVerifyHash(t *testing.T) {
+	stored := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	existing := topic()
+	existing.Finalizers = []string{kafka_nais_io_v1.Finalizer}
+	existing.SetSynchronizationHash(stored)
+
+	h := &hooks{}
+	r, cluster, _ := setup(t, h, existing)
+	r.Client = verifiedTopicClient{Client: cluster.Client}
+
+	var verifyErr error
+	r.NewResource = func() reconciler.SynchronizableResource {
+		return &verifiedTopic{
+			Topic: &kafka_nais_io_v1.Topic{},
+			verify: func(hash string) (bool, error) {
+				return hash == stored, verifyErr
+			},
+		}
+	}
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 0, h.synchronized, "verified hashes are not synchronized")
+
+	verifyErr = fmt.Errorf("unsupported hash algorithm")
+	result, err = r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 1, h.synchronized, "hashes that cannot be verified are synchronized")
+}
+
+func TestReconcileDelete(t *testing.T) {
+	deleted := topic()
+	deleted.Finalizers = []string{kafka_nais_io_v1.Finalizer, "other"}
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	h := &hooks{}
+	r, cluster, _ := setup(t, h, deleted)
+	r.Resync = resync.NewPlan(time.Hour, 1)
+	r.Resync.Delay(request.NamespacedName)
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 1, h.deleted)
+	assert.Equal(t, 0, h.synchronized)
+	assert.InDelta(t, time.Hour, r.Resync.Delay(request.NamespacedName), float64(time.Minute), "slots of finalized resources are released")
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	assert.Equal(t, []string{"other"}, actual.Finalizers)
+
+	t.Run("cleanup is not repeated once the finalizer is removed", func(t *testing.T) {
+		_, err := r.Reconcile(request)
+		require.NoError(t, err)
+		assert.Equal(t, 1, h.deleted)
+	})
+}
+
+func TestReconcileDeleteFailure(t *testing.T) {
+	deleted := topic()
+	deleted.Finalizers = []string{kafka_nais_io_v1.Finalizer}
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	h := &hooks{err: fmt.Errorf("permission denied")}
+	r, cluster, recorder := setup(t, h, deleted)
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconciler.DefaultBaseDelay, result.RequeueAfter)
+	assert.Equal(t, "Warning FailedSynchronization Finalization failed: cleanup: permission denied", <-recorder.Events)
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	assert.Equal(t, []string{kafka_nais_io_v1.Finalizer}, actual.Finalizers)
+}
+
+// blockingHooks ignores cancellation of cleanup, and blocks until released.
+type blockingHooks struct {
+	hooks
+	release chan struct{}
+}
+
+func (h *blockingHooks) Delete(ctx context.Context, resource reconciler.SynchronizableResource) error {
+	<-h.release
+	return nil
+}
+
+func TestReconcileDeleteTimeout(t *testing.T) {
+	deleted := topic()
+	deleted.Finalizers = []string{kafka_nais_io_v1.Finalizer}
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	h := &blockingHooks{release: make(chan struct{})}
+	defer close(h.release)
+	r, cluster, recorder := setup(t, &h.hooks, deleted)
+	r.Hooks = h
+	r.CleanupTimeout = time.Millisecond
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconciler.DefaultBaseDelay, result.RequeueAfter)
+	assert.Equal(t, "Warning FailedSynchronization Finalization failed: cleanup: did not complete within 1ms: context deadline exceeded", <-recorder.Events)
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	assert.Equal(t, []string{kafka_nais_io_v1.Finalizer}, actual.Finalizers)
+}
+
+func TestReconcileNotFound(t *testing.T) {
+	h := &hooks{}
+	r, _, _ := setup(t, h)
+	r.Resync = resync.NewPlan(time.Hour, 1)
+	assert.Equal(t, time.Duration(0), r.Resync.Delay(request.NamespacedName))
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 0, h.synchronized)
+	assert.InDelta(t, time.Hour, r.Resync.Delay(request.NamespacedName), float64(time.Minute), "slots of missing resources are released")
+}
+
+// Mimics the API server for resources without a status subresource.
+type noStatusClient struct {
+	client.Client
+}
+
+func (c noStatusClient) Status() client.StatusWriter {
+	return noStatusWriter{}
+}
+
+type noStatusWriter struct{}
+
+func (noStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return errors.NewNotFound(schema.GroupResource{Group: "kafka.nais.io", Resource: "topics/status"}, "mytopic")
+}
+
+func (noStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return errors.NewNotFound(schema.GroupResource{Group: "kafka.nais.io", Resource: "topics/status"}, "mytopic")
+}
+
+func TestReconcileStatusSubresource(t *testing.T) {
+	h := &hooks{}
+	r, cluster, _ := setup(t, h, topic())
+	r.Client = noStatusClient{Client: cluster.Client}
+
+	result, err := r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result, "status is written with a regular update")
+
+	actual := &kafka_nais_io_v1.Topic{ObjectMeta: topic().ObjectMeta}
+	cluster.Get(t, actual)
+	assert.Equal(t, events.Synchronized, actual.Status.SynchronizationState)
+
+	r.StatusSubresource = true
+	actual.Spec.Pool = "otherpool"
+	require.NoError(t, cluster.Client.Update(context.Background(), actual))
+	result, err = r.Reconcile(request)
+	require.NoError(t, err)
+	assert.Equal(t, reconciler.DefaultBaseDelay, result.RequeueAfter, "status subresource is used when enabled")
+}
@@ -0,0 +1,519 @@
+package render
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+const (
+	// Label identifying the Alert resource a Prometheus rule was rendered from.
+	AlertLabel = "alert"
+	// Label and annotation containing the severity of a Prometheus rule.
+	SeverityLabel = nais_io_v1.AlertSeverityLabel
+
+	// Severity used for rules that do not specify one.
+	DefaultSeverity = nais_io_v1.DefaultAlertSeverity
+	// Label by which Alertmanager groups notifications.
+	DefaultGroupBy = "alertname"
+
+	// Directory in which secrets referenced by receivers are mounted in the Alertmanager container.
+	DefaultSecretsDirectory = "/etc/alertmanager/secrets"
+
+	PrometheusRuleAPIVersion = "monitoring.coreos.com/v1"
+	PrometheusRuleKind       = "PrometheusRule"
+)
+
+// Templates used for Slack notifications. The prepend text of the Slack receiver is inserted in front of alerts with severity `danger`.
+const (
+	slackTitleTemplate = `{{ .CommonLabels.alertname }} ({{ .Status }})`
+	slackColorTemplate = `{{ (index .Alerts 0).Annotations.severity }}`
+	slackTextTemplate  = `{{ range .Alerts }}{{ if eq .Annotations.severity "danger" }}%s{{ end }}*{{ .Labels.alertname }}*
+{{ .Annotations.description }}
+{{ if .Annotations.action }}*Action:* {{ .Annotations.action }}
+{{ end }}{{ if .Annotations.documentation }}*Documentation:* {{ .Annotations.documentation }}
+{{ end }}{{ if .Annotations.sla }}*SLA:* {{ .Annotations.sla }}
+{{ end }}{{ end }}`
+)
+
+// AlertOptions contains cluster specific settings used when rendering an Alert.
+type AlertOptions struct {
+	// Webhook that delivers SMS notifications. SMS receivers are only rendered if this value is set.
+	SMSWebhookURL string
+	// Directory in which secrets are mounted as `<namespace>/<name>/<key>`. Defaults to DefaultSecretsDirectory.
+	// Credentials are referenced as files, and never inlined in the rendered configuration.
+	SecretsDirectory string
+}
+
+// PrometheusRule mirrors the Prometheus Operator resource of the same name.
+type PrometheusRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PrometheusRuleSpec `json:"spec"`
+}
+
+type PrometheusRuleSpec struct {
+	Groups []RuleGroup `json:"groups"`
+}
+
+type RuleGroup struct {
+	Name  string         `json:"name"`
+	Rules []AlertingRule `json:"rules"`
+}
+
+// AlertingRule is a single alerting rule in a rule group.
+type AlertingRule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AlertmanagerConfig is a fragment of the Alertmanager configuration file.
+// Fragments for several Alerts are merged by appending their routes, receivers and inhibit rules.
+type AlertmanagerConfig struct {
+	Route        AlertmanagerRoute         `json:"route"`
+	Receivers    []AlertmanagerReceiver    `json:"receivers"`
+	InhibitRules []AlertmanagerInhibitRule `json:"inhibit_rules,omitempty"`
+}
+
+type AlertmanagerRoute struct {
+	Receiver       string              `json:"receiver,omitempty"`
+	GroupBy        []string            `json:"group_by,omitempty"`
+	GroupWait      string              `json:"group_wait,omitempty"`
+	GroupInterval  string              `json:"group_interval,omitempty"`
+	RepeatInterval string              `json:"repeat_interval,omitempty"`
+	Match          map[string]string   `json:"match,omitempty"`
+	MatchRE        map[string]string   `json:"match_re,omitempty"`
+	Continue       bool                `json:"continue,omitempty"`
+	Routes         []AlertmanagerRoute `json:"routes,omitempty"`
+}
+
+type AlertmanagerReceiver struct {
+	Name             string            `json:"name"`
+	SlackConfigs     []SlackConfig     `json:"slack_configs,omitempty"`
+	EmailConfigs     []EmailConfig     `json:"email_configs,omitempty"`
+	WebhookConfigs   []WebhookConfig   `json:"webhook_configs,omitempty"`
+	MSTeamsConfigs   []MSTeamsConfig   `json:"msteams_configs,omitempty"`
+	PagerDutyConfigs []PagerDutyConfig `json:"pagerduty_configs,omitempty"`
+}
+
+type SlackConfig struct {
+	SendResolved bool   `json:"send_resolved"`
+	Channel      string `json:"channel"`
+	Username     string `json:"username,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	IconEmoji    string `json:"icon_emoji,omitempty"`
+	Title        string `json:"title,omitempty"`
+	Text         string `json:"text,omitempty"`
+	Color        string `json:"color,omitempty"`
+}
+
+type EmailConfig struct {
+	SendResolved bool   `json:"send_resolved"`
+	To           string `json:"to"`
+}
+
+type WebhookConfig struct {
+	SendResolved bool        `json:"send_resolved"`
+	URL          string      `json:"url"`
+	MaxAlerts    int         `json:"max_alerts,omitempty"`
+	HTTPConfig   *HTTPConfig `json:"http_config,omitempty"`
+}
+
+type HTTPConfig struct {
+	BasicAuth     *BasicAuth     `json:"basic_auth,omitempty"`
+	Authorization *Authorization `json:"authorization,omitempty"`
+}
+
+type BasicAuth struct {
+	Username     string `json:"username"`
+	PasswordFile string `json:"password_file"`
+}
+
+type Authorization struct {
+	Type            string `json:"type"`
+	CredentialsFile string `json:"credentials_file"`
+}
+
+type MSTeamsConfig struct {
+	SendResolved bool   `json:"send_resolved"`
+	WebhookURL   string `json:"webhook_url"`
+	Title        string `json:"title,omitempty"`
+}
+
+type PagerDutyConfig struct {
+	SendResolved   bool   `json:"send_resolved"`
+	RoutingKeyFile string `json:"routing_key_file"`
+	URL            string `json:"url,omitempty"`
+	Severity       string `json:"severity,omitempty"`
+}
+
+type AlertmanagerInhibitRule struct {
+	TargetMatch   map[string]string `json:"target_match,omitempty"`
+	TargetMatchRE map[string]string `json:"target_match_re,omitempty"`
+	SourceMatch   map[string]string `json:"source_match,omitempty"`
+	SourceMatchRE map[string]string `json:"source_match_re,omitempty"`
+	Equal         []string          `json:"equal,omitempty"`
+}
+
+// AlertTeam returns the team owning an Alert, which is the team owning its namespace. Team namespaces are named after their team.
+// The team label of the Alert is ignored, as it could name another team and scope the inhibit rules of the Alert to its alerts.
+func AlertTeam(alert *nais_io_v1.Alert) string {
+	return alert.Namespace
+}
+
+// AlertReceiverName returns the name of the Alertmanager receiver, route and rule group rendered from an Alert.
+func AlertReceiverName(alert *nais_io_v1.Alert) string {
+	return fmt.Sprintf("%s-%s", alert.Namespace, alert.Name)
+}
+
+// AdditionalReceiverName returns the name of the Alertmanager receiver rendered from one of the additional receivers of an Alert.
+func AdditionalReceiverName(alert *nais_io_v1.Alert, name string) string {
+	return fmt.Sprintf("%s-%s", AlertReceiverName(alert), name)
+}
+
+// AlertRules renders the Prometheus alerting rules of an Alert into a single rule group.
+// Every rule is labeled with the team and Alert name, so that the Alertmanager route rendered by AlertmanagerFragment matches it.
+func AlertRules(alert *nais_io_v1.Alert) *PrometheusRule {
+	rules := make([]AlertingRule, 0, len(alert.Spec.Alerts))
+	for _, rule := range alert.Spec.Alerts {
+		severity := rule.Severity
+		if len(severity) == 0 {
+			severity = DefaultSeverity
+		}
+		rules = append(rules, AlertingRule{
+			Alert: rule.Alert,
+			Expr:  rule.Expr,
+			For:   rule.For,
+			Labels: alertMatchLabels(alert, mergeLabels(rule.Labels, map[string]string{
+				SeverityLabel: severity,
+			})),
+			Annotations: nonEmpty(map[string]string{
+				"description":   rule.Description,
+				"action":        rule.Action,
+				"documentation": rule.Documentation,
+				"sla":           rule.SLA,
+				"priority":      rule.Priority,
+				SeverityLabel:   severity,
+			}),
+		})
+	}
+
+	team := AlertTeam(alert)
+	return &PrometheusRule{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       PrometheusRuleKind,
+			APIVersion: PrometheusRuleAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alert.Name,
+			Namespace: alert.Namespace,
+			Labels: map[string]string{
+				TeamLabel: team,
+			},
+			OwnerReferences: []metav1.OwnerReference{alertOwnerReference(alert)},
+		},
+		Spec: PrometheusRuleSpec{
+			Groups: []RuleGroup{
+				{
+					Name:  AlertReceiverName(alert),
+					Rules: rules,
+				},
+			},
+		},
+	}
+}
+
+// AlertmanagerFragment renders the route tree, receivers and inhibit rules of an Alert.
+// Child routes are nested below a route matching the alerts of the Alert, so they cannot match the alerts of others.
+// Inhibit rules are restricted to alerts from the same team, so that one team cannot mute the alerts of another.
+// An error is returned if a receiver refers to a secret outside of the namespace of the Alert.
+func AlertmanagerFragment(alert *nais_io_v1.Alert, opts AlertOptions) (*AlertmanagerConfig, error) {
+	name := AlertReceiverName(alert)
+	spec := alert.Spec
+
+	route := AlertmanagerRoute{
+		Receiver:       name,
+		GroupBy:        []string{DefaultGroupBy},
+		GroupWait:      spec.Route.GroupWait,
+		GroupInterval:  spec.Route.GroupInterval,
+		RepeatInterval: spec.Route.RepeatInterval,
+		Match:          alertMatchLabels(alert, nil),
+	}
+
+	for _, child := range spec.Route.Routes {
+		childRoute := alertmanagerChildRoute(alert, child.LeafRoute)
+		for _, leaf := range child.Routes {
+			childRoute.Routes = append(childRoute.Routes, alertmanagerChildRoute(alert, leaf))
+		}
+		route.Routes = append(route.Routes, childRoute)
+	}
+
+	receiver, err := alertmanagerReceiver(alert, name, spec.Receivers, opts)
+	if err != nil {
+		return nil, fmt.Errorf("receiver %s: %w", name, err)
+	}
+	receivers := []AlertmanagerReceiver{receiver}
+	for _, named := range spec.AdditionalReceivers {
+		receiverName := AdditionalReceiverName(alert, named.Name)
+		receiver, err := alertmanagerReceiver(alert, receiverName, named.Receivers, opts)
+		if err != nil {
+			return nil, fmt.Errorf("receiver %s: %w", receiverName, err)
+		}
+		receivers = append(receivers, receiver)
+	}
+
+	inhibitRules := make([]AlertmanagerInhibitRule, 0, len(spec.InhibitRules))
+	for _, inhibit := range spec.InhibitRules {
+		inhibitRules = append(inhibitRules, AlertmanagerInhibitRule{
+			TargetMatch:   teamMatch(alert, inhibit.Targets),
+			TargetMatchRE: inhibit.TargetsRegex,
+			SourceMatch:   teamMatch(alert, inhibit.Sources),
+			SourceMatchRE: inhibit.SourcesRegex,
+			Equal:         inhibit.Labels,
+		})
+	}
+
+	return &AlertmanagerConfig{
+		Route:        route,
+		Receivers:    receivers,
+		InhibitRules: inhibitRules,
+	}, nil
+}
+
+// Child routes without a receiver inherit the receiver of their parent in Alertmanager.
+func alertmanagerChildRoute(alert *nais_io_v1.Alert, route nais_io_v1.LeafRoute) AlertmanagerRoute {
+	receiver := ""
+	if len(route.Receiver) > 0 {
+		receiver = AdditionalReceiverName(alert, route.Receiver)
+	}
+	return AlertmanagerRoute{
+		Receiver:       receiver,
+		GroupWait:      route.GroupWait,
+		GroupInterval:  route.GroupInterval,
+		RepeatInterval: route.RepeatInterval,
+		Match:          route.Match,
+		MatchRE:        route.MatchRegex,
+		Continue:       route.Continue,
+	}
+}
+
+func alertmanagerReceiver(alert *nais_io_v1.Alert, name string, receivers nais_io_v1.Receivers, opts AlertOptions) (AlertmanagerReceiver, error) {
+	receiver := AlertmanagerReceiver{
+		Name: name,
+	}
+
+	if slack := receivers.Slack; len(slack.Channel) > 0 {
+		prependText := ""
+		if len(slack.PrependText) > 0 {
+			prependText = slack.PrependText + " "
+		}
+		receiver.SlackConfigs = []SlackConfig{
+			{
+				SendResolved: slack.SendResolved == nil || *slack.SendResolved,
+				Channel:      slackChannel(slack.Channel),
+				Username:     slack.Username,
+				IconURL:      slack.IconUrl,
+				IconEmoji:    slack.IconEmoji,
+				Title:        slackTitleTemplate,
+				Text:         fmt.Sprintf(slackTextTemplate, prependText),
+				Color:        slackColorTemplate,
+			},
+		}
+	}
+
+	if email := receivers.Email; len(email.To) > 0 {
+		receiver.EmailConfigs = []EmailConfig{
+			{
+				SendResolved: email.SendResolved,
+				To:           email.To,
+			},
+		}
+	}
+
+	if sms := receivers.SMS; len(sms.Recipients) > 0 && len(opts.SMSWebhookURL) > 0 {
+		receiver.WebhookConfigs = append(receiver.WebhookConfigs, WebhookConfig{
+			SendResolved: sms.SendResolved != nil && *sms.SendResolved,
+			URL:          smsWebhookURL(opts.SMSWebhookURL, sms.Recipients),
+		})
+	}
+
+	if webhook := receivers.Webhook; webhook != nil {
+		config := WebhookConfig{
+			SendResolved: webhook.SendResolved == nil || *webhook.SendResolved,
+			URL:          webhook.URL,
+			MaxAlerts:    webhook.MaxAlerts,
+		}
+		if webhook.BasicAuth != nil {
+			passwordFile, err := opts.secretFile(alert, webhook.BasicAuth.Password)
+			if err != nil {
+				return receiver, fmt.Errorf("webhook password: %w", err)
+			}
+			config.HTTPConfig = &HTTPConfig{
+				BasicAuth: &BasicAuth{
+					Username:     webhook.BasicAuth.Username,
+					PasswordFile: passwordFile,
+				},
+			}
+		} else if webhook.BearerToken != nil {
+			credentialsFile, err := opts.secretFile(alert, *webhook.BearerToken)
+			if err != nil {
+				return receiver, fmt.Errorf("webhook bearer token: %w", err)
+			}
+			config.HTTPConfig = &HTTPConfig{
+				Authorization: &Authorization{
+					Type:            "Bearer",
+					CredentialsFile: credentialsFile,
+				},
+			}
+		}
+		receiver.WebhookConfigs = append(receiver.WebhookConfigs, config)
+	}
+
+	if teams := receivers.Teams; teams != nil {
+		receiver.MSTeamsConfigs = []MSTeamsConfig{
+			{
+				SendResolved: teams.SendResolved == nil || *teams.SendResolved,
+				WebhookURL:   teams.URL,
+				Title:        teams.Title,
+			},
+		}
+	}
+
+	if pagerDuty := receivers.PagerDuty; pagerDuty != nil {
+		routingKeyFile, err := opts.secretFile(alert, pagerDuty.RoutingKey)
+		if err != nil {
+			return receiver, fmt.Errorf("pagerduty routing key: %w", err)
+		}
+		receiver.PagerDutyConfigs = []PagerDutyConfig{
+			{
+				SendResolved:   pagerDuty.SendResolved == nil || *pagerDuty.SendResolved,
+				RoutingKeyFile: routingKeyFile,
+				URL:            pagerDuty.URL,
+				Severity:       pagerDuty.Severity,
+			},
+		}
+	}
+
+	return receiver, nil
+}
+
+// AlertYAML renders an Alert and returns the Prometheus rules and the Alertmanager configuration fragment as YAML documents.
+func AlertYAML(alert *nais_io_v1.Alert, opts AlertOptions) (rules []byte, alertmanager []byte, err error) {
+	rules, err = yaml.Marshal(AlertRules(alert))
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal prometheus rules: %w", err)
+	}
+	fragment, err := AlertmanagerFragment(alert, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	alertmanager, err = yaml.Marshal(fragment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal alertmanager config: %w", err)
+	}
+	return rules, alertmanager, nil
+}
+
+// Path of a secret key mounted in the Alertmanager container. Secrets are looked up in the namespace of the Alert.
+// References that are not valid secret names and keys are rejected, and the path may never leave the directory
+// of the namespace, so that an Alert cannot send the secrets of another team to an endpoint of its choosing.
+func (in AlertOptions) secretFile(alert *nais_io_v1.Alert, ref nais_io_v1.SecretKeyReference) (string, error) {
+	dir := in.SecretsDirectory
+	if len(dir) == 0 {
+		dir = DefaultSecretsDirectory
+	}
+	if errs := validation.IsDNS1123Label(alert.Namespace); len(errs) > 0 {
+		return "", fmt.Errorf("invalid namespace %q: %s", alert.Namespace, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(ref.Name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid secret name %q: %s", ref.Name, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsConfigMapKey(ref.Key); len(errs) > 0 {
+		return "", fmt.Errorf("invalid secret key %q: %s", ref.Key, strings.Join(errs, ", "))
+	}
+
+	namespaceDir := path.Join(dir, alert.Namespace) + "/"
+	file := path.Join(namespaceDir, ref.Name, ref.Key)
+	if !strings.HasPrefix(file, namespaceDir) {
+		return "", fmt.Errorf("secret %s/%s resolves to %s, outside of %s", ref.Name, ref.Key, file, namespaceDir)
+	}
+	return file, nil
+}
+
+func alertOwnerReference(alert *nais_io_v1.Alert) metav1.OwnerReference {
+	ref := alert.GetOwnerReference()
+	ref.APIVersion = nais_io_v1.GroupVersion.String()
+	return ref
+}
+
+// Labels identifying the alerts of a single Alert resource, merged with extra labels.
+func alertMatchLabels(alert *nais_io_v1.Alert, extra map[string]string) map[string]string {
+	labels := map[string]string{
+		TeamLabel:  AlertTeam(alert),
+		AlertLabel: alert.Name,
+	}
+	for k, v := range extra {
+		labels[k] = v
+	}
+	return labels
+}
+
+func mergeLabels(base, overrides map[string]string) map[string]string {
+	labels := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		labels[k] = v
+	}
+	for k, v := range overrides {
+		labels[k] = v
+	}
+	return labels
+}
+
+func teamMatch(alert *nais_io_v1.Alert, match map[string]string) map[string]string {
+	result := make(map[string]string, len(match)+1)
+	for k, v := range match {
+		result[k] = v
+	}
+	result[TeamLabel] = AlertTeam(alert)
+	return result
+}
+
+func slackChannel(channel string) string {
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		return channel
+	}
+	return "#" + channel
+}
+
+// SMS recipients are passed to the webhook as a comma separated, sorted query parameter.
+func smsWebhookURL(base, recipients string) string {
+	numbers := strings.FieldsFunc(recipients, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	sort.Strings(numbers)
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "recipients=" + strings.Join(numbers, ",")
+}
+
+func nonEmpty(m map[string]string) map[string]string {
+	for k, v := range m {
+		if len(v) == 0 {
+			delete(m, k)
+		}
+	}
+	return m
+}
@@ -0,0 +1,213 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/nais/liberator/pkg/render"
+)
+
+func TestAlertRules(t *testing.T) {
+	alert := nais_io_v1.ExampleAlertForDocumentation()
+	alert.Spec.Alerts = append(alert.Spec.Alerts, nais_io_v1.Rule{
+		Alert: "no severity",
+		Expr:  "up == 0",
+		For:   "5m",
+	})
+
+	rules := render.AlertRules(alert)
+	assert.Equal(t, "PrometheusRule", rules.Kind)
+	assert.Equal(t, "myalert", rules.Name)
+	assert.Equal(t, "myteam", rules.Namespace)
+	assert.Equal(t, "myteam", rules.Labels[render.TeamLabel])
+	require.Len(t, rules.OwnerReferences, 1)
+	assert.Equal(t, "nais.io/v1", rules.OwnerReferences[0].APIVersion)
+
+	require.Len(t, rules.Spec.Groups, 1)
+	group := rules.Spec.Groups[0]
+	assert.Equal(t, "myteam-myalert", group.Name)
+	require.Len(t, group.Rules, 2)
+
+	rule := group.Rules[0]
+	assert.Equal(t, "applikasjon nede", rule.Alert)
+	assert.Equal(t, "2m", rule.For)
+	assert.Equal(t, map[string]string{
+		"team":      "myteam",
+		"alert":     "myalert",
+		"severity":  "danger",
+		"component": "postgres",
+	}, rule.Labels)
+	assert.Equal(t, "Mellom 8 og 16", rule.Annotations["sla"])
+	assert.Equal(t, "https://doc.nais.io/observability/alerts/", rule.Annotations["documentation"])
+
+	rule = group.Rules[1]
+	assert.Equal(t, render.DefaultSeverity, rule.Labels[render.SeverityLabel])
+	assert.NotContains(t, rule.Annotations, "description", "empty annotations are omitted")
+}
+
+func TestAlertmanagerFragment(t *testing.T) {
+	alert := nais_io_v1.ExampleAlertForDocumentation()
+
+	t.Run("without sms webhook", func(t *testing.T) {
+		config, err := render.AlertmanagerFragment(alert, render.AlertOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "myteam-myalert", config.Route.Receiver)
+		assert.Equal(t, "30s", config.Route.GroupWait)
+		assert.Equal(t, "5m", config.Route.GroupInterval)
+		assert.Equal(t, "3h", config.Route.RepeatInterval)
+		assert.Equal(t, map[string]string{"team": "myteam", "alert": "myalert"}, config.Route.Match)
+
+		require.Len(t, config.Receivers, 3)
+		receiver := config.Receivers[0]
+		assert.Equal(t, "myteam-myalert", receiver.Name)
+		require.Len(t, receiver.SlackConfigs, 1)
+		assert.Equal(t, "#alert-channel", receiver.SlackConfigs[0].Channel)
+		assert.True(t, receiver.SlackConfigs[0].SendResolved)
+		assert.Contains(t, receiver.SlackConfigs[0].Text, "Oh noes! ")
+		require.Len(t, receiver.EmailConfigs, 1)
+		assert.Equal(t, "myteam@nav.no", receiver.EmailConfigs[0].To)
+		require.Len(t, receiver.WebhookConfigs, 1)
+		webhook := receiver.WebhookConfigs[0]
+		assert.Equal(t, "https://oncall.example.com/api/alerts", webhook.URL)
+		assert.Equal(t, 10, webhook.MaxAlerts)
+		require.NotNil(t, webhook.HTTPConfig)
+		assert.Nil(t, webhook.HTTPConfig.Authorization)
+		assert.Equal(t, &render.BasicAuth{
+			Username:     "alertmanager",
+			PasswordFile: "/etc/alertmanager/secrets/myteam/oncall-credentials/password",
+		}, webhook.HTTPConfig.BasicAuth)
+		require.Len(t, receiver.MSTeamsConfigs, 1)
+		assert.Equal(t, "Alerts for myteam", receiver.MSTeamsConfigs[0].Title)
+		require.Len(t, receiver.PagerDutyConfigs, 1)
+		assert.Equal(t, render.PagerDutyConfig{
+			SendResolved:   true,
+			RoutingKeyFile: "/etc/alertmanager/secrets/myteam/pagerduty/routing-key",
+			URL:            "https://events.pagerduty.com/v2/enqueue",
+			Severity:       "critical",
+		}, receiver.PagerDutyConfigs[0])
+
+		assert.Equal(t, "myteam-myalert-on-call", config.Receivers[1].Name)
+		assert.Empty(t, config.Receivers[1].WebhookConfigs, "sms is not rendered without a webhook")
+		assert.Equal(t, "myteam-myalert-database", config.Receivers[2].Name)
+		require.Len(t, config.Receivers[2].SlackConfigs, 1)
+		assert.Equal(t, "#database-alerts", config.Receivers[2].SlackConfigs[0].Channel)
+
+		require.Len(t, config.InhibitRules, 1)
+		inhibit := config.InhibitRules[0]
+		assert.Equal(t, map[string]string{"key": "value", "team": "myteam"}, inhibit.TargetMatch)
+		assert.Equal(t, map[string]string{"key": "value", "team": "myteam"}, inhibit.SourceMatch)
+		assert.Equal(t, []string{"label", "lebal"}, inhibit.Equal)
+		assert.Equal(t, map[string]string{"key": "value"}, alert.Spec.InhibitRules[0].Targets, "input object is not modified")
+	})
+
+	t.Run("team label cannot scope inhibit rules to another team", func(t *testing.T) {
+		alert := alert.DeepCopy()
+		alert.Labels = map[string]string{"team": "otherteam"}
+		config, err := render.AlertmanagerFragment(alert, render.AlertOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "myteam", config.InhibitRules[0].TargetMatch["team"])
+		assert.Equal(t, "myteam", config.InhibitRules[0].SourceMatch["team"])
+		assert.Equal(t, "myteam", config.Route.Match["team"])
+	})
+
+	t.Run("with sms webhook", func(t *testing.T) {
+		alert := alert.DeepCopy()
+		alert.Spec.Receivers.SMS.Recipients = "87654321, 12345678"
+		config, err := render.AlertmanagerFragment(alert, render.AlertOptions{SMSWebhookURL: "http://sms/send?source=nais"})
+		require.NoError(t, err)
+		require.Len(t, config.Receivers[0].WebhookConfigs, 2)
+		require.Len(t, config.Receivers[1].WebhookConfigs, 1)
+		assert.Equal(t, "http://sms/send?source=nais&recipients=12345678", config.Receivers[1].WebhookConfigs[0].URL)
+		assert.Equal(t, "http://sms/send?source=nais&recipients=12345678,87654321", config.Receivers[0].WebhookConfigs[0].URL)
+		assert.False(t, config.Receivers[0].WebhookConfigs[0].SendResolved)
+	})
+
+	t.Run("route tree", func(t *testing.T) {
+		config, err := render.AlertmanagerFragment(alert, render.AlertOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []render.AlertmanagerRoute{
+			{
+				Receiver:       "myteam-myalert-on-call",
+				Match:          map[string]string{"severity": "danger"},
+				Continue:       true,
+				RepeatInterval: "1h",
+			},
+			{
+				Receiver: "myteam-myalert-database",
+				MatchRE:  map[string]string{"component": "postgres|redis"},
+				Routes: []render.AlertmanagerRoute{
+					{
+						Match:          map[string]string{"severity": "warning"},
+						RepeatInterval: "12h",
+					},
+				},
+			},
+		}, config.Route.Routes)
+	})
+
+	t.Run("webhook with bearer token", func(t *testing.T) {
+		alert := alert.DeepCopy()
+		alert.Spec.Receivers.Webhook.BasicAuth = nil
+		alert.Spec.Receivers.Webhook.BearerToken = &nais_io_v1.SecretKeyReference{Name: "oncall", Key: "token"}
+		config, err := render.AlertmanagerFragment(alert, render.AlertOptions{SecretsDirectory: "/secrets"})
+		require.NoError(t, err)
+		require.Len(t, config.Receivers[0].WebhookConfigs, 1)
+		httpConfig := config.Receivers[0].WebhookConfigs[0].HTTPConfig
+		require.NotNil(t, httpConfig)
+		assert.Nil(t, httpConfig.BasicAuth)
+		assert.Equal(t, &render.Authorization{
+			Type:            "Bearer",
+			CredentialsFile: "/secrets/myteam/oncall/token",
+		}, httpConfig.Authorization)
+	})
+
+	t.Run("secret outside of namespace", func(t *testing.T) {
+		for _, ref := range []nais_io_v1.SecretKeyReference{
+			{Name: "../otherteam/pagerduty", Key: "routing-key"},
+			{Name: "pagerduty", Key: "../../otherteam/pagerduty/routing-key"},
+			{Name: "pagerduty", Key: ".."},
+		} {
+			alert := alert.DeepCopy()
+			alert.Spec.Receivers.PagerDuty.RoutingKey = ref
+			_, err := render.AlertmanagerFragment(alert, render.AlertOptions{})
+			assert.Error(t, err, "%+v", ref)
+		}
+	})
+
+	t.Run("without optional receivers", func(t *testing.T) {
+		alert := alert.DeepCopy()
+		alert.Spec.Receivers.Webhook = nil
+		alert.Spec.Receivers.Teams = nil
+		alert.Spec.Receivers.PagerDuty = nil
+		config, err := render.AlertmanagerFragment(alert, render.AlertOptions{})
+		require.NoError(t, err)
+		receiver := config.Receivers[0]
+		assert.Empty(t, receiver.WebhookConfigs)
+		assert.Empty(t, receiver.MSTeamsConfigs)
+		assert.Empty(t, receiver.PagerDutyConfigs)
+	})
+}
+
+func TestAlertYAML(t *testing.T) {
+	alert := nais_io_v1.ExampleAlertForDocumentation()
+	rules, alertmanager, err := render.AlertYAML(alert, render.AlertOptions{})
+	require.NoError(t, err)
+
+	parsed := make(map[string]interface{})
+	require.NoError(t, yaml.Unmarshal(rules, &parsed))
+	assert.Equal(t, "monitoring.coreos.com/v1", parsed["apiVersion"])
+	assert.Contains(t, string(rules), "alert: applikasjon nede")
+
+	parsed = make(map[string]interface{})
+	require.NoError(t, yaml.Unmarshal(alertmanager, &parsed))
+	assert.Contains(t, parsed, "route")
+	assert.Contains(t, parsed, "receivers")
+	assert.Contains(t, parsed, "inhibit_rules")
+	assert.Contains(t, string(alertmanager), "repeat_interval: 3h")
+	assert.Contains(t, string(alertmanager), "password_file: /etc/alertmanager/secrets/myteam/oncall-credentials/password")
+	assert.NotContains(t, string(alertmanager), "routing_key:", "secrets are referenced by file")
+}
@@ -0,0 +1,399 @@
+package render
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// Secret name prefixes for credentials provisioned by other NAIS operators.
+const (
+	AzureSecretPrefix        = "azure"
+	TokenXSecretPrefix       = "tokenx"
+	IDPortenSecretPrefix     = "idporten"
+	MaskinportenSecretPrefix = "maskinporten"
+	KafkaSecretPrefix        = "kafka"
+)
+
+// Deployment renders the Deployment running the Application's container.
+// Defaults are expected to be applied to the Application beforehand.
+func Deployment(app *nais_io_v1alpha1.Application, opts Options) (*appsv1.Deployment, error) {
+	container, err := container(app, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	podSpec := corev1.PodSpec{
+		Containers:    []corev1.Container{*container},
+		RestartPolicy: corev1.RestartPolicyAlways,
+		DNSPolicy:     corev1.DNSClusterFirst,
+	}
+
+	podSpec = withFilesFrom(app, podSpec)
+	podSpec, err = withCredentials(app, podSpec)
+	if err != nil {
+		return nil, err
+	}
+	podSpec = withVault(app, opts, podSpec)
+
+	var replicas *int32
+	if app.Spec.Replicas != nil {
+		min := int32(app.Spec.Replicas.Min)
+		replicas = &min
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: objectMeta(app),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels(app),
+			},
+			Strategy: deploymentStrategy(app.Spec.Strategy),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        app.Name,
+					Namespace:   app.Namespace,
+					Labels:      labels(app),
+					Annotations: podAnnotations(app),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	return deployment, nil
+}
+
+func deploymentStrategy(strategy *nais_io_v1.Strategy) appsv1.DeploymentStrategy {
+	if strategy != nil && strategy.Type == nais_io_v1alpha1.DeploymentStrategyRecreate {
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	}
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+			MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+		},
+	}
+}
+
+func podAnnotations(app *nais_io_v1alpha1.Application) map[string]string {
+	annotations := make(map[string]string)
+
+	if app.Spec.Prometheus != nil && app.Spec.Prometheus.Enabled {
+		port := app.Spec.Prometheus.Port
+		if len(port) == 0 {
+			port = fmt.Sprintf("%d", app.Spec.Port)
+		}
+		annotations["prometheus.io/scrape"] = "true"
+		annotations["prometheus.io/port"] = port
+		annotations["prometheus.io/path"] = app.Spec.Prometheus.Path
+	}
+
+	if len(app.Spec.Logformat) > 0 {
+		annotations["nais.io/logformat"] = app.Spec.Logformat
+	}
+	if len(app.Spec.Logtransform) > 0 {
+		annotations["nais.io/logtransform"] = app.Spec.Logtransform
+	}
+
+	return annotations
+}
+
+func container(app *nais_io_v1alpha1.Application, opts Options) (*corev1.Container, error) {
+	resources, err := resourceRequirements(app.Spec.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "NAIS_APP_NAME", Value: app.Name},
+		{Name: "NAIS_NAMESPACE", Value: app.Namespace},
+		{Name: "NAIS_APP_IMAGE", Value: app.Spec.Image},
+		{Name: "NAIS_CLUSTER_NAME", Value: opts.ClusterName},
+		{Name: "NAIS_CLIENT_ID", Value: app.ClientID(opts.ClusterName)},
+	}
+	env = append(env, app.Spec.Env.ToKubernetes()...)
+
+	c := &corev1.Container{
+		Name:    app.Name,
+		Image:   app.Spec.Image,
+		Command: app.Spec.Command,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          nais_io_v1alpha1.DefaultPortName,
+				ContainerPort: int32(app.Spec.Port),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env:             env,
+		EnvFrom:         envFrom(app.Spec.EnvFrom),
+		Resources:       resources,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		LivenessProbe:   probe(app.Spec.Liveness, app.Spec.Port),
+		ReadinessProbe:  probe(app.Spec.Readiness, app.Spec.Port),
+		StartupProbe:    probe(app.Spec.Startup, app.Spec.Port),
+		Lifecycle:       lifecycle(app),
+	}
+
+	return c, nil
+}
+
+func envFrom(sources []nais_io_v1.EnvFrom) []corev1.EnvFromSource {
+	result := make([]corev1.EnvFromSource, 0, len(sources))
+	for _, source := range sources {
+		switch {
+		case len(source.ConfigMap) > 0:
+			result = append(result, corev1.EnvFromSource{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: source.ConfigMap},
+				},
+			})
+		case len(source.Secret) > 0:
+			result = append(result, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: source.Secret},
+				},
+			})
+		}
+	}
+	return result
+}
+
+func resourceRequirements(requirements *nais_io_v1.ResourceRequirements) (corev1.ResourceRequirements, error) {
+	var err error
+	result := corev1.ResourceRequirements{}
+	if requirements == nil {
+		return result, nil
+	}
+	result.Limits, err = resourceList(requirements.Limits)
+	if err != nil {
+		return result, fmt.Errorf("resource limits: %w", err)
+	}
+	result.Requests, err = resourceList(requirements.Requests)
+	if err != nil {
+		return result, fmt.Errorf("resource requests: %w", err)
+	}
+	return result, nil
+}
+
+func resourceList(spec *nais_io_v1.ResourceSpec) (corev1.ResourceList, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	list := corev1.ResourceList{}
+	if len(spec.Cpu) > 0 {
+		q, err := resource.ParseQuantity(spec.Cpu)
+		if err != nil {
+			return nil, fmt.Errorf("cpu: %w", err)
+		}
+		list[corev1.ResourceCPU] = q
+	}
+	if len(spec.Memory) > 0 {
+		q, err := resource.ParseQuantity(spec.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		list[corev1.ResourceMemory] = q
+	}
+	return list, nil
+}
+
+// Probes without a path are not rendered.
+func probe(p *nais_io_v1.Probe, defaultPort int) *corev1.Probe {
+	if p == nil || len(p.Path) == 0 {
+		return nil
+	}
+	port := p.Port
+	if port == 0 {
+		port = defaultPort
+	}
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: p.Path,
+				Port: intstr.FromInt(port),
+			},
+		},
+		InitialDelaySeconds: int32(p.InitialDelay),
+		PeriodSeconds:       int32(p.PeriodSeconds),
+		FailureThreshold:    int32(p.FailureThreshold),
+		TimeoutSeconds:      int32(p.Timeout),
+	}
+}
+
+func lifecycle(app *nais_io_v1alpha1.Application) *corev1.Lifecycle {
+	hook := app.Spec.PreStopHook
+	switch {
+	case hook != nil && hook.Exec != nil:
+		return &corev1.Lifecycle{
+			PreStop: &corev1.Handler{
+				Exec: &corev1.ExecAction{Command: hook.Exec.Command},
+			},
+		}
+	case hook != nil && hook.Http != nil:
+		port := app.Spec.Port
+		if hook.Http.Port != nil {
+			port = *hook.Http.Port
+		}
+		return &corev1.Lifecycle{
+			PreStop: &corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: hook.Http.Path,
+					Port: intstr.FromInt(port),
+				},
+			},
+		}
+	case len(app.Spec.PreStopHookPath) > 0:
+		return &corev1.Lifecycle{
+			PreStop: &corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: app.Spec.PreStopHookPath,
+					Port: intstr.FromString(nais_io_v1alpha1.DefaultPortName),
+				},
+			},
+		}
+	}
+	return nil
+}
+
+func withFilesFrom(app *nais_io_v1alpha1.Application, podSpec corev1.PodSpec) corev1.PodSpec {
+	for _, files := range app.Spec.FilesFrom {
+		switch {
+		case len(files.ConfigMap) > 0:
+			mountPath := files.MountPath
+			if len(mountPath) == 0 {
+				mountPath = nais_io_v1alpha1.GetDefaultMountPath(files.ConfigMap)
+			}
+			podSpec = withVolume(podSpec, corev1.Volume{
+				Name: files.ConfigMap,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: files.ConfigMap},
+					},
+				},
+			}, mountPath)
+		case len(files.Secret) > 0:
+			mountPath := files.MountPath
+			if len(mountPath) == 0 {
+				mountPath = nais_io_v1alpha1.DefaultSecretMountPath
+			}
+			podSpec = withSecretVolume(podSpec, files.Secret, mountPath)
+		}
+	}
+	return podSpec
+}
+
+func withCredentials(app *nais_io_v1alpha1.Application, podSpec corev1.PodSpec) (corev1.PodSpec, error) {
+	spec := app.Spec
+	type credential struct {
+		enabled   bool
+		prefix    string
+		mountPath string
+		filesOnly bool
+	}
+	credentials := []credential{
+		{
+			enabled:   spec.Azure != nil && spec.Azure.Application != nil && spec.Azure.Application.Enabled,
+			prefix:    AzureSecretPrefix,
+			mountPath: nais_io_v1alpha1.DefaultAzureratorMountPath,
+		},
+		{
+			enabled:   spec.TokenX != nil && spec.TokenX.Enabled,
+			prefix:    TokenXSecretPrefix,
+			mountPath: nais_io_v1alpha1.DefaultJwkerMountPath,
+			filesOnly: spec.TokenX != nil && spec.TokenX.MountSecretsAsFilesOnly,
+		},
+		{
+			enabled:   spec.IDPorten != nil && spec.IDPorten.Enabled,
+			prefix:    IDPortenSecretPrefix,
+			mountPath: nais_io_v1alpha1.DefaultDigdiratorIDPortenMountPath,
+		},
+		{
+			enabled:   spec.Maskinporten != nil && spec.Maskinporten.Enabled,
+			prefix:    MaskinportenSecretPrefix,
+			mountPath: nais_io_v1alpha1.DefaultDigdiratorMaskinportenMountPath,
+		},
+		{
+			enabled:   spec.Kafka != nil && len(spec.Kafka.Pool) > 0,
+			prefix:    KafkaSecretPrefix,
+			mountPath: nais_io_v1alpha1.DefaultKafkaratorMountPath,
+		},
+	}
+
+	for _, cred := range credentials {
+		if !cred.enabled {
+			continue
+		}
+		name, err := secretName(cred.prefix, app)
+		if err != nil {
+			return podSpec, err
+		}
+		podSpec = withSecretVolume(podSpec, name, cred.mountPath)
+		if !cred.filesOnly {
+			podSpec.Containers[0].EnvFrom = append(podSpec.Containers[0].EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			})
+		}
+	}
+
+	return podSpec, nil
+}
+
+// Vault secrets are fetched into shared in-memory volumes by a sidecar, which is not rendered.
+func withVault(app *nais_io_v1alpha1.Application, opts Options, podSpec corev1.PodSpec) corev1.PodSpec {
+	if len(opts.VaultKVBasePath) == 0 || app.Spec.Vault == nil || !app.Spec.Vault.Enabled {
+		return podSpec
+	}
+
+	paths := append([]nais_io_v1.SecretPath{app.DefaultSecretPath(opts.VaultKVBasePath)}, app.Spec.Vault.Paths...)
+	for i, path := range paths {
+		podSpec = withVolume(podSpec, corev1.Volume{
+			Name: fmt.Sprintf("vault-volume-%d", i),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		}, path.MountPath)
+	}
+
+	return podSpec
+}
+
+func withSecretVolume(podSpec corev1.PodSpec, name, mountPath string) corev1.PodSpec {
+	return withVolume(podSpec, corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: name,
+			},
+		},
+	}, mountPath)
+}
+
+func withVolume(podSpec corev1.PodSpec, volume corev1.Volume, mountPath string) corev1.PodSpec {
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      volume.Name,
+		ReadOnly:  volume.EmptyDir == nil,
+		MountPath: mountPath,
+	})
+	return podSpec
+}
@@ -0,0 +1,49 @@
+package render
+
+import (
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// HorizontalPodAutoscaler renders the autoscaler scaling the Application's Deployment on CPU usage.
+// Defaults are expected to be applied to the Application beforehand.
+// An error is returned if the replica settings do not describe a valid autoscaler, e.g. if min is greater than max.
+func HorizontalPodAutoscaler(app *nais_io_v1alpha1.Application, opts Options) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	path := field.NewPath("spec", "replicas")
+	replicas := app.Spec.Replicas
+	if replicas == nil {
+		return nil, field.Required(path, "defaults must be applied before rendering")
+	}
+	if replicas.Max < 1 {
+		return nil, field.Invalid(path.Child("max"), replicas.Max, "must be greater than or equal to 1")
+	}
+	if replicas.Min > replicas.Max {
+		return nil, field.Invalid(path.Child("min"), replicas.Min, fmt.Sprintf("must be less than or equal to max (%d)", replicas.Max))
+	}
+
+	minReplicas := int32(replicas.Min)
+	threshold := int32(replicas.CpuThresholdPercentage)
+
+	return &autoscalingv1.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v1",
+		},
+		ObjectMeta: objectMeta(app),
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       app.Name,
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    int32(replicas.Max),
+			TargetCPUUtilizationPercentage: &threshold,
+		},
+	}, nil
+}
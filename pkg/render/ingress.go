@@ -0,0 +1,69 @@
+package render
+
+import (
+	"fmt"
+	"net/url"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// Ingress renders an Ingress routing traffic from the Application's ingress URLs to its Service.
+// Returns nil if the Application has no ingresses.
+func Ingress(app *nais_io_v1alpha1.Application) (*networkingv1beta1.Ingress, error) {
+	if len(app.Spec.Ingresses) == 0 {
+		return nil, nil
+	}
+
+	servicePort := int32(nais_io_v1alpha1.DefaultServicePort)
+	if app.Spec.Service != nil {
+		servicePort = app.Spec.Service.Port
+	}
+
+	rules := make([]networkingv1beta1.IngressRule, 0, len(app.Spec.Ingresses))
+	for _, ingress := range app.Spec.Ingresses {
+		u, err := url.Parse(string(ingress))
+		if err != nil {
+			return nil, fmt.Errorf("parse ingress %q: %w", ingress, err)
+		}
+		if len(u.Hostname()) == 0 {
+			return nil, fmt.Errorf("ingress %q has no host name", ingress)
+		}
+
+		path := u.Path
+		if len(path) == 0 {
+			path = "/"
+		}
+
+		rules = append(rules, networkingv1beta1.IngressRule{
+			Host: u.Hostname(),
+			IngressRuleValue: networkingv1beta1.IngressRuleValue{
+				HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+					Paths: []networkingv1beta1.HTTPIngressPath{
+						{
+							Path: path,
+							Backend: networkingv1beta1.IngressBackend{
+								ServiceName: app.Name,
+								ServicePort: intstr.FromInt(int(servicePort)),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return &networkingv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1beta1",
+		},
+		ObjectMeta: objectMeta(app),
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: rules,
+		},
+	}, nil
+}
@@ -0,0 +1,29 @@
+package render
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// NamespaceLabel is the label used to select namespaces by name in network policies.
+const NamespaceLabel = accesspolicy.NamespaceLabel
+
+// NetworkPolicy renders a NetworkPolicy allowing traffic to and from the applications
+// listed in the Application's access policy, and from the ingress gateway if the Application has ingresses.
+// Rules targeting other clusters are ignored.
+func NetworkPolicy(app *nais_io_v1alpha1.Application, opts Options) *networkingv1.NetworkPolicy {
+	workload := accesspolicy.Workload{
+		Name:       app.Name,
+		Namespace:  app.Namespace,
+		ObjectMeta: objectMeta(app),
+		Exposed:    len(app.Spec.Ingresses) > 0,
+	}
+	return accesspolicy.NetworkPolicy(workload, app.Spec.AccessPolicy, accesspolicy.Options{
+		ClusterName:         opts.ClusterName,
+		EgressPeers:         opts.EgressPeers,
+		IngressPeers:        opts.IngressPeers,
+		IngressGatewayPeers: opts.IngressGatewayPeers,
+	})
+}
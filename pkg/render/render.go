@@ -0,0 +1,133 @@
+// Package render maps NAIS resources, such as Applications and Alerts, to the objects they imply.
+//
+// The output is a best-effort description of desired state, suitable for offline diffing and linting.
+// Operator specific side effects, such as Vault sidecars or credential rotation, are not rendered.
+//
+// Application applies the built-in and configured defaults before rendering. The functions rendering a single
+// object, such as Deployment and HorizontalPodAutoscaler, expect defaults to be applied to their input beforehand.
+package render
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/namegen"
+)
+
+const (
+	AppLabel  = "app"
+	TeamLabel = "team"
+)
+
+// Options contains cluster specific settings used when rendering an Application.
+type Options struct {
+	// Name of the cluster the Application is rendered for.
+	ClusterName string
+	// Defaults profiles, ordered from least to most specific, that are layered on top of the built-in defaults.
+	DefaultsProfiles []*nais_io_v1.DefaultsProfile
+	// Base path in the Vault key/value store, used to compute the default secret path.
+	// Vault volumes are only rendered if this value is set.
+	VaultKVBasePath string
+	// Render NetworkPolicy resources from the Application's access policy.
+	NetworkPolicy bool
+	// Peers every application may reach, such as a web proxy. The cluster DNS service is always reachable.
+	EgressPeers []accesspolicy.EgressPeer
+	// Peers that may reach every application, such as Prometheus.
+	IngressPeers []accesspolicy.IngressPeer
+	// Peers that may reach applications with ingresses, such as the ingress gateway.
+	IngressGatewayPeers []accesspolicy.IngressPeer
+}
+
+// Application returns the Kubernetes objects implied by an Application, after applying defaults.
+// The input object is not modified.
+func Application(source *nais_io_v1alpha1.Application, opts Options) ([]runtime.Object, error) {
+	app, err := withDefaults(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]runtime.Object, 0)
+
+	deployment, err := Deployment(app, opts)
+	if err != nil {
+		return nil, fmt.Errorf("render deployment: %w", err)
+	}
+	objects = append(objects, deployment)
+	objects = append(objects, Service(app))
+
+	hpa, err := HorizontalPodAutoscaler(app, opts)
+	if err != nil {
+		return nil, fmt.Errorf("render horizontal pod autoscaler: %w", err)
+	}
+	objects = append(objects, hpa)
+
+	ingress, err := Ingress(app)
+	if err != nil {
+		return nil, fmt.Errorf("render ingress: %w", err)
+	}
+	if ingress != nil {
+		objects = append(objects, ingress)
+	}
+
+	if opts.NetworkPolicy {
+		objects = append(objects, NetworkPolicy(app, opts))
+	}
+
+	return objects, nil
+}
+
+// Returns a copy of the Application with defaults from the built-in and configured profiles applied.
+func withDefaults(source *nais_io_v1alpha1.Application, opts Options) (*nais_io_v1alpha1.Application, error) {
+	app := source.DeepCopy()
+	err := app.ApplyDefaults(opts.DefaultsProfiles...)
+	if err != nil {
+		return nil, fmt.Errorf("apply defaults: %w", err)
+	}
+	return app, nil
+}
+
+func objectMeta(app *nais_io_v1alpha1.Application) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            app.Name,
+		Namespace:       app.Namespace,
+		Labels:          labels(app),
+		OwnerReferences: []metav1.OwnerReference{ownerReference(app)},
+	}
+}
+
+func ownerReference(app *nais_io_v1alpha1.Application) metav1.OwnerReference {
+	ref := app.GetOwnerReference()
+	ref.APIVersion = nais_io_v1alpha1.GroupVersion.String()
+	return ref
+}
+
+func labels(app *nais_io_v1alpha1.Application) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range app.Labels {
+		labels[k] = v
+	}
+	labels[AppLabel] = app.Name
+	labels[TeamLabel] = app.Namespace
+	return labels
+}
+
+func selectorLabels(app *nais_io_v1alpha1.Application) map[string]string {
+	return map[string]string{
+		AppLabel: app.Name,
+	}
+}
+
+// Deterministic name for a secret provisioned on behalf of the application.
+func secretName(prefix string, app *nais_io_v1alpha1.Application) (string, error) {
+	name, err := namegen.ShortName(fmt.Sprintf("%s-%s", prefix, app.Name), validation.DNS1035LabelMaxLength)
+	if err != nil {
+		return "", fmt.Errorf("generate %s secret name: %w", prefix, err)
+	}
+	return name, nil
+}
@@ -0,0 +1,147 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nais/liberator/pkg/accesspolicy"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/render"
+)
+
+func application() *nais_io_v1alpha1.Application {
+	return &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp",
+			Namespace: "myteam",
+		},
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			Image:     "ghcr.io/navikt/myapp:1",
+			Ingresses: []nais_io_v1.Ingress{"https://myapp.nav.no/api"},
+			Liveness:  &nais_io_v1.Probe{Path: "/isalive"},
+			Resources: &nais_io_v1.ResourceRequirements{
+				Limits: &nais_io_v1.ResourceSpec{Cpu: "500m", Memory: "512Mi"},
+			},
+			AccessPolicy: &nais_io_v1.AccessPolicy{
+				Inbound: &nais_io_v1.AccessPolicyInbound{
+					Rules: []nais_io_v1.AccessPolicyInboundRule{
+						{AccessPolicyRule: nais_io_v1.AccessPolicyRule{Application: "frontend"}},
+						{AccessPolicyRule: nais_io_v1.AccessPolicyRule{Application: "remote", Cluster: "other-cluster"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplication(t *testing.T) {
+	app := application()
+	gateway := accesspolicy.IngressPeer{
+		Peer: networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{render.NamespaceLabel: "istio-system"}},
+		},
+	}
+	objects, err := render.Application(app, render.Options{
+		ClusterName:         "test-cluster",
+		NetworkPolicy:       true,
+		IngressGatewayPeers: []accesspolicy.IngressPeer{gateway},
+	})
+	require.NoError(t, err)
+	require.Len(t, objects, 5)
+	assert.Nil(t, app.Spec.Replicas, "input object is not modified")
+
+	deployment := objects[0].(*appsv1.Deployment)
+	assert.Equal(t, "myapp", deployment.Name)
+	assert.Equal(t, "myteam", deployment.Labels[render.TeamLabel])
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.Len(t, deployment.OwnerReferences, 1)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, app.Spec.Image, container.Image)
+	assert.Equal(t, int32(nais_io_v1alpha1.DefaultAppPort), container.Ports[0].ContainerPort)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "NAIS_CLIENT_ID", Value: "test-cluster:myteam:myapp"})
+	assert.Equal(t, "/isalive", container.LivenessProbe.HTTPGet.Path)
+	assert.Nil(t, container.ReadinessProbe)
+	assert.Equal(t, "500m", container.Resources.Limits.Cpu().String())
+
+	service := objects[1].(*corev1.Service)
+	assert.Equal(t, int32(nais_io_v1alpha1.DefaultServicePort), service.Spec.Ports[0].Port)
+	assert.Equal(t, map[string]string{render.AppLabel: "myapp"}, service.Spec.Selector)
+
+	hpa := objects[2].(*autoscalingv1.HorizontalPodAutoscaler)
+	assert.Equal(t, int32(4), hpa.Spec.MaxReplicas)
+
+	ingress := objects[3].(*networkingv1beta1.Ingress)
+	assert.Equal(t, "myapp.nav.no", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/api", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+
+	netpol := objects[4].(*networkingv1.NetworkPolicy)
+	require.Len(t, netpol.Spec.Ingress, 2)
+	require.Len(t, netpol.Spec.Ingress[0].From, 1, "rules for other clusters are ignored")
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{gateway.Peer}, netpol.Spec.Ingress[1].From, "application with ingresses is reachable from the gateway")
+	assert.Equal(t, "frontend", netpol.Spec.Ingress[0].From[0].PodSelector.MatchLabels[render.AppLabel])
+	assert.Equal(t, "myteam", netpol.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels[accesspolicy.NamespaceLabel])
+	require.Len(t, netpol.Spec.Egress, 1, "DNS lookups are allowed without outbound rules")
+	assert.Equal(t, accesspolicy.DNSEgressPeer.Peer, netpol.Spec.Egress[0].To[0])
+}
+
+func TestHorizontalPodAutoscaler(t *testing.T) {
+	app := application()
+	app.Spec.Replicas = &nais_io_v1.Replicas{Max: 6}
+	require.NoError(t, app.ApplyDefaults())
+
+	hpa, err := render.HorizontalPodAutoscaler(app, render.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas, "default minimum is applied")
+	assert.Equal(t, int32(6), hpa.Spec.MaxReplicas)
+	assert.Equal(t, int32(50), *hpa.Spec.TargetCPUUtilizationPercentage)
+
+	app.Spec.Replicas = nil
+	_, err = render.HorizontalPodAutoscaler(app, render.Options{})
+	assert.EqualError(t, err, "spec.replicas: Required value: defaults must be applied before rendering")
+
+	t.Run("minimum above maximum", func(t *testing.T) {
+		app := application()
+		app.Spec.Replicas = &nais_io_v1.Replicas{Min: 5, Max: 2}
+
+		_, err := render.Application(app, render.Options{})
+		assert.EqualError(t, err, "render horizontal pod autoscaler: spec.replicas.min: Invalid value: 5: must be less than or equal to max (2)")
+	})
+}
+
+func TestApplication_CredentialSecrets(t *testing.T) {
+	app := application()
+	app.Spec.TokenX = &nais_io_v1.TokenX{Enabled: true, MountSecretsAsFilesOnly: true}
+	app.Spec.Maskinporten = &nais_io_v1.Maskinporten{Enabled: true}
+
+	deployment, err := render.Deployment(app, render.Options{})
+	require.NoError(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	mounts := make(map[string]string)
+	for _, mount := range container.VolumeMounts {
+		mounts[mount.MountPath] = mount.Name
+	}
+	assert.Regexp(t, "^tokenx-myapp-[0-9a-f]{8}$", mounts[nais_io_v1alpha1.DefaultJwkerMountPath])
+	assert.Regexp(t, "^maskinporten-myapp-[0-9a-f]{8}$", mounts[nais_io_v1alpha1.DefaultDigdiratorMaskinportenMountPath])
+
+	require.Len(t, container.EnvFrom, 1, "tokenx secrets are mounted as files only")
+	assert.Equal(t, mounts[nais_io_v1alpha1.DefaultDigdiratorMaskinportenMountPath], container.EnvFrom[0].SecretRef.Name)
+}
+
+func TestApplication_InvalidResources(t *testing.T) {
+	app := application()
+	app.Spec.Resources.Limits.Memory = "lots"
+
+	_, err := render.Application(app, render.Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package render
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// Service renders the Service exposing the Application's container port inside the cluster.
+func Service(app *nais_io_v1alpha1.Application) *corev1.Service {
+	port := int32(nais_io_v1alpha1.DefaultServicePort)
+	name := nais_io_v1alpha1.DefaultPortName
+	if app.Spec.Service != nil {
+		port = app.Spec.Service.Port
+		if len(app.Spec.Service.Protocol) > 0 {
+			name = app.Spec.Service.Protocol
+		}
+	}
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: objectMeta(app),
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: selectorLabels(app),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       name,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       port,
+					TargetPort: intstr.FromString(nais_io_v1alpha1.DefaultPortName),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package resync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Plan spreads re-synchronization of many resources over time, so that a liberator upgrade
+// does not cause every resource in the cluster to be synchronized at once.
+//
+// Up to Burst resources are scheduled immediately, after which one resource is scheduled per Interval.
+// Each resource is given a fixed time slot when first seen, so repeated calls to Delay for the same
+// resource count down to its slot instead of pushing it further back.
+type Plan struct {
+	Interval time.Duration
+	Burst    int
+
+	lock      sync.Mutex
+	start     time.Time
+	slots     map[types.NamespacedName]time.Time
+	scheduled int
+}
+
+// Item is a resource and the time until it should be re-synchronized.
+type Item struct {
+	Key   types.NamespacedName
+	Delay time.Duration
+}
+
+func NewPlan(interval time.Duration, burst int) *Plan {
+	return &Plan{
+		Interval: interval,
+		Burst:    burst,
+	}
+}
+
+// Delay returns the time remaining until the resource may be re-synchronized.
+// Zero means that re-synchronization may happen now.
+func (p *Plan) Delay(key types.NamespacedName) time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	slot, ok := p.slots[key]
+	if !ok {
+		slot = p.nextSlot(now)
+		p.slots[key] = slot
+	}
+
+	delay := slot.Sub(now)
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// Done removes a resource from the plan once it has been re-synchronized.
+func (p *Plan) Done(key types.NamespacedName) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.slots, key)
+}
+
+// Schedule assigns a slot to each of the given resources, in the order of their namespace and name,
+// and returns the resulting schedule.
+func (p *Plan) Schedule(keys []types.NamespacedName) []Item {
+	sorted := make([]types.NamespacedName, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	items := make([]Item, len(sorted))
+	for i, key := range sorted {
+		items[i] = Item{
+			Key:   key,
+			Delay: p.Delay(key),
+		}
+	}
+	return items
+}
+
+// Slots are handed out in order: the first Burst at the start of the plan, then one per interval.
+// Once more than an interval has passed since the last slot, the plan starts over with a new burst.
+func (p *Plan) nextSlot(now time.Time) time.Time {
+	if p.slots == nil {
+		p.slots = make(map[types.NamespacedName]time.Time)
+	}
+
+	burst := p.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	if p.scheduled == 0 || p.slotTime(p.scheduled-1, burst).Add(p.Interval).Before(now) {
+		p.start = now
+		p.scheduled = 0
+	}
+	slot := p.slotTime(p.scheduled, burst)
+	p.scheduled++
+
+	return slot
+}
+
+func (p *Plan) slotTime(n, burst int) time.Time {
+	if n < burst {
+		return p.start
+	}
+	return p.start.Add(time.Duration(n-burst+1) * p.Interval)
+}
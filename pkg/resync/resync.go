@@ -0,0 +1,69 @@
+// Package resync decides when resources must be re-synchronized because liberator itself changed.
+//
+// Hash implementations and default values are versioned. Operators stamp the current version into
+// the status of every resource they synchronize, and use NeedsResync to find resources that were
+// synchronized by an older version of liberator whose output is no longer valid.
+package resync
+
+// Change describes a liberator release that changed one or more Hash() implementations or default values.
+type Change struct {
+	Version int
+	// Resources synchronized by a previous version must be re-synchronized, even if their hash is unchanged.
+	// Set to false for changes that do not alter the synchronized result.
+	Resync bool
+	// Human readable explanation of the change.
+	Description string
+}
+
+// History of hash and defaults changes, in ascending version order.
+//
+// When changing a Hash() implementation or the defaults applied to a resource, append a new entry
+// and decide whether existing resources must be re-synchronized.
+var History = []Change{
+	{
+		Version:     1,
+		Resync:      false,
+		Description: "Liberator version is stamped into status.",
+	},
+	{
+		Version:     2,
+		Resync:      false,
+		Description: "Alert receivers webhook, teams and pagerDuty are added, and defaulted only when configured. Alert.Hash is unchanged while they are unset.",
+	},
+	{
+		Version:     3,
+		Resync:      false,
+		Description: "Alert rule labels, child routes and additional receivers are added. Alert.Hash is unchanged while they are unset.",
+	},
+}
+
+// CurrentVersion is the version of the hash and defaults implementations in this release of liberator.
+var CurrentVersion = History[len(History)-1].Version
+
+// Versioned is implemented by statuses that record which liberator version synchronized the resource.
+type Versioned interface {
+	GetLiberatorVersion() int
+	SetLiberatorVersion(version int)
+}
+
+// NeedsResync returns true if a resource synchronized with the given liberator version must be re-synchronized,
+// i.e. if any later change in History requires it. Resources without a stamped version have version 0.
+func NeedsResync(version int) bool {
+	for _, change := range History {
+		if change.Version > version && change.Resync {
+			return true
+		}
+	}
+	return false
+}
+
+// Pending returns the changes between the given version and the current version.
+func Pending(version int) []Change {
+	var changes []Change
+	for _, change := range History {
+		if change.Version > version {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,88 @@
+package resync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nais/liberator/pkg/resync"
+)
+
+func withHistory(t *testing.T, history []resync.Change) {
+	original := resync.History
+	resync.History = history
+	t.Cleanup(func() {
+		resync.History = original
+	})
+}
+
+func TestNeedsResync(t *testing.T) {
+	withHistory(t, []resync.Change{
+		{Version: 1, Resync: false},
+		{Version: 2, Resync: true},
+		{Version: 3, Resync: false},
+	})
+
+	assert.True(t, resync.NeedsResync(0), "unstamped resources")
+	assert.True(t, resync.NeedsResync(1))
+	assert.False(t, resync.NeedsResync(2))
+	assert.False(t, resync.NeedsResync(3))
+
+	assert.Len(t, resync.Pending(0), 3)
+	assert.Len(t, resync.Pending(2), 1)
+	assert.Empty(t, resync.Pending(3))
+}
+
+func TestCurrentVersion(t *testing.T) {
+	assert.False(t, resync.NeedsResync(resync.CurrentVersion))
+	for i := 1; i < len(resync.History); i++ {
+		assert.Greater(t, resync.History[i].Version, resync.History[i-1].Version, "history must be in ascending version order")
+	}
+}
+
+func TestNeedsResync_VersionStamping(t *testing.T) {
+	withHistory(t, []resync.Change{
+		{Version: 1, Resync: false},
+	})
+	assert.False(t, resync.NeedsResync(0), "introducing version stamping does not trigger a resync")
+}
+
+func TestPlan(t *testing.T) {
+	plan := resync.NewPlan(time.Hour, 2)
+	keys := []types.NamespacedName{
+		{Namespace: "b", Name: "app"},
+		{Namespace: "a", Name: "app2"},
+		{Namespace: "a", Name: "app1"},
+		{Namespace: "c", Name: "app"},
+	}
+
+	items := plan.Schedule(keys)
+	assert.Len(t, items, 4)
+	assert.Equal(t, types.NamespacedName{Namespace: "a", Name: "app1"}, items[0].Key)
+	assert.Equal(t, types.NamespacedName{Namespace: "c", Name: "app"}, items[3].Key)
+
+	assert.Zero(t, items[0].Delay)
+	assert.Zero(t, items[1].Delay)
+	assert.InDelta(t, time.Hour, items[2].Delay, float64(time.Minute))
+	assert.InDelta(t, 2*time.Hour, items[3].Delay, float64(time.Minute))
+
+	t.Run("resources keep their slot", func(t *testing.T) {
+		delay := plan.Delay(items[3].Key)
+		assert.InDelta(t, 2*time.Hour, delay, float64(time.Minute))
+		assert.LessOrEqual(t, int64(delay), int64(items[3].Delay))
+	})
+
+	t.Run("finished resources are rescheduled at the end of the plan", func(t *testing.T) {
+		plan.Done(items[0].Key)
+		assert.InDelta(t, 3*time.Hour, plan.Delay(items[0].Key), float64(time.Minute))
+	})
+}
+
+func TestPlanRestart(t *testing.T) {
+	plan := resync.NewPlan(time.Millisecond, 1)
+	assert.Zero(t, plan.Delay(types.NamespacedName{Name: "first"}))
+	time.Sleep(5 * time.Millisecond)
+	assert.Zero(t, plan.Delay(types.NamespacedName{Name: "second"}), "drained plan starts over")
+}
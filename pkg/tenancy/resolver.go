@@ -0,0 +1,163 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Namespace tenancy is cached for this long by default.
+const DefaultTTL = 5 * time.Minute
+
+// Resolver looks up the tenancy of namespaces through the Kubernetes API, and caches the result.
+type Resolver struct {
+	Reader client.Reader
+	// Duration for which a lookup is cached. Defaults to DefaultTTL.
+	TTL time.Duration
+
+	lock  sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	namespace Namespace
+	expires   time.Time
+}
+
+// Maximum number of readers given a shared, cached resolver by IsTeamNamespace.
+// Operators typically use a single long-lived reader, such as the manager's client.
+const maxDefaultResolvers = 16
+
+var (
+	defaultResolversLock sync.Mutex
+	defaultResolvers     = make(map[client.Reader]*Resolver)
+)
+
+func NewResolver(reader client.Reader, ttl time.Duration) *Resolver {
+	return &Resolver{
+		Reader: reader,
+		TTL:    ttl,
+	}
+}
+
+// IsTeamNamespace returns true if the namespace exists and is owned by a team.
+// Lookups are cached per reader for DefaultTTL. Resolvers are kept for the lifetime of the process,
+// so only the first few readers are cached; use a Resolver directly with short-lived readers.
+func IsTeamNamespace(ctx context.Context, reader client.Reader, namespace string) (bool, error) {
+	return defaultResolver(reader).IsTeamNamespace(ctx, namespace)
+}
+
+// Namespace returns the tenancy of a namespace. Namespaces that do not exist are of KindUnknown.
+// Missing namespaces are not cached, so that they are recognized as soon as they are created.
+func (r *Resolver) Namespace(ctx context.Context, name string) (Namespace, error) {
+	now := time.Now()
+	if result, ok := r.cached(name, now); ok {
+		return result, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err := r.Reader.Get(ctx, types.NamespacedName{Name: name}, namespace)
+	switch {
+	case errors.IsNotFound(err):
+		return Namespace{Name: name, Kind: KindUnknown}, nil
+	case err != nil:
+		return Namespace{Name: name, Kind: KindUnknown}, fmt.Errorf("get namespace %s: %w", name, err)
+	}
+
+	result := Classify(*namespace)
+	r.store(name, result, now)
+	return result, nil
+}
+
+func (r *Resolver) cached(name string, now time.Time) (Namespace, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entry, ok := r.cache[name]
+	if !ok || !now.Before(entry.expires) {
+		return Namespace{}, false
+	}
+	return entry.namespace, true
+}
+
+// Expired entries are removed when storing, so that deleted namespaces do not accumulate.
+func (r *Resolver) store(name string, namespace Namespace, now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cache == nil {
+		r.cache = make(map[string]cacheEntry)
+	}
+	for key, entry := range r.cache {
+		if !now.Before(entry.expires) {
+			delete(r.cache, key)
+		}
+	}
+	r.cache[name] = cacheEntry{
+		namespace: namespace,
+		expires:   now.Add(r.ttl()),
+	}
+}
+
+// IsTeamNamespace returns true if the namespace exists and is owned by a team.
+func (r *Resolver) IsTeamNamespace(ctx context.Context, name string) (bool, error) {
+	namespace, err := r.Namespace(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return namespace.Kind == KindTeam, nil
+}
+
+// TeamOf returns the team owning an object, as determined by Owner from the tenancy of its namespace.
+// Returns an empty string if no team is known.
+func (r *Resolver) TeamOf(ctx context.Context, obj metav1.Object) (string, error) {
+	namespace, err := r.Namespace(ctx, obj.GetNamespace())
+	if err != nil {
+		return "", err
+	}
+	return Owner(namespace, obj)
+}
+
+// Invalidate removes a namespace from the cache, e.g. when the namespace is changed.
+func (r *Resolver) Invalidate(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.cache, name)
+}
+
+func (r *Resolver) ttl() time.Duration {
+	if r.TTL == 0 {
+		return DefaultTTL
+	}
+	return r.TTL
+}
+
+// Returns a shared resolver for the reader. Readers that cannot be used as map keys,
+// or that exceed maxDefaultResolvers, get a new resolver that is not shared.
+func defaultResolver(reader client.Reader) *Resolver {
+	if !reflect.TypeOf(reader).Comparable() {
+		return NewResolver(reader, DefaultTTL)
+	}
+
+	defaultResolversLock.Lock()
+	defer defaultResolversLock.Unlock()
+
+	resolver, ok := defaultResolvers[reader]
+	if ok {
+		return resolver
+	}
+	resolver = NewResolver(reader, DefaultTTL)
+	if len(defaultResolvers) < maxDefaultResolvers {
+		defaultResolvers[reader] = resolver
+	}
+	return resolver
+}
@@ -0,0 +1,114 @@
+// Package tenancy implements the NAIS tenancy model, in which every team owns one or more namespaces.
+//
+// A team namespace is a namespace carrying the `team` label, or the `nais.io/team` annotation, that is
+// neither a system namespace nor shared between teams. Operators use IsTeamNamespace to decide
+// whether to act on resources in a namespace.
+package tenancy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nais/liberator/pkg/kubernetes"
+)
+
+const (
+	// Label denoting the team that owns a namespace or an object.
+	TeamLabelKey = "team"
+	// Annotation denoting the team that owns a namespace, for namespaces where the label cannot be used.
+	TeamAnnotationKey = "nais.io/team"
+)
+
+// Kind classifies namespaces according to the tenancy model.
+type Kind string
+
+const (
+	// Owned by a single team.
+	KindTeam Kind = "team"
+	// Shared between teams, labeled with `shared=true`.
+	KindShared Kind = "shared"
+	// Runs Kubernetes or platform components.
+	KindSystem Kind = "system"
+	// Does not exist, or has no owner.
+	KindUnknown Kind = "unknown"
+)
+
+// SystemNamespaces are never team namespaces, regardless of their labels.
+var SystemNamespaces = []string{
+	"default",
+	"istio-system",
+	"kube-node-lease",
+	"kube-public",
+	"kube-system",
+	"nais",
+	"nais-system",
+}
+
+// Namespace describes the tenancy of a namespace.
+type Namespace struct {
+	Name string
+	Kind Kind
+	// Owning team of team namespaces. Empty for all other kinds.
+	Team string
+}
+
+// Classify determines the tenancy of a namespace.
+func Classify(namespace corev1.Namespace) Namespace {
+	result := Namespace{
+		Name: namespace.Name,
+		Kind: KindUnknown,
+	}
+
+	switch {
+	case isSystemNamespace(namespace.Name):
+		result.Kind = KindSystem
+	case namespace.Labels[kubernetes.SharedNamespaceLabelKey] == "true":
+		result.Kind = KindShared
+	default:
+		team := namespaceTeam(namespace)
+		if len(team) > 0 {
+			result.Kind = KindTeam
+			result.Team = team
+		}
+	}
+
+	return result
+}
+
+// ObjectTeam returns the team label of an object, or an empty string if the object is not labeled.
+// The label is not authoritative, as anyone creating the object can set it. Use Owner or Resolver.TeamOf instead.
+func ObjectTeam(obj metav1.Object) string {
+	return obj.GetLabels()[TeamLabelKey]
+}
+
+// Owner returns the team owning an object in the given namespace.
+// Objects in a team namespace are owned by that team, and an error is returned if their team label names another team.
+// In all other namespaces, objects are owned by the team in their team label, if any.
+func Owner(namespace Namespace, obj metav1.Object) (string, error) {
+	team := ObjectTeam(obj)
+	if namespace.Kind != KindTeam {
+		return team, nil
+	}
+	if len(team) > 0 && team != namespace.Team {
+		return "", fmt.Errorf("team label %q of %s/%s does not match team %q owning the namespace", team, obj.GetNamespace(), obj.GetName(), namespace.Team)
+	}
+	return namespace.Team, nil
+}
+
+func namespaceTeam(namespace corev1.Namespace) string {
+	if team := namespace.Labels[TeamLabelKey]; len(team) > 0 {
+		return team
+	}
+	return namespace.Annotations[TeamAnnotationKey]
+}
+
+func isSystemNamespace(name string) bool {
+	for _, system := range SystemNamespaces {
+		if name == system {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nais/liberator/pkg/tenancy"
+)
+
+// countingReader counts get requests, so that caching can be verified.
+type countingReader struct {
+	client.Reader
+	gets int
+}
+
+func (r *countingReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	r.gets++
+	return r.Reader.Get(ctx, key, obj)
+}
+
+func namespace(name string, labels, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace *corev1.Namespace
+		expected  tenancy.Namespace
+	}{
+		{
+			name:      "team label",
+			namespace: namespace("aura", map[string]string{"team": "aura"}, nil),
+			expected:  tenancy.Namespace{Name: "aura", Kind: tenancy.KindTeam, Team: "aura"},
+		},
+		{
+			name:      "team annotation",
+			namespace: namespace("aura", nil, map[string]string{"nais.io/team": "aura"}),
+			expected:  tenancy.Namespace{Name: "aura", Kind: tenancy.KindTeam, Team: "aura"},
+		},
+		{
+			name:      "label takes precedence over annotation",
+			namespace: namespace("aura", map[string]string{"team": "aura"}, map[string]string{"nais.io/team": "other"}),
+			expected:  tenancy.Namespace{Name: "aura", Kind: tenancy.KindTeam, Team: "aura"},
+		},
+		{
+			name:      "shared namespace",
+			namespace: namespace("shared", map[string]string{"shared": "true", "team": "aura"}, nil),
+			expected:  tenancy.Namespace{Name: "shared", Kind: tenancy.KindShared},
+		},
+		{
+			name:      "system namespace",
+			namespace: namespace("kube-system", map[string]string{"team": "aura"}, nil),
+			expected:  tenancy.Namespace{Name: "kube-system", Kind: tenancy.KindSystem},
+		},
+		{
+			name:      "no owner",
+			namespace: namespace("orphan", nil, nil),
+			expected:  tenancy.Namespace{Name: "orphan", Kind: tenancy.KindUnknown},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, tenancy.Classify(*test.namespace))
+		})
+	}
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	reader := &countingReader{
+		Reader: fake.NewFakeClientWithScheme(scheme.Scheme,
+			namespace("aura", map[string]string{"team": "aura"}, nil),
+			namespace("default", nil, nil),
+		),
+	}
+	resolver := tenancy.NewResolver(reader, time.Hour)
+
+	ok, err := resolver.IsTeamNamespace(ctx, "aura")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = resolver.IsTeamNamespace(ctx, "default")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = resolver.IsTeamNamespace(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 3, reader.gets)
+
+	t.Run("lookups are cached", func(t *testing.T) {
+		ok, err := resolver.IsTeamNamespace(ctx, "aura")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 3, reader.gets)
+
+		resolver.Invalidate("aura")
+		_, err = resolver.IsTeamNamespace(ctx, "aura")
+		require.NoError(t, err)
+		assert.Equal(t, 4, reader.gets)
+	})
+
+	t.Run("missing namespaces are not cached", func(t *testing.T) {
+		gets := reader.gets
+		ok, err := resolver.IsTeamNamespace(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, gets+1, reader.gets)
+
+		require.NoError(t, reader.Reader.(client.Client).Create(ctx, namespace("missing", map[string]string{"team": "missing"}, nil)))
+		ok, err = resolver.IsTeamNamespace(ctx, "missing")
+		require.NoError(t, err)
+		assert.True(t, ok, "namespace is recognized as soon as it is created")
+	})
+
+	t.Run("team of object", func(t *testing.T) {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "aura"}}
+		team, err := resolver.TeamOf(ctx, pod)
+		require.NoError(t, err)
+		assert.Equal(t, "aura", team)
+
+		pod.Labels = map[string]string{"team": "aura"}
+		team, err = resolver.TeamOf(ctx, pod)
+		require.NoError(t, err)
+		assert.Equal(t, "aura", team)
+
+		pod.Labels = map[string]string{"team": "other"}
+		_, err = resolver.TeamOf(ctx, pod)
+		assert.Error(t, err, "objects cannot claim another team than the one owning their namespace")
+
+		pod.Namespace = "default"
+		team, err = resolver.TeamOf(ctx, pod)
+		require.NoError(t, err)
+		assert.Equal(t, "other", team, "the label is used in namespaces without an owner")
+	})
+}
+
+// blockingReader blocks lookups of the namespace named "slow" until released.
+type blockingReader struct {
+	client.Reader
+	release chan struct{}
+	started chan struct{}
+}
+
+func (r *blockingReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if key.Name == "slow" {
+		close(r.started)
+		<-r.release
+	}
+	return r.Reader.Get(ctx, key, obj)
+}
+
+func TestResolver_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	reader := &blockingReader{
+		Reader:  fake.NewFakeClientWithScheme(scheme.Scheme, namespace("aura", map[string]string{"team": "aura"}, nil)),
+		release: make(chan struct{}),
+		started: make(chan struct{}),
+	}
+	resolver := tenancy.NewResolver(reader, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = resolver.Namespace(ctx, "slow")
+		close(done)
+	}()
+	<-reader.started
+
+	ok, err := resolver.IsTeamNamespace(ctx, "aura")
+	require.NoError(t, err)
+	assert.True(t, ok, "lookups are not blocked by other lookups in progress")
+
+	close(reader.release)
+	<-done
+}
+
+func TestIsTeamNamespace(t *testing.T) {
+	ctx := context.Background()
+	reader := &countingReader{
+		Reader: fake.NewFakeClientWithScheme(scheme.Scheme, namespace("aura", map[string]string{"team": "aura"}, nil)),
+	}
+
+	for i := 0; i < 3; i++ {
+		ok, err := tenancy.IsTeamNamespace(ctx, reader, "aura")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 1, reader.gets)
+}
@@ -0,0 +1,83 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nais/liberator/pkg/scheme"
+)
+
+// Get fetches the object with the name and namespace of obj into obj, failing the test if it does not exist.
+func (c *Cluster) Get(t testing.TB, obj runtime.Object) {
+	t.Helper()
+
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		t.Fatalf("object key: %s", err)
+	}
+	err = c.Client.Get(context.Background(), key, obj)
+	if err != nil {
+		t.Fatalf("get %s: %s", scheme.TypeName(obj), err)
+	}
+}
+
+// AssertExists checks that an object with the name and namespace of obj exists,
+// and returns true if it does. The object is updated with the current state of the cluster.
+func (c *Cluster) AssertExists(t testing.TB, obj runtime.Object) bool {
+	t.Helper()
+
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		t.Errorf("object key: %s", err)
+		return false
+	}
+	err = c.Client.Get(context.Background(), key, obj)
+	if err != nil {
+		t.Errorf("expected %s to exist: %s", scheme.TypeName(obj), err)
+		return false
+	}
+	return true
+}
+
+// AssertNotExists checks that no object with the name and namespace of obj exists.
+func (c *Cluster) AssertNotExists(t testing.TB, obj runtime.Object) bool {
+	t.Helper()
+
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		t.Errorf("object key: %s", err)
+		return false
+	}
+	err = c.Client.Get(context.Background(), key, obj.DeepCopyObject())
+	switch {
+	case errors.IsNotFound(err):
+		return true
+	case err != nil:
+		t.Errorf("get %s: %s", scheme.TypeName(obj), err)
+	default:
+		t.Errorf("expected %s to not exist", scheme.TypeName(obj))
+	}
+	return false
+}
+
+// AssertCount lists objects into list using the given options, and checks the number of items returned.
+func (c *Cluster) AssertCount(t testing.TB, list runtime.Object, expected int, opts ...client.ListOption) bool {
+	t.Helper()
+
+	err := c.Client.List(context.Background(), list, opts...)
+	if err != nil {
+		t.Errorf("list: %s", err)
+		return false
+	}
+	actual := meta.LenList(list)
+	if actual != expected {
+		t.Errorf("expected %d items in list, got %d", expected, actual)
+		return false
+	}
+	return true
+}
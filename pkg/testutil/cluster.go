@@ -0,0 +1,164 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/nais/liberator/pkg/crd"
+	"github.com/nais/liberator/pkg/scheme"
+)
+
+// Cluster is a Kubernetes API for use in tests, with all liberator CRDs installed.
+type Cluster struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	CRDs   []*apiextensionsv1beta1.CustomResourceDefinition
+}
+
+// NewFakeCluster returns an in-memory cluster backed by the controller-runtime fake client,
+// seeded with the given objects.
+func NewFakeCluster(t testing.TB, objects ...runtime.Object) *Cluster {
+	t.Helper()
+
+	cluster := newCluster(t)
+
+	seed := make([]runtime.Object, 0, len(cluster.CRDs)+len(objects))
+	for _, c := range cluster.CRDs {
+		seed = append(seed, c)
+	}
+	for _, obj := range objects {
+		seed = append(seed, obj.DeepCopyObject())
+	}
+	cluster.Client = fake.NewFakeClientWithScheme(cluster.Scheme, seed...)
+
+	return cluster
+}
+
+// NewEnvtestCluster starts a real API server using envtest, and seeds it with the given objects.
+// The API server is stopped when the test finishes.
+//
+// The test is skipped if the envtest binaries are not installed. Their location is configured
+// using the KUBEBUILDER_ASSETS environment variable, see the envtest documentation.
+func NewEnvtestCluster(t testing.TB, objects ...runtime.Object) *Cluster {
+	t.Helper()
+
+	if !envtestAvailable() {
+		t.Skip("envtest binaries not found; set KUBEBUILDER_ASSETS to run this test")
+	}
+
+	cluster := newCluster(t)
+
+	crds := make([]runtime.Object, len(cluster.CRDs))
+	for i := range cluster.CRDs {
+		crds[i] = cluster.CRDs[i].DeepCopy()
+	}
+	env := &envtest.Environment{
+		CRDs: crds,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("start envtest: %s", err)
+	}
+	t.Cleanup(func() {
+		err := env.Stop()
+		if err != nil {
+			t.Errorf("stop envtest: %s", err)
+		}
+	})
+
+	cluster.Client, err = client.New(cfg, client.Options{Scheme: cluster.Scheme})
+	if err != nil {
+		t.Fatalf("create client: %s", err)
+	}
+
+	ctx := context.Background()
+	for _, obj := range objects {
+		err = cluster.Client.Create(ctx, obj.DeepCopyObject())
+		if err != nil {
+			t.Fatalf("seed %s: %s", scheme.TypeName(obj), err)
+		}
+	}
+
+	return cluster
+}
+
+func newCluster(t testing.TB) *Cluster {
+	t.Helper()
+
+	sch, err := scheme.All()
+	if err != nil {
+		t.Fatalf("create scheme: %s", err)
+	}
+	err = apiextensionsv1beta1.AddToScheme(sch)
+	if err != nil {
+		t.Fatalf("create scheme: %s", err)
+	}
+
+	crds, err := CustomResourceDefinitions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Cluster{
+		Scheme: sch,
+		CRDs:   crds,
+	}
+}
+
+// CustomResourceDefinitions returns all CRDs shipped with liberator.
+func CustomResourceDefinitions() ([]*apiextensionsv1beta1.CustomResourceDefinition, error) {
+	crdScheme := runtime.NewScheme()
+	err := apiextensionsv1beta1.AddToScheme(crdScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := crd.YamlDirectory()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read CRD directory: %w", err)
+	}
+
+	crds := make([]*apiextensionsv1beta1.CustomResourceDefinition, 0, len(files))
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
+		objects, err := LoadFixtures(crdScheme, filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			c, ok := obj.(*apiextensionsv1beta1.CustomResourceDefinition)
+			if !ok {
+				return nil, fmt.Errorf("%s: not a v1beta1 CustomResourceDefinition", file.Name())
+			}
+			crds = append(crds, c)
+		}
+	}
+
+	return crds, nil
+}
+
+func envtestAvailable() bool {
+	if strings.EqualFold(os.Getenv("USE_EXISTING_CLUSTER"), "true") {
+		return true
+	}
+	assets := os.Getenv("KUBEBUILDER_ASSETS")
+	if len(assets) == 0 {
+		assets = "/usr/local/kubebuilder/bin"
+	}
+	_, err := os.Stat(filepath.Join(assets, "kube-apiserver"))
+	return err == nil
+}
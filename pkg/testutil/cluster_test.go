@@ -0,0 +1,72 @@
+package testutil_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kafka_nais_io_v1 "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/scheme"
+	"github.com/nais/liberator/pkg/testutil"
+)
+
+func TestNewFakeCluster(t *testing.T) {
+	sch, err := scheme.All()
+	require.NoError(t, err)
+
+	fixtures := testutil.MustLoadFixtures(sch, "testdata/fixtures.yaml")
+	require.Len(t, fixtures, 3)
+
+	cluster := testutil.NewFakeCluster(t, fixtures...)
+
+	crd := &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "applications.nais.io"},
+	}
+	cluster.AssertExists(t, crd)
+	assert.Equal(t, "Application", crd.Spec.Names.Kind)
+	cluster.AssertCount(t, &apiextensionsv1beta1.CustomResourceDefinitionList{}, len(cluster.CRDs))
+
+	app := &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam"},
+	}
+	cluster.Get(t, app)
+	assert.Equal(t, "ghcr.io/navikt/myapp:1", app.Spec.Image)
+
+	cluster.AssertExists(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "myteam"}})
+	cluster.AssertCount(t, &kafka_nais_io_v1.TopicList{}, 1, client.InNamespace("myteam"))
+
+	err = cluster.Client.Delete(context.Background(), app)
+	require.NoError(t, err)
+	cluster.AssertNotExists(t, app)
+}
+
+func TestNewFakeCluster_FixturesAreCopied(t *testing.T) {
+	app := &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam"},
+	}
+	cluster := testutil.NewFakeCluster(t, app)
+
+	app.Spec.Image = "changed"
+	fetched := &nais_io_v1alpha1.Application{ObjectMeta: app.ObjectMeta}
+	cluster.Get(t, fetched)
+	assert.Empty(t, fetched.Spec.Image)
+}
+
+func TestNewEnvtestCluster(t *testing.T) {
+	cluster := testutil.NewEnvtestCluster(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "myteam"}})
+
+	app := &nais_io_v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "myteam"},
+		Spec:       nais_io_v1alpha1.ApplicationSpec{Image: "ghcr.io/navikt/myapp:1"},
+	}
+	err := cluster.Client.Create(context.Background(), app)
+	require.NoError(t, err)
+	cluster.AssertExists(t, app)
+}
@@ -0,0 +1,65 @@
+package testutil
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// LoadFixtures decodes all objects in the given YAML or JSON files.
+// Files may contain multiple documents separated by `---`. All kinds must be registered in the scheme.
+func LoadFixtures(scheme *runtime.Scheme, paths ...string) ([]runtime.Object, error) {
+	objects := make([]runtime.Object, 0)
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := DecodeFixtures(scheme, file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// DecodeFixtures decodes all objects in a stream of YAML or JSON documents.
+func DecodeFixtures(scheme *runtime.Scheme, r io.Reader) ([]runtime.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	objects := make([]runtime.Object, 0)
+
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// MustLoadFixtures is like LoadFixtures, but panics on errors.
+func MustLoadFixtures(scheme *runtime.Scheme, paths ...string) []runtime.Object {
+	objects, err := LoadFixtures(scheme, paths...)
+	if err != nil {
+		panic(err)
+	}
+	return objects
+}
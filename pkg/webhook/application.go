@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+)
+
+// ApplicationValidatorPath is the path controller-runtime serves the Application validating webhook on.
+const ApplicationValidatorPath = "/validate-nais-io-v1alpha1-application"
+
+// IngressDomainValidatorPath is the path the Application ingress domain webhook is registered on.
+const IngressDomainValidatorPath = "/validate-nais-io-v1alpha1-application-ingress"
+
+// SetupApplicationWebhooks registers the validating and conversion webhooks for Application resources with the manager.
+// Validation is implemented by nais_io_v1alpha1.Application through webhook.Validator.
+//
+// Allowed ingress domains are cluster specific, and are checked by a separate webhook on IngressDomainValidatorPath.
+// It is only registered if any domains are given.
+func SetupApplicationWebhooks(mgr ctrl.Manager, allowedIngressDomains []string) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&nais_io_v1alpha1.Application{}).
+		Complete()
+	if err != nil {
+		return err
+	}
+
+	if len(allowedIngressDomains) > 0 {
+		mgr.GetWebhookServer().Register(IngressDomainValidatorPath, NewIngressDomainValidatingWebhook(allowedIngressDomains))
+	}
+
+	return nil
+}
+
+// IngressDomainValidator is an admission handler that rejects Application resources with ingresses outside the allowed domains.
+type IngressDomainValidator struct {
+	// Ingress hosts must be equal to, or a subdomain of, one of these domains.
+	AllowedIngressDomains []string
+
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &IngressDomainValidator{}
+var _ admission.DecoderInjector = &IngressDomainValidator{}
+
+func NewIngressDomainValidatingWebhook(allowedIngressDomains []string) *admission.Webhook {
+	return &admission.Webhook{
+		Handler: &IngressDomainValidator{
+			AllowedIngressDomains: allowedIngressDomains,
+		},
+	}
+}
+
+// InjectDecoder is called by controller-runtime when the webhook is registered.
+func (v *IngressDomainValidator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+func (v *IngressDomainValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != v1beta1.Create && req.Operation != v1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	app := &nais_io_v1alpha1.Application{}
+	if err := v.decoder.DecodeRaw(req.Object, app); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	errs := app.ValidateIngressDomains(v.AllowedIngressDomains)
+	if len(errs) == 0 {
+		return admission.Allowed("")
+	}
+
+	gk := nais_io_v1alpha1.GroupVersion.WithKind("Application").GroupKind()
+	status := apierrors.NewInvalid(gk, app.GetName(), errs).ErrStatus
+	return admission.Response{
+		AdmissionResponse: v1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
+}
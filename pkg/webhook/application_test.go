@@ -0,0 +1,140 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/liberator/pkg/scheme"
+	"github.com/nais/liberator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func application(ingresses ...nais_io_v1.Ingress) *nais_io_v1alpha1.Application {
+	return &nais_io_v1alpha1.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Application",
+			APIVersion: "nais.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapplication",
+			Namespace: "myteam",
+		},
+		Spec: nais_io_v1alpha1.ApplicationSpec{
+			Image:     "docker.pkg.github.com/nais/testapp/testapp:latest",
+			Ingresses: ingresses,
+		},
+	}
+}
+
+func request(t *testing.T, operation v1beta1.Operation, app, old *nais_io_v1alpha1.Application) admission.Request {
+	raw := func(app *nais_io_v1alpha1.Application) runtime.RawExtension {
+		if app == nil {
+			return runtime.RawExtension{}
+		}
+		data, err := json.Marshal(app)
+		assert.NoError(t, err)
+		return runtime.RawExtension{Raw: data}
+	}
+	return admission.Request{
+		AdmissionRequest: v1beta1.AdmissionRequest{
+			Operation: operation,
+			Object:    raw(app),
+			OldObject: raw(old),
+		},
+	}
+}
+
+func decoder(t *testing.T) *admission.Decoder {
+	sch, err := scheme.All()
+	assert.NoError(t, err)
+	decoder, err := admission.NewDecoder(sch)
+	assert.NoError(t, err)
+	return decoder
+}
+
+func TestSetupApplicationWebhooks(t *testing.T) {
+	t.Run("without allowed ingress domains", func(t *testing.T) {
+		mgr := manager(t)
+		assert.NoError(t, webhook.SetupApplicationWebhooks(mgr, nil))
+		assert.Equal(t, webhook.ApplicationValidatorPath, handledPath(mgr, webhook.ApplicationValidatorPath))
+		assert.Equal(t, webhook.ConversionPath, handledPath(mgr, webhook.ConversionPath))
+		assert.Empty(t, handledPath(mgr, webhook.IngressDomainValidatorPath))
+	})
+
+	t.Run("with allowed ingress domains", func(t *testing.T) {
+		mgr := manager(t)
+		assert.NoError(t, webhook.SetupApplicationWebhooks(mgr, []string{"nav.no"}))
+		assert.Equal(t, webhook.IngressDomainValidatorPath, handledPath(mgr, webhook.IngressDomainValidatorPath))
+	})
+
+	t.Run("conversion can be registered separately", func(t *testing.T) {
+		mgr := manager(t)
+		assert.NoError(t, webhook.SetupApplicationConversion(mgr))
+		assert.NoError(t, webhook.SetupApplicationWebhooks(mgr, nil))
+	})
+}
+
+func TestApplicationValidatingWebhook(t *testing.T) {
+	validator := admission.ValidatingWebhookFor(&nais_io_v1alpha1.Application{})
+	assert.NoError(t, validator.Handler.(admission.DecoderInjector).InjectDecoder(decoder(t)))
+
+	ctx := context.Background()
+
+	t.Run("valid application is allowed", func(t *testing.T) {
+		response := validator.Handle(ctx, request(t, v1beta1.Create, application("https://myapp.example.com"), nil))
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("invalid update is denied", func(t *testing.T) {
+		old := application("https://myapp.nav.no")
+		app := application("https://myapp.nav.no", "https://myapp.nav.no")
+		response := validator.Handle(ctx, request(t, v1beta1.Update, app, old))
+		assert.False(t, response.Allowed)
+		assert.Contains(t, response.Result.Reason, "spec.ingresses[1]")
+	})
+
+	t.Run("deletion is always allowed", func(t *testing.T) {
+		response := validator.Handle(ctx, request(t, v1beta1.Delete, nil, application("http://invalid")))
+		assert.True(t, response.Allowed)
+	})
+}
+
+func TestIngressDomainValidator_Handle(t *testing.T) {
+	validator := &webhook.IngressDomainValidator{
+		AllowedIngressDomains: []string{"nav.no"},
+	}
+	assert.NoError(t, validator.InjectDecoder(decoder(t)))
+
+	ctx := context.Background()
+
+	t.Run("ingress within allowed domains is allowed", func(t *testing.T) {
+		response := validator.Handle(ctx, request(t, v1beta1.Create, application("https://myapp.nav.no"), nil))
+		assert.True(t, response.Allowed)
+	})
+
+	t.Run("ingress outside allowed domains is denied", func(t *testing.T) {
+		response := validator.Handle(ctx, request(t, v1beta1.Create, application("https://myapp.example.com"), nil))
+		assert.False(t, response.Allowed)
+		assert.Len(t, response.Result.Details.Causes, 1)
+		assert.Equal(t, "spec.ingresses[0]", response.Result.Details.Causes[0].Field)
+	})
+
+	t.Run("ingress outside allowed domains is denied on update", func(t *testing.T) {
+		old := application("https://myapp.nav.no")
+		app := application("https://myapp.example.com")
+		response := validator.Handle(ctx, request(t, v1beta1.Update, app, old))
+		assert.False(t, response.Allowed)
+	})
+
+	t.Run("deletion is always allowed", func(t *testing.T) {
+		response := validator.Handle(ctx, request(t, v1beta1.Delete, nil, application("https://myapp.example.com")))
+		assert.True(t, response.Allowed)
+	})
+}
@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// ConversionPath is the path controller-runtime serves CRD conversion requests on.
+// The `conversion.webhookClientConfig` of every CRD served in more than one version must point here.
+const ConversionPath = "/convert"
+
+// SetupApplicationConversion registers the conversion webhook for Application resources with the manager.
+// Both nais.io/v1 and nais.io/v1alpha1 must be registered in the manager's scheme.
+func SetupApplicationConversion(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&nais_io_v1.Application{}).
+		Complete()
+}
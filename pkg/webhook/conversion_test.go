@@ -0,0 +1,41 @@
+package webhook_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nais/liberator/pkg/scheme"
+	"github.com/nais/liberator/pkg/webhook"
+)
+
+func manager(t *testing.T) ctrl.Manager {
+	sch, err := scheme.All()
+	assert.NoError(t, err)
+
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "localhost"}, ctrl.Options{
+		Scheme:             sch,
+		MetricsBindAddress: "0",
+		MapperProvider: func(c *rest.Config) (meta.RESTMapper, error) {
+			return meta.NewDefaultRESTMapper(nil), nil
+		},
+	})
+	assert.NoError(t, err)
+	return mgr
+}
+
+// Returns the path pattern the webhook server routes requests for the given path to.
+func handledPath(mgr ctrl.Manager, path string) string {
+	_, pattern := mgr.GetWebhookServer().WebhookMux.Handler(httptest.NewRequest("POST", path, nil))
+	return pattern
+}
+
+func TestSetupApplicationConversion(t *testing.T) {
+	mgr := manager(t)
+	assert.NoError(t, webhook.SetupApplicationConversion(mgr))
+	assert.Equal(t, webhook.ConversionPath, handledPath(mgr, webhook.ConversionPath))
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	aiven_nais_io_v1 "github.com/nais/liberator/pkg/apis/aiven.nais.io/v1"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// SetupCredentialWebhooks registers the validating webhooks for resources that produce credential secrets with the manager:
+// AzureAdApplication, MaskinportenClient, IDPortenClient, Jwker and AivenApplication.
+// These reject secret key prefixes that yield invalid environment variable names.
+func SetupCredentialWebhooks(mgr ctrl.Manager) error {
+	resources := []runtime.Object{
+		&nais_io_v1.AzureAdApplication{},
+		&nais_io_v1.MaskinportenClient{},
+		&nais_io_v1.IDPortenClient{},
+		&nais_io_v1.Jwker{},
+		&aiven_nais_io_v1.AivenApplication{},
+	}
+	for _, resource := range resources {
+		err := ctrl.NewWebhookManagedBy(mgr).
+			For(resource).
+			Complete()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nais/liberator/pkg/webhook"
+)
+
+func TestSetupCredentialWebhooks(t *testing.T) {
+	mgr := manager(t)
+	assert.NoError(t, webhook.SetupCredentialWebhooks(mgr))
+
+	for _, path := range []string{
+		"/validate-nais-io-v1-azureadapplication",
+		"/validate-nais-io-v1-maskinportenclient",
+		"/validate-nais-io-v1-idportenclient",
+		"/validate-nais-io-v1-jwker",
+		"/validate-aiven-nais-io-v1-aivenapplication",
+	} {
+		assert.Equal(t, path, handledPath(mgr, path))
+	}
+}